@@ -65,8 +65,87 @@ func App() *buffalo.App {
 		})
 		v1 := app.Group("/v1")
 
+		// registered before the swagger UI, the member types are read at runtime
+		v1.GET("/doc/doc.json", SwaggerDoc)
 		v1.GET("/doc/{doc:.*}", buffaloSwagger.WrapHandler(swaggerFiles.Handler))
+		v1.GET("/member-types", MemberTypesList)
+		// registered before the resource, "schema" is not a custom_field_id
+		v1.GET("/custom-fields/schema", CustomFieldsSchema)
+		v1.Resource("/custom-fields", CustomFieldsResource{})
+		v1.Resource("/checklist-templates", ChecklistTemplatesResource{})
+		v1.Resource("/roles", RolesResource{})
+		// registered before the resource, "changes" is not a member_id
+		v1.GET("/members/changes", MemberChanges)
+		v1.GET("/members/duplicates", MemberDuplicates)
+		v1.PUT("/members/by-external/{system}/{external_id}", MemberUpsertByExternal)
 		v1.Resource("/members", MembersResource{})
+		v1.GET("/members/{member_id}/reports", MemberReports)
+		v1.GET("/members/{member_id}/chain", MemberChain)
+		v1.Resource("/members/{member_id}/assignments", AssignmentsResource{})
+		v1.GET("/members/{member_id}/contracts", MemberContracts)
+		v1.POST("/members/{member_id}/contracts/extend", MemberContractExtend)
+		v1.POST("/members/{member_id}/convert", MemberConvert)
+		v1.POST("/members/{member_id}/merge", MemberMerge)
+		v1.GET("/members/{member_id}/external-ids", MemberExternalIDs)
+		v1.PUT("/members/{member_id}/external-ids/{system}", MemberExternalIDSet)
+		v1.DELETE("/members/{member_id}/external-ids/{system}", MemberExternalIDDelete)
+		v1.GET("/members/{member_id}/transitions", MemberTransitions)
+		v1.POST("/members/{member_id}/transitions", MemberTransition)
+		v1.GET("/members/{member_id}/checklists", MemberChecklists)
+		v1.PUT("/members/{member_id}/checklists/{checklist_id}/items/{item_id}", MemberChecklistItemUpdate)
+		v1.GET("/orgchart", OrgChart)
+		v1.GET("/orgchart.dot", OrgChartDOT)
+		v1.GET("/orgchart.mmd", OrgChartMermaid)
+
+		// the stream can last for hours, it must not hold a transaction
+		v1.GET("/events/stream", EventsStream)
+		v1.Middleware.Skip(popmw.Transaction(models.DB), EventsStream)
+
+		v1.Resource("/teams", TeamsResource{})
+		v1.GET("/teams/{team_id}/members", TeamMembersList)
+		v1.POST("/teams/{team_id}/members", TeamMembersAdd)
+		v1.DELETE("/teams/{team_id}/members/{member_id}", TeamMembersRemove)
+		v1.POST("/teams/{team_id}/move", TeamMove)
+		v1.GET("/teams/{team_id}/path", TeamPath)
+
+		v1.GET("/contracts/expiring", ContractsExpiring)
+
+		v1.Resource("/projects", ProjectsResource{})
+		v1.POST("/staffing/search", StaffingSearch)
+
+		v1.Resource("/webhooks", WebhooksResource{})
+		v1.GET("/webhooks/{webhook_id}/deliveries", WebhookDeliveries)
+		v1.POST("/webhooks/{webhook_id}/deliveries/{delivery_id}/redeliver", WebhookRedeliver)
+
+		v1.GET("/graphql", GraphQL)
+		v1.POST("/graphql", GraphQL)
+
+		// SCIM 2.0 provisioning, outside of the /v1 API
+		scimV2 := app.Group("/scim/v2")
+		scimV2.Use(scimAuth)
+		scimV2.GET("/ServiceProviderConfig", ScimServiceProviderConfig)
+		scimV2.GET("/ResourceTypes", ScimResourceTypesList)
+		scimV2.GET("/ResourceTypes/{resource_type_id}", ScimResourceTypesShow)
+		scimV2.GET("/Schemas", ScimSchemasList)
+		scimV2.GET("/Schemas/{schema_id}", ScimSchemasShow)
+		scimV2.GET("/Users", ScimUsersList)
+		scimV2.POST("/Users", ScimUsersCreate)
+		scimV2.GET("/Users/{user_id}", ScimUsersShow)
+		scimV2.PUT("/Users/{user_id}", ScimUsersReplace)
+		scimV2.PATCH("/Users/{user_id}", ScimUsersPatch)
+		scimV2.DELETE("/Users/{user_id}", ScimUsersDelete)
+		scimV2.GET("/Groups", ScimGroupsList)
+		scimV2.POST("/Groups", ScimGroupsCreate)
+		scimV2.GET("/Groups/{group_id}", ScimGroupsShow)
+		scimV2.PUT("/Groups/{group_id}", ScimGroupsReplace)
+		scimV2.PATCH("/Groups/{group_id}", ScimGroupsPatch)
+		scimV2.DELETE("/Groups/{group_id}", ScimGroupsDelete)
+
+		// Dispatch the member events from the outbox, send
+		// the webhook deliveries and alert the contracts ending in the background
+		registerOutboxWorker(app)
+		registerWebhooksWorker(app)
+		registerContractsWorker(app)
 	}
 
 	return app
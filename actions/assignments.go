@@ -0,0 +1,231 @@
+package actions
+
+import (
+	"fmt"
+	"net/http"
+	"team_manager/models"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gobuffalo/x/responder"
+)
+
+// AssignmentsResource is the resource for the Assignment model (CRUD),
+// under the member staffed
+type AssignmentsResource struct {
+	buffalo.Resource
+}
+
+// List gets the Assignments of a Member.
+// @Summary List the assignments of a member
+// @ID list-member-assignments
+// @Param member_id path string true "Member ID"
+// @Param page query integer false "Go to the page"
+// @Param per_page query integer false "How many assignment per pages"
+// @Produce json,xml
+// @Success 200 {object} models.Assignments
+// @Failure 404,500
+// @Router /members/{member_id}/assignments [get]
+func (v AssignmentsResource) List(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	member := &models.Member{}
+	if err := tx.Find(member, c.Param("member_id")); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	assignments := models.Assignments{}
+
+	// Paginate results. Params "page" and "per_page" control pagination.
+	// Default values are "page=1" and "per_page=20".
+	q := tx.PaginateFromParams(c.Params()).Where("member_id = ?", member.ID).Order("start_date, created_at")
+
+	if err := q.All(&assignments); err != nil {
+		return err
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(200, r.JSON(assignments))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(200, r.XML(assignments))
+	}).Respond(c)
+}
+
+// Show gets the data for one Assignment of a Member.
+// @Summary Show an assignment
+// @ID show-member-assignment
+// @Produce json,xml
+// @Param member_id path string true "Member ID"
+// @Param assignment_id path string true "Assignment ID"
+// @Success 200 {object} models.Assignment
+// @Failure 404,500
+// @Router /members/{member_id}/assignments/{assignment_id} [get]
+func (v AssignmentsResource) Show(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	assignment, err := findAssignment(c, tx)
+	if err != nil {
+		return err
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(200, r.JSON(assignment))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(200, r.XML(assignment))
+	}).Respond(c)
+}
+
+// Create staffs a Member on a project.
+// @Summary Assign a member to a project
+// @Description The member cannot be allocated more than 100% at any time, across all its assignments.
+// @ID create-member-assignment
+// @Accept json,xml
+// @Produce json,xml
+// @Param member_id path string true "Member ID"
+// @Param assignment body models.Assignment true "Assignment Payload, member_id is read from the path"
+// @Success 201 {object} models.Assignment
+// @Failure 422 {object} validate.Errors
+// @Failure 404,500
+// @Router /members/{member_id}/assignments [post]
+func (v AssignmentsResource) Create(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	member := &models.Member{}
+	if err := tx.Find(member, c.Param("member_id")); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	// Allocate an empty Assignment
+	assignment := &models.Assignment{}
+
+	// Bind assignment to the request payload
+	if err := c.Bind(assignment); err != nil {
+		return err
+	}
+	assignment.MemberID = member.ID
+
+	// Validate the data from the request
+	verrs, err := tx.ValidateAndCreate(assignment)
+	if err != nil {
+		return err
+	}
+
+	if verrs.HasAny() {
+		return responder.Wants("json", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.JSON(verrs))
+		}).Wants("xml", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.XML(verrs))
+		}).Respond(c)
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusCreated, r.JSON(assignment))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusCreated, r.XML(assignment))
+	}).Respond(c)
+}
+
+// Update changes an Assignment of a Member.
+// @Summary Update an assignment
+// @ID update-member-assignment
+// @Accept json,xml
+// @Produce json,xml
+// @Param member_id path string true "Member ID"
+// @Param assignment_id path string true "Assignment ID"
+// @Param assignment body models.Assignment true "Assignment Payload, member_id is read from the path"
+// @Success 200 {object} models.Assignment
+// @Failure 422 {object} validate.Errors
+// @Failure 404,500
+// @Router /members/{member_id}/assignments/{assignment_id} [put]
+func (v AssignmentsResource) Update(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	assignment, err := findAssignment(c, tx)
+	if err != nil {
+		return err
+	}
+
+	// Bind Assignment to the request payload
+	memberID := assignment.MemberID
+	if err := c.Bind(assignment); err != nil {
+		return err
+	}
+	assignment.MemberID = memberID
+
+	verrs, err := tx.ValidateAndUpdate(assignment)
+	if err != nil {
+		return err
+	}
+
+	if verrs.HasAny() {
+		return responder.Wants("json", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.JSON(verrs))
+		}).Wants("xml", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.XML(verrs))
+		}).Respond(c)
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.JSON(assignment))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.XML(assignment))
+	}).Respond(c)
+}
+
+// Destroy deletes an Assignment of a Member.
+// @Summary Delete an assignment
+// @ID delete-member-assignment
+// @Param member_id path string true "Member ID"
+// @Param assignment_id path string true "Assignment ID"
+// @Success 204
+// @Failure 404,500
+// @Router /members/{member_id}/assignments/{assignment_id} [delete]
+func (v AssignmentsResource) Destroy(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	assignment, err := findAssignment(c, tx)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Destroy(assignment); err != nil {
+		return err
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusNoContent, nil)
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusNoContent, nil)
+	}).Respond(c)
+}
+
+// findAssignment finds the assignment of the request,
+// it is not found when it belongs to another member
+func findAssignment(c buffalo.Context, tx *pop.Connection) (*models.Assignment, error) {
+	assignment := &models.Assignment{}
+	err := tx.Where("member_id::text = ?", c.Param("member_id")).Find(assignment, c.Param("assignment_id"))
+	if err != nil {
+		return nil, c.Error(http.StatusNotFound, err)
+	}
+	return assignment, nil
+}
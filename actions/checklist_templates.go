@@ -0,0 +1,211 @@
+package actions
+
+import (
+	"fmt"
+	"net/http"
+	"team_manager/models"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gobuffalo/x/responder"
+)
+
+// ChecklistTemplatesResource is the resource for the ChecklistTemplate model (CRUD)
+type ChecklistTemplatesResource struct {
+	buffalo.Resource
+}
+
+// List gets all ChecklistTemplates.
+// @Summary List checklist templates
+// @ID list-checklist-templates
+// @Param page query integer false "Go to the page"
+// @Param per_page query integer false "How many checklist template per pages"
+// @Produce json,xml
+// @Success 200 {object} models.ChecklistTemplates
+// @Failure 500
+// @Router /checklist-templates [get]
+func (v ChecklistTemplatesResource) List(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	templates := models.ChecklistTemplates{}
+
+	// Paginate results. Params "page" and "per_page" control pagination.
+	// Default values are "page=1" and "per_page=20".
+	q := tx.PaginateFromParams(c.Params()).Order("name")
+
+	// Retrieve all ChecklistTemplates from the DB
+	if err := q.All(&templates); err != nil {
+		return err
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(200, r.JSON(templates))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(200, r.XML(templates))
+	}).Respond(c)
+}
+
+// Show gets the data for one ChecklistTemplate.
+// @Summary Show a checklist template
+// @ID show-checklist-template
+// @Produce json,xml
+// @Param checklist_template_id path string true "Checklist template ID"
+// @Success 200 {object} models.ChecklistTemplate
+// @Failure 404,500
+// @Router /checklist-templates/{checklist_template_id} [get]
+func (v ChecklistTemplatesResource) Show(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	// Allocate an empty ChecklistTemplate
+	template := &models.ChecklistTemplate{}
+
+	// To find the ChecklistTemplate the parameter checklist_template_id is used.
+	if err := tx.Find(template, c.Param("checklist_template_id")); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(200, r.JSON(template))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(200, r.XML(template))
+	}).Respond(c)
+}
+
+// Create adds a ChecklistTemplate to the DB.
+// @Summary Create a new checklist template
+// @Description An onboarding template is applied to the members created, an offboarding one to the members moving to offboarding. The template applies to the member_types listed, or to all the types when there is none. Each item can have an owner, a member.
+// @ID create-checklist-template
+// @Accept json,xml
+// @Produce json,xml
+// @Param checklist_template body models.ChecklistTemplate true "Checklist Template Payload"
+// @Success 201 {object} models.ChecklistTemplate
+// @Failure 422 {object} validate.Errors
+// @Failure 500
+// @Router /checklist-templates [post]
+func (v ChecklistTemplatesResource) Create(c buffalo.Context) error {
+	// Allocate an empty ChecklistTemplate
+	template := &models.ChecklistTemplate{}
+
+	// Bind template to the request payload
+	if err := c.Bind(template); err != nil {
+		return err
+	}
+
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	// Validate the data from the request
+	verrs, err := tx.ValidateAndCreate(template)
+	if err != nil {
+		return err
+	}
+
+	if verrs.HasAny() {
+		return responder.Wants("json", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.JSON(verrs))
+		}).Wants("xml", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.XML(verrs))
+		}).Respond(c)
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusCreated, r.JSON(template))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusCreated, r.XML(template))
+	}).Respond(c)
+}
+
+// Update changes a ChecklistTemplate in the DB.
+// @Summary Update a checklist template
+// @Description The checklists already created from the template are not changed.
+// @ID update-checklist-template
+// @Accept json,xml
+// @Produce json,xml
+// @Param checklist_template_id path string true "Checklist template ID"
+// @Param checklist_template body models.ChecklistTemplate true "Checklist Template Payload"
+// @Success 200 {object} models.ChecklistTemplate
+// @Failure 422 {object} validate.Errors
+// @Failure 404,500
+// @Router /checklist-templates/{checklist_template_id} [put]
+func (v ChecklistTemplatesResource) Update(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	// Allocate an empty ChecklistTemplate
+	template := &models.ChecklistTemplate{}
+
+	if err := tx.Find(template, c.Param("checklist_template_id")); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	// Bind ChecklistTemplate to the request payload
+	if err := c.Bind(template); err != nil {
+		return err
+	}
+
+	verrs, err := tx.ValidateAndUpdate(template)
+	if err != nil {
+		return err
+	}
+
+	if verrs.HasAny() {
+		return responder.Wants("json", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.JSON(verrs))
+		}).Wants("xml", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.XML(verrs))
+		}).Respond(c)
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.JSON(template))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.XML(template))
+	}).Respond(c)
+}
+
+// Destroy deletes a ChecklistTemplate from the DB, the checklists created from it are kept.
+// @Summary Delete a checklist template
+// @ID delete-checklist-template
+// @Param checklist_template_id path string true "Checklist template ID"
+// @Success 204
+// @Failure 404,500
+// @Router /checklist-templates/{checklist_template_id} [delete]
+func (v ChecklistTemplatesResource) Destroy(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	// Allocate an empty ChecklistTemplate
+	template := &models.ChecklistTemplate{}
+
+	// To find the ChecklistTemplate the parameter checklist_template_id is used.
+	if err := tx.Find(template, c.Param("checklist_template_id")); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	if err := tx.Destroy(template); err != nil {
+		return err
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusNoContent, nil)
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusNoContent, nil)
+	}).Respond(c)
+}
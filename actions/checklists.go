@@ -0,0 +1,101 @@
+package actions
+
+import (
+	"fmt"
+	"net/http"
+	"team_manager/models"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gobuffalo/x/responder"
+	"github.com/gofrs/uuid"
+)
+
+// MemberChecklists lists the checklists of a Member.
+// @Summary List the checklists of a member
+// @Description The onboarding checklists are created with the member, the offboarding ones when it moves to offboarding, from the checklist templates of its type. A checklist is completed once all its items are.
+// @ID list-member-checklists
+// @Param member_id path string true "Member ID"
+// @Produce json,xml
+// @Success 200 {object} models.Checklists
+// @Failure 404,500
+// @Router /members/{member_id}/checklists [get]
+func MemberChecklists(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	member := &models.Member{}
+	if err := tx.Find(member, c.Param("member_id")); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	checklists, err := models.FindChecklists(tx, member.ID)
+	if err != nil {
+		return err
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.JSON(checklists))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.XML(checklists))
+	}).Respond(c)
+}
+
+// MemberChecklistItemUpdate assigns an item of a checklist and marks it done or not.
+// @Summary Update an item of a checklist
+// @ID update-member-checklist-item
+// @Accept json,xml
+// @Produce json,xml
+// @Param member_id path string true "Member ID"
+// @Param checklist_id path string true "Checklist ID"
+// @Param item_id path string true "Item ID"
+// @Param change body models.ChecklistItemChange true "Item change"
+// @Success 200 {object} models.Checklist
+// @Failure 422 {object} validate.Errors
+// @Failure 404,500
+// @Router /members/{member_id}/checklists/{checklist_id}/items/{item_id} [put]
+func MemberChecklistItemUpdate(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	checklist := &models.Checklist{}
+	err := tx.Eager("Items").Where("member_id = ?", c.Param("member_id")).Find(checklist, c.Param("checklist_id"))
+	if err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	itemID, err := uuid.FromString(c.Param("item_id"))
+	if err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	change := models.ChecklistItemChange{}
+	if err := c.Bind(&change); err != nil {
+		return err
+	}
+
+	verrs, err := checklist.UpdateItem(tx, itemID, change)
+	if err != nil {
+		return err
+	}
+
+	if verrs.HasAny() {
+		return responder.Wants("json", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.JSON(verrs))
+		}).Wants("xml", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.XML(verrs))
+		}).Respond(c)
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.JSON(checklist))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.XML(checklist))
+	}).Respond(c)
+}
@@ -0,0 +1,44 @@
+package actions
+
+import (
+	"encoding/json"
+	"net/http"
+	"team_manager/models"
+)
+
+func (as *ActionSuite) Test_ChecklistTemplatesResource_Create() {
+	t := models.ChecklistTemplate{Name: "Onboarding", Kind: models.ChecklistOnboarding, Items: models.ChecklistTemplateItems{{Title: "Laptop"}}}
+	res := as.JSON("/v1/checklist-templates").Post(t)
+	as.Equal(http.StatusCreated, res.Code)
+
+	res = as.JSON("/v1/checklist-templates").Post(t)
+	as.Equal(http.StatusUnprocessableEntity, res.Code)
+}
+
+func (as *ActionSuite) Test_MemberChecklists() {
+	as.NoError(models.DB.Create(&models.ChecklistTemplate{Name: "Onboarding", Kind: models.ChecklistOnboarding, Items: models.ChecklistTemplateItems{{Title: "Laptop"}}}))
+
+	m := &models.Member{Name: "Member Name", Type: "employee", Role: "DevOps"}
+	as.NoError(models.DB.Create(m))
+
+	res := as.JSON("/v1/members/" + m.ID.String() + "/checklists").Get()
+	as.Equal(http.StatusOK, res.Code)
+
+	checklists := models.Checklists{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &checklists))
+	as.Equal(1, len(checklists))
+	as.Equal(1, len(checklists[0].Items))
+
+	c, item := checklists[0], checklists[0].Items[0]
+	res = as.JSON("/v1/members/" + m.ID.String() + "/checklists/" + c.ID.String() + "/items/" + item.ID.String()).Put(models.ChecklistItemChange{Done: true})
+	as.Equal(http.StatusOK, res.Code)
+
+	checklist := models.Checklist{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &checklist))
+	as.True(checklist.CompletedAt.Valid)
+	as.True(checklist.Items[0].CompletedAt.Valid)
+
+	// the checklist must be one of the member
+	res = as.JSON("/v1/members/" + c.ID.String() + "/checklists/" + c.ID.String() + "/items/" + item.ID.String()).Put(models.ChecklistItemChange{Done: true})
+	as.Equal(http.StatusNotFound, res.Code)
+}
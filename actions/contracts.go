@@ -0,0 +1,262 @@
+package actions
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"team_manager/models"
+	"time"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/envy"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gobuffalo/x/responder"
+)
+
+// alertContractsJob records the contract.expiring and contract.expired events
+const alertContractsJob = "contracts:alert"
+
+var (
+	// contractAlertsInterval is how often the contracts are checked
+	contractAlertsInterval = time.Hour
+	// contractExpiringWithin is the default window of the expiring contracts
+	contractExpiringWithin = "30d"
+)
+
+// ContractsExpiring lists the contracts ending soon.
+// @Summary List the expiring contracts
+// @Description Returns the contractors whose contract ends from today to the end of the window, the first to end first.
+// @ID list-expiring-contracts
+// @Param within query string false "Window, in days (30d or 30) or weeks (4w), 30d by default"
+// @Produce json,xml
+// @Success 200 {object} models.ExpiringContracts
+// @Failure 400,500
+// @Router /contracts/expiring [get]
+func ContractsExpiring(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	within := c.Param("within")
+	if within == "" {
+		within = contractExpiringWithin
+	}
+
+	days, err := parseDays(within)
+	if err != nil {
+		return c.Error(http.StatusBadRequest, err)
+	}
+
+	contracts, err := models.FindExpiringContracts(tx, time.Now(), days)
+	if err != nil {
+		return err
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.JSON(contracts))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.XML(contracts))
+	}).Respond(c)
+}
+
+// parseDays reads a number of days: "30", "30d" or "4w"
+func parseDays(s string) (int, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	unit := 1
+	switch {
+	case strings.HasSuffix(s, "d"):
+		s = strings.TrimSuffix(s, "d")
+	case strings.HasSuffix(s, "w"):
+		s, unit = strings.TrimSuffix(s, "w"), 7
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid number of days %q", s)
+	}
+
+	return n * unit, nil
+}
+
+// registerContractsWorker registers the email sink and the job alerting the contracts
+// ending within CONTRACT_EXPIRING_WITHIN (30d by default) on the app worker
+func registerContractsWorker(app *buffalo.App) {
+	RegisterEventSink("email", &emailSink{
+		addr:     envy.Get("SMTP_ADDR", "localhost:25"),
+		from:     envy.Get("SMTP_FROM", "team-manager@localhost"),
+		to:       splitList(envy.Get("CONTRACT_ALERTS_TO", "")),
+		user:     envy.Get("SMTP_USER", ""),
+		password: envy.Get("SMTP_PASSWORD", ""),
+	})
+
+	within, err := parseDays(envy.Get("CONTRACT_EXPIRING_WITHIN", contractExpiringWithin))
+	if err != nil {
+		app.Logger.Fatal(err)
+	}
+
+	registerPeriodicJob(app, alertContractsJob, contractAlertsInterval, func() error {
+		return alertContracts(models.DB, time.Now, within)
+	})
+}
+
+// alertContracts records the contract events due, in one transaction
+func alertContracts(db *pop.Connection, now func() time.Time, within int) error {
+	return db.Transaction(func(tx *pop.Connection) error {
+		_, err := models.RecordContractAlerts(tx, now(), within)
+		return err
+	})
+}
+
+// emailSink emails the contract events to the recipients,
+// the other events are ignored
+type emailSink struct {
+	addr     string
+	from     string
+	to       []string
+	user     string
+	password string
+}
+
+// Dispatch sends the email of a contract event
+func (s *emailSink) Dispatch(tx *pop.Connection, e *models.MemberEvent) error {
+	if (e.Type != models.ContractExpiring && e.Type != models.ContractExpired) || len(s.to) == 0 {
+		return nil
+	}
+
+	m := models.Member{}
+	if err := json.Unmarshal(e.Payload, &m); err != nil {
+		return err
+	}
+
+	end := ""
+	if m.ContractEnd.Valid {
+		end = m.ContractEnd.Time.Format("2006-01-02")
+	}
+
+	// a line break in the name would add headers to the email
+	name := strings.NewReplacer("\r", " ", "\n", " ").Replace(m.Name)
+	subject := fmt.Sprintf("The contract of %s ends on %s", name, end)
+	if e.Type == models.ContractExpired {
+		subject = fmt.Sprintf("The contract of %s ended on %s", name, end)
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", s.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(s.to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	fmt.Fprintf(&msg, "%s.\r\n\r\nMember: %s\r\nContract: %s\r\n", subject, m.ID, m.ContractDuration)
+
+	var auth smtp.Auth
+	if s.user != "" {
+		host := strings.Split(s.addr, ":")[0]
+		auth = smtp.PlainAuth("", s.user, s.password, host)
+	}
+
+	return smtp.SendMail(s.addr, auth, s.from, s.to, msg.Bytes())
+}
+
+// splitList splits a comma separated list, the blank items are dropped
+func splitList(s string) []string {
+	list := []string{}
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// MemberContracts lists the contract history of a Member.
+// @Summary List the contracts of a member
+// @Description Every contract period of the member, the oldest first. The current contract is the contract_start and contract_end of the member.
+// @ID list-member-contracts
+// @Param member_id path string true "Member ID"
+// @Produce json,xml
+// @Success 200 {object} models.Contracts
+// @Failure 404,500
+// @Router /members/{member_id}/contracts [get]
+func MemberContracts(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	member := &models.Member{}
+	if err := tx.Find(member, c.Param("member_id")); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	contracts, err := models.FindContracts(tx, member.ID)
+	if err != nil {
+		return err
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.JSON(contracts))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.XML(contracts))
+	}).Respond(c)
+}
+
+// MemberContractExtend extends, or renews, the contract of a contractor.
+// @Summary Extend the contract of a member
+// @Description Without contract_start, the contract is extended to the new contract_end. With it, the contract is renewed: a new period starts after the current end. Both are kept in the history with who approved them.
+// @ID extend-member-contract
+// @Accept json,xml
+// @Produce json,xml
+// @Param member_id path string true "Member ID"
+// @Param change body models.ContractChange true "Extension"
+// @Success 200 {object} models.Member
+// @Failure 422 {object} validate.Errors
+// @Failure 404,500
+// @Router /members/{member_id}/contracts/extend [post]
+func MemberContractExtend(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	member := &models.Member{}
+	if err := tx.Find(member, c.Param("member_id")); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	change := models.ContractChange{}
+	if err := c.Bind(&change); err != nil {
+		return err
+	}
+
+	verrs, err := member.ExtendContract(tx, change)
+	if err != nil {
+		return err
+	}
+
+	if verrs.HasAny() {
+		return responder.Wants("json", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.JSON(verrs))
+		}).Wants("xml", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.XML(verrs))
+		}).Respond(c)
+	}
+
+	if err := tx.Load(member, "Teams"); err != nil {
+		return err
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.JSON(member))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.XML(member))
+	}).Respond(c)
+}
@@ -0,0 +1,201 @@
+package actions
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strings"
+	"team_manager/models"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+)
+
+// startSMTPServer starts a local SMTP server accepting every message,
+// the messages received are sent on the channel
+func (as *ActionSuite) startSMTPServer() (string, chan string) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	as.NoError(err)
+
+	messages := make(chan string, 10)
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go serveSMTP(conn, messages)
+		}
+	}()
+
+	return l.Addr().String(), messages
+}
+
+// serveSMTP answers an SMTP session, just enough for net/smtp
+func serveSMTP(conn net.Conn, messages chan string) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(s string) { conn.Write([]byte(s + "\r\n")) }
+
+	reply("220 localhost ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		switch cmd := strings.ToUpper(strings.TrimSpace(line)); {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(cmd, "DATA"):
+			reply("354 end with <CRLF>.<CRLF>")
+			var msg strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				msg.WriteString(l)
+			}
+			messages <- msg.String()
+			reply("250 OK")
+		case strings.HasPrefix(cmd, "QUIT"):
+			reply("221 bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+// createContractor creates a contractor whose contract ends days after today
+func (as *ActionSuite) createContractor(name string, days int) *models.Member {
+	today := time.Now()
+	m := &models.Member{
+		Name:          name,
+		Type:          "contractor",
+		ContractStart: nulls.NewTime(today.AddDate(-1, 0, 0)),
+		ContractEnd:   nulls.NewTime(today.AddDate(0, 0, days)),
+	}
+
+	verrs, err := models.DB.ValidateAndCreate(m)
+	as.NoError(err)
+	as.False(verrs.HasAny())
+	return m
+}
+
+func (as *ActionSuite) Test_ContractsExpiring() {
+	as.createContractor("Ends Soon", 10)
+	as.createContractor("Ends Later", 40)
+
+	res := as.JSON("/v1/contracts/expiring").Get()
+	as.Equal(http.StatusOK, res.Code)
+	contracts := models.ExpiringContracts{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &contracts))
+	as.Equal(1, len(contracts))
+	as.Equal("Ends Soon", contracts[0].Member.Name)
+	as.Equal(10, contracts[0].DaysLeft)
+
+	res = as.JSON("/v1/contracts/expiring?within=6w").Get()
+	as.Equal(http.StatusOK, res.Code)
+	contracts = models.ExpiringContracts{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &contracts))
+	as.Equal(2, len(contracts))
+
+	res = as.JSON("/v1/contracts/expiring?within=soon").Get()
+	as.Equal(http.StatusBadRequest, res.Code)
+}
+
+func (as *ActionSuite) Test_ContractAlerts_Email() {
+	addr, messages := as.startSMTPServer()
+	RegisterEventSink("email", &emailSink{addr: addr, from: "team-manager@localhost", to: []string{"hr@example.com"}})
+
+	sinks := models.OutboxSinks
+	models.OutboxSinks = []string{"email"}
+	defer func() { models.OutboxSinks = sinks }()
+
+	m := as.createContractor("Ends Soon", 10)
+	as.NoError(alertContracts(models.DB, time.Now, 30))
+	as.NoError(dispatchOutbox(models.DB, time.Now))
+
+	select {
+	case msg := <-messages:
+		as.Contains(msg, "To: hr@example.com")
+		as.Contains(msg, "Subject: The contract of Ends Soon ends on "+m.ContractEnd.Time.Format("2006-01-02"))
+	case <-time.After(5 * time.Second):
+		as.Fail("no email received")
+	}
+
+	// the member events are not emailed
+	as.Empty(messages)
+	count, err := models.DB.Where("sink = ? AND status = ?", "email", models.OutboxDispatched).Count(&models.OutboxMessage{})
+	as.NoError(err)
+	as.Equal(2, count)
+}
+
+func (as *ActionSuite) Test_ContractAlerts_Email_Name() {
+	addr, messages := as.startSMTPServer()
+	RegisterEventSink("email", &emailSink{addr: addr, from: "team-manager@localhost", to: []string{"hr@example.com"}})
+
+	sinks := models.OutboxSinks
+	models.OutboxSinks = []string{"email"}
+	defer func() { models.OutboxSinks = sinks }()
+
+	as.createContractor("Ends Soon\r\nBcc: someone@example.com", 10)
+	as.createContractor("Zoë", 10)
+	as.NoError(alertContracts(models.DB, time.Now, 30))
+	as.NoError(dispatchOutbox(models.DB, time.Now))
+
+	received := []string{}
+	for len(received) < 2 {
+		select {
+		case msg := <-messages:
+			received = append(received, msg)
+		case <-time.After(5 * time.Second):
+			as.FailNow("no email received")
+		}
+	}
+
+	all := strings.Join(received, "")
+	as.NotContains(all, "\nBcc:")
+	as.Contains(all, "Subject: The contract of Ends Soon  Bcc: someone@example.com ends on")
+	as.Contains(all, "Subject: =?utf-8?q?The_contract_of_Zo=C3=AB_ends_on_")
+}
+
+func (as *ActionSuite) Test_parseDays() {
+	for s, days := range map[string]int{"30": 30, "30d": 30, "4w": 28, " 7D ": 7} {
+		n, err := parseDays(s)
+		as.NoError(err)
+		as.Equal(days, n, s)
+	}
+
+	_, err := parseDays("-1d")
+	as.Error(err)
+}
+
+func (as *ActionSuite) Test_MemberContractExtend() {
+	m := as.createContractor("Extended", 10)
+	end := m.ContractEnd.Time.AddDate(0, 3, 0).Format(time.RFC3339)
+
+	res := as.JSON("/v1/members/" + m.ID.String() + "/contracts/extend").Post(map[string]string{"contract_end": end})
+	as.Equal(http.StatusUnprocessableEntity, res.Code)
+
+	res = as.JSON("/v1/members/" + m.ID.String() + "/contracts/extend").Post(map[string]string{"contract_end": end, "approved_by": "Jane Manager"})
+	as.Equal(http.StatusOK, res.Code)
+	member := models.Member{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &member))
+	as.Equal(end[:10], member.ContractEnd.Time.Format("2006-01-02"))
+
+	res = as.JSON("/v1/members/" + m.ID.String() + "/contracts").Get()
+	as.Equal(http.StatusOK, res.Code)
+	contracts := models.Contracts{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &contracts))
+	as.Equal(2, len(contracts))
+	as.Equal(models.ContractInitial, contracts[0].Kind)
+	as.Equal(models.ContractExtension, contracts[1].Kind)
+	as.Equal("Jane Manager", contracts[1].ApprovedBy)
+}
@@ -0,0 +1,244 @@
+package actions
+
+import (
+	"fmt"
+	"net/http"
+	"team_manager/models"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gobuffalo/x/responder"
+)
+
+// CustomFieldsResource is the resource for the CustomField model (CRUD)
+type CustomFieldsResource struct {
+	buffalo.Resource
+}
+
+// List gets all CustomFields.
+// @Summary List custom fields
+// @ID list-custom-fields
+// @Param page query integer false "Go to the page"
+// @Param per_page query integer false "How many custom field per pages"
+// @Produce json,xml
+// @Success 200 {object} models.CustomFields
+// @Failure 500
+// @Router /custom-fields [get]
+func (v CustomFieldsResource) List(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	fields := models.CustomFields{}
+
+	// Paginate results. Params "page" and "per_page" control pagination.
+	// Default values are "page=1" and "per_page=20".
+	q := tx.PaginateFromParams(c.Params()).Order("name")
+
+	// Retrieve all CustomFields from the DB
+	if err := q.All(&fields); err != nil {
+		return err
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(200, r.JSON(fields))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(200, r.XML(fields))
+	}).Respond(c)
+}
+
+// Show gets the data for one CustomField.
+// @Summary Show a custom field
+// @ID show-custom-field
+// @Produce json,xml
+// @Param custom_field_id path string true "Custom field ID"
+// @Success 200 {object} models.CustomField
+// @Failure 404,500
+// @Router /custom-fields/{custom_field_id} [get]
+func (v CustomFieldsResource) Show(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	// Allocate an empty CustomField
+	field := &models.CustomField{}
+
+	// To find the CustomField the parameter custom_field_id is used.
+	if err := tx.Find(field, c.Param("custom_field_id")); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(200, r.JSON(field))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(200, r.XML(field))
+	}).Respond(c)
+}
+
+// Create adds a CustomField to the DB.
+// @Summary Create a new custom field
+// @Description The name is the key of the value in the custom_fields of the members. The field applies to the member_types listed, or to all the types when there is none. An enum lists its options.
+// @ID create-custom-field
+// @Accept json,xml
+// @Produce json,xml
+// @Param custom_field body models.CustomField true "Custom Field Payload"
+// @Success 201 {object} models.CustomField
+// @Failure 422 {object} validate.Errors
+// @Failure 500
+// @Router /custom-fields [post]
+func (v CustomFieldsResource) Create(c buffalo.Context) error {
+	// Allocate an empty CustomField
+	field := &models.CustomField{}
+
+	// Bind field to the request payload
+	if err := c.Bind(field); err != nil {
+		return err
+	}
+
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	// Validate the data from the request
+	verrs, err := tx.ValidateAndCreate(field)
+	if err != nil {
+		return err
+	}
+
+	if verrs.HasAny() {
+		return responder.Wants("json", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.JSON(verrs))
+		}).Wants("xml", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.XML(verrs))
+		}).Respond(c)
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusCreated, r.JSON(field))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusCreated, r.XML(field))
+	}).Respond(c)
+}
+
+// Update changes a CustomField in the DB.
+// @Summary Update a custom field
+// @Description The name can not be changed. The values already saved are checked again when their member is updated.
+// @ID update-custom-field
+// @Accept json,xml
+// @Produce json,xml
+// @Param custom_field_id path string true "Custom field ID"
+// @Param custom_field body models.CustomField true "Custom Field Payload"
+// @Success 200 {object} models.CustomField
+// @Failure 422 {object} validate.Errors
+// @Failure 404,500
+// @Router /custom-fields/{custom_field_id} [put]
+func (v CustomFieldsResource) Update(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	// Allocate an empty CustomField
+	field := &models.CustomField{}
+
+	if err := tx.Find(field, c.Param("custom_field_id")); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	// Bind CustomField to the request payload
+	if err := c.Bind(field); err != nil {
+		return err
+	}
+
+	verrs, err := tx.ValidateAndUpdate(field)
+	if err != nil {
+		return err
+	}
+
+	if verrs.HasAny() {
+		return responder.Wants("json", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.JSON(verrs))
+		}).Wants("xml", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.XML(verrs))
+		}).Respond(c)
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.JSON(field))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.XML(field))
+	}).Respond(c)
+}
+
+// Destroy deletes a CustomField from the DB, along with the values of the members.
+// @Summary Delete a custom field
+// @ID delete-custom-field
+// @Param custom_field_id path string true "Custom field ID"
+// @Success 204
+// @Failure 404,500
+// @Router /custom-fields/{custom_field_id} [delete]
+func (v CustomFieldsResource) Destroy(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	// Allocate an empty CustomField
+	field := &models.CustomField{}
+
+	// To find the CustomField the parameter custom_field_id is used.
+	if err := tx.Find(field, c.Param("custom_field_id")); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	if err := tx.Destroy(field); err != nil {
+		return err
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusNoContent, nil)
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusNoContent, nil)
+	}).Respond(c)
+}
+
+// CustomFieldsSchema describes the custom_fields of the members as a JSON Schema.
+// @Summary JSON Schema of the custom fields
+// @Description Without a type, the schema covers the fields of every type and only requires the fields required for all of them.
+// @ID custom-fields-schema
+// @Produce json
+// @Param type query string false "Only the fields of this member type"
+// @Success 200 {object} object
+// @Failure 422 {object} validate.Errors
+// @Failure 500
+// @Router /custom-fields/schema [get]
+func CustomFieldsSchema(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	memberType := c.Param("type")
+	if memberType != "" && !models.IsMemberType(memberType) {
+		verrs := validate.NewErrors()
+		verrs.Add("type", memberType+" is not a member type.")
+		return c.Render(http.StatusUnprocessableEntity, r.JSON(verrs))
+	}
+
+	schema, err := models.CustomFieldsSchema(tx, memberType)
+	if err != nil {
+		return err
+	}
+
+	return c.Render(http.StatusOK, r.JSON(schema))
+}
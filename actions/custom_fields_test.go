@@ -0,0 +1,61 @@
+package actions
+
+import (
+	"encoding/json"
+	"net/http"
+	"team_manager/models"
+
+	"github.com/gobuffalo/pop/slices"
+)
+
+func (as *ActionSuite) Test_CustomFieldsResource_Create() {
+	res := as.JSON("/v1/custom-fields").Post(models.CustomField{Name: "tshirt_size", Type: models.CustomFieldEnum, Options: slices.String{"S", "M", "L"}})
+	as.Equal(http.StatusCreated, res.Code)
+
+	res = as.JSON("/v1/custom-fields").Post(models.CustomField{Name: "tshirt_size", Type: models.CustomFieldString})
+	as.Equal(http.StatusUnprocessableEntity, res.Code)
+}
+
+func (as *ActionSuite) Test_CustomFieldsResource_Update_Name() {
+	field := &models.CustomField{Name: "github", Type: models.CustomFieldString}
+	as.NoError(models.DB.Create(field))
+
+	field.Name = "github_handle"
+	res := as.JSON("/v1/custom-fields/" + field.ID.String()).Put(field)
+	as.Equal(http.StatusUnprocessableEntity, res.Code)
+}
+
+func (as *ActionSuite) Test_MembersResource_CustomFields() {
+	as.NoError(models.DB.Create(&models.CustomField{Name: "github", Type: models.CustomFieldString, Required: true}))
+
+	m := &models.Member{Name: "Member Name", Type: "employee", Role: "DevOps"}
+	res := as.JSON("/v1/members").Post(m)
+	as.Equal(http.StatusUnprocessableEntity, res.Code)
+
+	m.CustomFields = models.CustomValues{"github": "octocat"}
+	res = as.JSON("/v1/members").Post(m)
+	as.Equal(http.StatusCreated, res.Code)
+
+	res = as.JSON("/v1/members?custom=github:octocat").Get()
+	as.Equal(http.StatusOK, res.Code)
+
+	members := models.Members{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &members))
+	as.Equal(1, len(members))
+	as.Equal("octocat", members[0].CustomFields["github"])
+}
+
+func (as *ActionSuite) Test_CustomFieldsSchema() {
+	as.NoError(models.DB.Create(&models.CustomField{Name: "badge_number", Type: models.CustomFieldNumber, Required: true, MemberTypes: slices.String{"employee"}}))
+
+	res := as.JSON("/v1/custom-fields/schema?type=employee").Get()
+	as.Equal(http.StatusOK, res.Code)
+
+	schema := map[string]interface{}{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &schema))
+	as.Equal("object", schema["type"])
+	as.Equal([]interface{}{"badge_number"}, schema["required"])
+
+	res = as.JSON("/v1/custom-fields/schema?type=robot").Get()
+	as.Equal(http.StatusUnprocessableEntity, res.Code)
+}
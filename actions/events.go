@@ -0,0 +1,124 @@
+package actions
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"team_manager/models"
+	"time"
+
+	"github.com/gobuffalo/buffalo"
+)
+
+var (
+	// eventsPollInterval is how often the event log is read for new events
+	eventsPollInterval = time.Second
+	// eventsKeepAliveInterval is how often a comment is sent on an idle stream,
+	// so proxies do not close the connection
+	eventsKeepAliveInterval = 15 * time.Second
+)
+
+// eventsBatchSize is how many events are read from the log at a time
+const eventsBatchSize = 100
+
+// eventsStreamTypes are the events sent on the stream, the other
+// events of the log are skipped and their IDs never sent
+var eventsStreamTypes = []string{models.MemberCreated, models.MemberUpdated, models.MemberDeleted}
+
+// EventsStream pushes the member events as Server-Sent Events.
+// It runs outside of the request transaction, the stream lasts as long as the client is connected.
+// @Summary Stream of member events
+// @Description Server-Sent Events stream of member.created, member.updated and member.deleted events, the data is the event with the member as it was. The other events, like member.status_changed or contract.expiring, are not streamed, see the webhooks. Send the Last-Event-ID header (or last_event_id param) to resume a stream, otherwise only the new events are sent.
+// @ID events-stream
+// @Param Last-Event-ID header string false "ID of the last event received"
+// @Param last_event_id query string false "ID of the last event received, for clients that can not set headers"
+// @Produce text/event-stream
+// @Success 200 {object} models.MemberEvent
+// @Failure 400,500
+// @Router /events/stream [get]
+func EventsStream(c buffalo.Context) error {
+	lastID, err := lastEventID(c)
+	if err != nil {
+		return c.Error(http.StatusBadRequest, err)
+	}
+
+	if lastID < 0 {
+		if lastID, err = models.LastMemberEventID(models.DB); err != nil {
+			return err
+		}
+	}
+
+	res := c.Response()
+	flusher, ok := res.(http.Flusher)
+	if !ok {
+		return fmt.Errorf("streaming is not supported")
+	}
+
+	res.Header().Set("Content-Type", "text/event-stream")
+	res.Header().Set("Cache-Control", "no-cache")
+	res.Header().Set("Connection", "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+
+	// tell the client how long to wait before reconnecting
+	fmt.Fprintf(res, "retry: %d\n\n", eventsPollInterval.Milliseconds()*3)
+	flusher.Flush()
+
+	poll := time.NewTicker(eventsPollInterval)
+	defer poll.Stop()
+	keepAlive := time.NewTicker(eventsKeepAliveInterval)
+	defer keepAlive.Stop()
+
+	ctx := c.Request().Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-keepAlive.C:
+			fmt.Fprint(res, ": keep-alive\n\n")
+			flusher.Flush()
+		case <-poll.C:
+			for {
+				events, err := models.FindMemberEventsAfter(models.DB, lastID, eventsBatchSize, eventsStreamTypes...)
+				if err != nil {
+					c.Logger().Errorf("reading the member events: %v", err)
+					break
+				}
+
+				for _, e := range events {
+					// encoded on a single line, as a data field can not span lines
+					data, err := json.Marshal(e)
+					if err != nil {
+						return err
+					}
+					fmt.Fprintf(res, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+					lastID = e.ID
+				}
+				flusher.Flush()
+
+				if len(events) < eventsBatchSize {
+					break
+				}
+			}
+		}
+	}
+}
+
+// lastEventID reads the ID of the last event the client received,
+// -1 when the client starts a new stream
+func lastEventID(c buffalo.Context) (int64, error) {
+	id := c.Request().Header.Get("Last-Event-ID")
+	if id == "" {
+		id = c.Param("last_event_id")
+	}
+	if id == "" {
+		return -1, nil
+	}
+
+	last, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || last < 0 {
+		return 0, fmt.Errorf("invalid last event id %q", id)
+	}
+	return last, nil
+}
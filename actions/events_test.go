@@ -0,0 +1,79 @@
+package actions
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"team_manager/models"
+	"time"
+)
+
+// streamEvents reads the event stream for a little more than a poll interval
+func (as *ActionSuite) streamEvents(lastEventID string) *httptest.ResponseRecorder {
+	ctx, cancel := context.WithTimeout(context.Background(), eventsPollInterval+500*time.Millisecond)
+	defer cancel()
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/events/stream", nil).WithContext(ctx)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	res := httptest.NewRecorder()
+	as.App.ServeHTTP(res, req)
+	return res
+}
+
+func (as *ActionSuite) Test_EventsStream_Resume() {
+	m := &models.Member{Name: "Member Name", Type: "employee", Role: "DevOps"}
+	as.NoError(models.DB.Create(m))
+
+	m.Role = "Software Engineer"
+	as.NoError(models.DB.Update(m))
+
+	events := models.MemberEvents{}
+	as.NoError(models.DB.Order("id").All(&events))
+	as.Equal(2, len(events))
+
+	res := as.streamEvents(strconv.FormatInt(events[0].ID, 10))
+	as.Equal(http.StatusOK, res.Code)
+	as.Equal("text/event-stream", res.Header().Get("Content-Type"))
+
+	body := res.Body.String()
+	as.NotContains(body, "event: "+models.MemberCreated)
+	as.Contains(body, "id: "+strconv.FormatInt(events[1].ID, 10)+"\nevent: "+models.MemberUpdated)
+	as.Contains(body, `"role":"Software Engineer"`)
+}
+
+func (as *ActionSuite) Test_EventsStream_Types() {
+	m := &models.Member{Name: "Member Name", Type: "employee", Role: "DevOps", Status: models.StatusActive}
+	as.NoError(models.DB.Create(m))
+
+	verrs, err := m.Transition(models.DB, models.StatusChange{Status: models.StatusOnLeave})
+	as.NoError(err)
+	as.False(verrs.HasAny())
+
+	events := models.MemberEvents{}
+	as.NoError(models.DB.Order("id").All(&events))
+	as.Equal(3, len(events))
+	as.Equal(models.MemberStatusChanged, events[2].Type)
+
+	// the status change is in the log, not on the stream
+	body := as.streamEvents(strconv.FormatInt(events[0].ID, 10)).Body.String()
+	as.Contains(body, "event: "+models.MemberUpdated)
+	as.NotContains(body, "event: "+models.MemberStatusChanged)
+}
+
+func (as *ActionSuite) Test_EventsStream_OnlyNewEvents() {
+	m := &models.Member{Name: "Member Name", Type: "employee", Role: "DevOps"}
+	as.NoError(models.DB.Create(m))
+
+	res := as.streamEvents("")
+	as.Equal(http.StatusOK, res.Code)
+	as.NotContains(res.Body.String(), "event: ")
+}
+
+func (as *ActionSuite) Test_EventsStream_InvalidLastEventID() {
+	res := as.streamEvents("abc")
+	as.Equal(http.StatusBadRequest, res.Code)
+}
@@ -0,0 +1,244 @@
+package actions
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"team_manager/models"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gobuffalo/x/responder"
+)
+
+// MemberUpsertByExternal creates or updates the Member with an ID in another system.
+// @Summary Create or update a member by its external ID
+// @Description The member with the external ID in the system is updated like with PUT /members/{member_id}, or else a member is created with the external ID. Integrations do not have to look up the member ID first. Like with POST /members, a member with probable duplicates is not created unless force is set.
+// @ID upsert-member-by-external
+// @Accept json,xml
+// @Produce json,xml
+// @Param system path string true "System, like hris or payroll"
+// @Param external_id path string true "ID of the member in the system"
+// @Param override query boolean false "Allow the change of type"
+// @Param force query boolean false "Create the member even if it has probable duplicates"
+// @Param member body models.Member true "Member Payload"
+// @Success 200 {object} models.Member
+// @Success 201 {object} models.Member
+// @Failure 409 {object} models.DuplicateCandidates
+// @Failure 422 {object} validate.Errors
+// @Failure 500
+// @Router /members/by-external/{system}/{external_id} [put]
+func MemberUpsertByExternal(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	if err := models.LockExternalID(tx, c.Param("system"), c.Param("external_id")); err != nil {
+		return err
+	}
+
+	status := http.StatusOK
+	member, err := models.FindMemberByExternalID(tx, c.Param("system"), c.Param("external_id"))
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	var verrs *validate.Errors
+	if err == nil {
+		if verrs, err = bindAndUpdateMember(c, tx, member); err != nil {
+			return err
+		}
+	} else {
+		status = http.StatusCreated
+		var candidates models.DuplicateCandidates
+		if candidates, verrs, err = createMemberWithExternalID(c, tx, member); err != nil {
+			return err
+		}
+
+		if len(candidates) > 0 {
+			return responder.Wants("json", func(c buffalo.Context) error {
+				return c.Render(http.StatusConflict, r.JSON(candidates))
+			}).Wants("xml", func(c buffalo.Context) error {
+				return c.Render(http.StatusConflict, r.XML(candidates))
+			}).Respond(c)
+		}
+	}
+
+	if verrs.HasAny() {
+		return responder.Wants("json", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.JSON(verrs))
+		}).Wants("xml", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.XML(verrs))
+		}).Respond(c)
+	}
+
+	if err := tx.Load(member, "Teams"); err != nil {
+		return err
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(status, r.JSON(member))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(status, r.XML(member))
+	}).Respond(c)
+}
+
+// createMemberWithExternalID binds the request payload to a new member
+// and saves it along with the external ID of the path. The probable duplicates
+// of the member are returned instead, unless the creation is forced.
+func createMemberWithExternalID(c buffalo.Context, tx *pop.Connection, member *models.Member) (models.DuplicateCandidates, *validate.Errors, error) {
+	if err := c.Bind(member); err != nil {
+		return nil, nil, err
+	}
+
+	ext := &models.ExternalID{System: c.Param("system"), ExternalID: c.Param("external_id")}
+	verrs, err := ext.Validate(tx)
+	if err != nil || verrs.HasAny() {
+		return nil, verrs, err
+	}
+
+	if force, _ := strconv.ParseBool(c.Param("force")); !force {
+		candidates, err := models.FindDuplicates(tx, member, *ext)
+		if err != nil || len(candidates) > 0 {
+			return candidates, verrs, err
+		}
+	}
+
+	// an email already taken is a validation error
+	verrs, err = models.ConstraintErrors(tx.ValidateAndCreate(member))
+	if err != nil || verrs.HasAny() {
+		return nil, verrs, err
+	}
+
+	ext.MemberID = member.ID
+	return nil, verrs, tx.Create(ext)
+}
+
+// MemberExternalIDs lists the IDs of a Member in other systems.
+// @Summary List the external IDs of a member
+// @ID list-member-external-ids
+// @Param member_id path string true "Member ID"
+// @Produce json,xml
+// @Success 200 {object} models.ExternalIDs
+// @Failure 404,500
+// @Router /members/{member_id}/external-ids [get]
+func MemberExternalIDs(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	member := &models.Member{}
+	if err := tx.Find(member, c.Param("member_id")); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	ids, err := models.FindExternalIDs(tx, member.ID)
+	if err != nil {
+		return err
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.JSON(ids))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.XML(ids))
+	}).Respond(c)
+}
+
+// MemberExternalIDSet sets the ID of a Member in another system.
+// @Summary Set the external ID of a member in a system
+// @Description It replaces the ID the member had in the system, an ID belongs to a single member.
+// @ID set-member-external-id
+// @Accept json,xml
+// @Produce json,xml
+// @Param member_id path string true "Member ID"
+// @Param system path string true "System, like hris or payroll"
+// @Param external_id body models.ExternalID true "External ID, the system and the member are the ones of the path"
+// @Success 200 {object} models.ExternalID
+// @Failure 422 {object} validate.Errors
+// @Failure 404,500
+// @Router /members/{member_id}/external-ids/{system} [put]
+func MemberExternalIDSet(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	member := &models.Member{}
+	if err := tx.Find(member, c.Param("member_id")); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	// the ID of the member in the system, if it has one already
+	ext, err := models.FindExternalID(tx, member.ID, c.Param("system"))
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	if err := c.Bind(ext); err != nil {
+		return err
+	}
+	ext.MemberID, ext.System = member.ID, c.Param("system")
+
+	verrs, err := tx.ValidateAndSave(ext)
+	if err != nil {
+		return err
+	}
+
+	if verrs.HasAny() {
+		return responder.Wants("json", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.JSON(verrs))
+		}).Wants("xml", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.XML(verrs))
+		}).Respond(c)
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.JSON(ext))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.XML(ext))
+	}).Respond(c)
+}
+
+// MemberExternalIDDelete removes the ID of a Member in another system.
+// @Summary Remove the external ID of a member in a system
+// @ID delete-member-external-id
+// @Param member_id path string true "Member ID"
+// @Param system path string true "System, like hris or payroll"
+// @Success 204
+// @Failure 404,500
+// @Router /members/{member_id}/external-ids/{system} [delete]
+func MemberExternalIDDelete(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	member := &models.Member{}
+	if err := tx.Find(member, c.Param("member_id")); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	ext, err := models.FindExternalID(tx, member.ID, c.Param("system"))
+	if err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	if err := tx.Destroy(ext); err != nil {
+		return err
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusNoContent, nil)
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusNoContent, nil)
+	}).Respond(c)
+}
@@ -0,0 +1,69 @@
+package actions
+
+import (
+	"encoding/json"
+	"net/http"
+	"team_manager/models"
+)
+
+func (as *ActionSuite) Test_MemberUpsertByExternal() {
+	m := &models.Member{Name: "Member Name", Type: "employee", Role: "DevOps"}
+	res := as.JSON("/v1/members/by-external/hris/E-1042").Put(m)
+	as.Equal(http.StatusCreated, res.Code)
+
+	created := models.Member{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &created))
+
+	m.Name = "New Name"
+	res = as.JSON("/v1/members/by-external/hris/E-1042").Put(m)
+	as.Equal(http.StatusOK, res.Code)
+
+	updated := models.Member{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &updated))
+	as.Equal(created.ID, updated.ID)
+	as.Equal("New Name", updated.Name)
+
+	res = as.JSON("/v1/members/by-external/1hris/E-1042").Put(m)
+	as.Equal(http.StatusUnprocessableEntity, res.Code)
+
+	// another ID in the system for a member with the same name is a probable duplicate
+	res = as.JSON("/v1/members/by-external/hris/E-2001").Put(m)
+	as.Equal(http.StatusConflict, res.Code)
+
+	candidates := models.DuplicateCandidates{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &candidates))
+	as.Equal(1, len(candidates))
+	as.Equal(created.ID, candidates[0].Member.ID)
+
+	res = as.JSON("/v1/members/by-external/hris/E-2001?force=true").Put(m)
+	as.Equal(http.StatusCreated, res.Code)
+
+	res = as.JSON("/v1/members/" + created.ID.String() + "/external-ids").Get()
+	as.Equal(http.StatusOK, res.Code)
+
+	ids := models.ExternalIDs{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &ids))
+	as.Equal(1, len(ids))
+	as.Equal("hris", ids[0].System)
+}
+
+func (as *ActionSuite) Test_MemberExternalIDSet() {
+	m := &models.Member{Name: "Member Name", Type: "employee", Role: "DevOps"}
+	as.NoError(models.DB.Create(m))
+
+	res := as.JSON("/v1/members/" + m.ID.String() + "/external-ids/payroll").Put(map[string]string{"external_id": "P-7"})
+	as.Equal(http.StatusOK, res.Code)
+
+	res = as.JSON("/v1/members/" + m.ID.String() + "/external-ids/payroll").Put(map[string]string{"external_id": "P-8"})
+	as.Equal(http.StatusOK, res.Code)
+
+	found, err := models.FindMemberByExternalID(models.DB, "payroll", "P-8")
+	as.NoError(err)
+	as.Equal(m.ID, found.ID)
+
+	res = as.JSON("/v1/members/" + m.ID.String() + "/external-ids/payroll").Delete()
+	as.Equal(http.StatusNoContent, res.Code)
+
+	res = as.JSON("/v1/members/" + m.ID.String() + "/external-ids/payroll").Delete()
+	as.Equal(http.StatusNotFound, res.Code)
+}
@@ -0,0 +1,91 @@
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/pop/v5"
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+// schema is the parsed GraphQL schema, the resolvers wait on each other
+// for the transaction so a request runs many of them at once only to batch them
+var schema = graphql.MustParseSchema(graphqlSchema, &graphqlResolver{}, graphql.MaxParallelism(100))
+
+// querySchema is the schema without the mutations, for the GETs: a link
+// or a cached request must never change the members
+var querySchema = graphql.MustParseSchema(graphqlQuerySchema, &graphqlResolver{}, graphql.MaxParallelism(100))
+
+// noMutations is the error of graphql-go for a mutation sent to querySchema
+const noMutations = "no mutations are offered by the schema"
+
+// graphqlRequest is a GraphQL request, in the body of a POST or the params of a GET
+type graphqlRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// GraphQL runs a GraphQL query or mutation on the members, tags and teams.
+// @Summary Run a GraphQL query
+// @Description Queries and mutations on the members, their tags and teams. The members query takes the filters of GET /members, the mutations validate the members like POST and PUT /members and are only run with a POST, a mutation sent with a GET is a 405. The related members and teams are loaded in batches. An error of a resolver is a 500 and rolls the mutations back.
+// @ID graphql
+// @Accept json
+// @Produce json
+// @Param query query string false "The query, for a GET"
+// @Param operationName query string false "The operation of the query to run, for a GET"
+// @Param variables query string false "The variables of the query as JSON, for a GET"
+// @Success 200
+// @Failure 400,405,500
+// @Router /graphql [get]
+// @Router /graphql [post]
+func GraphQL(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	req := graphqlRequest{}
+	exec := schema
+	if c.Request().Method == http.MethodGet {
+		exec = querySchema
+		req.Query = c.Param("query")
+		req.OperationName = c.Param("operationName")
+		if v := c.Param("variables"); v != "" {
+			if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+				return c.Error(http.StatusBadRequest, fmt.Errorf("variables are not valid JSON: %w", err))
+			}
+		}
+	} else if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return c.Error(http.StatusBadRequest, fmt.Errorf("the body is not a valid GraphQL request: %w", err))
+	}
+
+	ctx := context.WithValue(c, graphqlLoadersKey{}, newGraphQLLoaders(tx))
+	resp := exec.Exec(ctx, req.Query, req.OperationName, req.Variables)
+
+	for _, err := range resp.Errors {
+		if err.Message == noMutations {
+			c.Response().Header().Set("Allow", http.MethodPost)
+			return c.Render(http.StatusMethodNotAllowed, r.JSON(resp))
+		}
+	}
+
+	// a query that is not valid has no data, and an error of a resolver
+	// must roll the transaction back, along with what the mutations did
+	status := http.StatusOK
+	for _, err := range resp.Errors {
+		if err.ResolverError != nil {
+			status = http.StatusInternalServerError
+			break
+		}
+	}
+	if status == http.StatusOK && len(resp.Errors) > 0 && resp.Data == nil {
+		status = http.StatusBadRequest
+	}
+
+	return c.Render(status, r.JSON(resp))
+}
@@ -0,0 +1,270 @@
+package actions
+
+import (
+	"context"
+	"sync"
+	"team_manager/models"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/slices"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gofrs/uuid"
+	"github.com/graph-gophers/dataloader"
+)
+
+// graphqlLoadersKey is the key of the loaders of a GraphQL request in its context
+type graphqlLoadersKey struct{}
+
+// graphqlLoaders batch the queries of a GraphQL request: the teams of 20 members
+// are loaded with a query, not 20. The resolvers run concurrently, while
+// the transaction of the request must be used by one of them at a time.
+type graphqlLoaders struct {
+	mu sync.Mutex
+	tx *pop.Connection
+
+	members       *dataloader.Loader
+	teams         *dataloader.Loader
+	teamsByMember *dataloader.Loader
+	membersByTeam *dataloader.Loader
+	reports       *dataloader.Loader
+	subTeams      *dataloader.Loader
+	membersByTag  *dataloader.Loader
+}
+
+// newGraphQLLoaders returns the loaders of a request, using its transaction
+func newGraphQLLoaders(tx *pop.Connection) *graphqlLoaders {
+	l := &graphqlLoaders{tx: tx}
+	l.members = dataloader.NewBatchedLoader(l.loadMembers)
+	l.teams = dataloader.NewBatchedLoader(l.loadTeams)
+	l.teamsByMember = dataloader.NewBatchedLoader(l.loadTeamsByMember)
+	l.membersByTeam = dataloader.NewBatchedLoader(l.loadMembersByTeam)
+	l.reports = dataloader.NewBatchedLoader(l.loadReports)
+	l.subTeams = dataloader.NewBatchedLoader(l.loadSubTeams)
+	l.membersByTag = dataloader.NewBatchedLoader(l.loadMembersByTag)
+	return l
+}
+
+// loadersFrom returns the loaders of the request of the context
+func loadersFrom(ctx context.Context) *graphqlLoaders {
+	return ctx.Value(graphqlLoadersKey{}).(*graphqlLoaders)
+}
+
+// db runs fn with the transaction of the request, once the other resolvers are done with it
+func (l *graphqlLoaders) db(fn func(tx *pop.Connection) error) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return fn(l.tx)
+}
+
+// mutate runs fn like db, in a savepoint rolled back when fn returns validation errors.
+// A mutation rejected by the database, like with an email already taken, aborts
+// the transaction in Postgres: the savepoint keeps it usable by the next mutations.
+func (l *graphqlLoaders) mutate(fn func(tx *pop.Connection) (*validate.Errors, error)) (*validate.Errors, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := l.tx.RawQuery("SAVEPOINT graphql_mutation").Exec(); err != nil {
+		return nil, err
+	}
+
+	verrs, err := fn(l.tx)
+	if err != nil {
+		return verrs, err
+	}
+
+	end := "RELEASE SAVEPOINT graphql_mutation"
+	if verrs.HasAny() {
+		end = "ROLLBACK TO SAVEPOINT graphql_mutation"
+	}
+	return verrs, l.tx.RawQuery(end).Exec()
+}
+
+// keyArgs are the keys as query arguments
+func keyArgs(keys dataloader.Keys) []interface{} {
+	args := make([]interface{}, len(keys))
+	for i, k := range keys {
+		args[i] = k.String()
+	}
+	return args
+}
+
+// batchResults returns the result of each key, or the error for every key
+func batchResults(keys dataloader.Keys, err error, result func(key string) interface{}) []*dataloader.Result {
+	results := make([]*dataloader.Result, len(keys))
+	for i, k := range keys {
+		if err != nil {
+			results[i] = &dataloader.Result{Error: err}
+			continue
+		}
+		results[i] = &dataloader.Result{Data: result(k.String())}
+	}
+	return results
+}
+
+// loadMembers loads the members by ID, a member that does not exist is nil
+func (l *graphqlLoaders) loadMembers(ctx context.Context, keys dataloader.Keys) []*dataloader.Result {
+	members := models.Members{}
+	err := l.db(func(tx *pop.Connection) error {
+		return tx.Where("id IN (?)", keyArgs(keys)...).All(&members)
+	})
+
+	byID := map[string]*models.Member{}
+	for i := range members {
+		byID[members[i].ID.String()] = &members[i]
+	}
+	return batchResults(keys, err, func(key string) interface{} { return byID[key] })
+}
+
+// loadTeams loads the teams by ID, a team that does not exist is nil
+func (l *graphqlLoaders) loadTeams(ctx context.Context, keys dataloader.Keys) []*dataloader.Result {
+	teams := models.Teams{}
+	err := l.db(func(tx *pop.Connection) error {
+		return tx.Where("id IN (?)", keyArgs(keys)...).All(&teams)
+	})
+
+	byID := map[string]*models.Team{}
+	for i := range teams {
+		byID[teams[i].ID.String()] = &teams[i]
+	}
+	return batchResults(keys, err, func(key string) interface{} { return byID[key] })
+}
+
+// loadTeamsByMember loads the teams of the members, by member ID
+func (l *graphqlLoaders) loadTeamsByMember(ctx context.Context, keys dataloader.Keys) []*dataloader.Result {
+	memberships := models.TeamMemberships{}
+	teams := models.Teams{}
+	err := l.db(func(tx *pop.Connection) error {
+		if err := tx.Where("member_id IN (?)", keyArgs(keys)...).All(&memberships); err != nil {
+			return err
+		}
+		return tx.Where("id IN (SELECT team_id FROM team_memberships WHERE member_id IN (?))", keyArgs(keys)...).Order("name").All(&teams)
+	})
+
+	// the teams are added in order of their names
+	members := map[uuid.UUID][]string{}
+	for _, tm := range memberships {
+		members[tm.TeamID] = append(members[tm.TeamID], tm.MemberID.String())
+	}
+	teamsByMember := map[string][]*models.Team{}
+	for i := range teams {
+		for _, id := range members[teams[i].ID] {
+			teamsByMember[id] = append(teamsByMember[id], &teams[i])
+		}
+	}
+	return batchResults(keys, err, func(key string) interface{} { return teamsByMember[key] })
+}
+
+// loadMembersByTeam loads the members of the teams, by team ID
+func (l *graphqlLoaders) loadMembersByTeam(ctx context.Context, keys dataloader.Keys) []*dataloader.Result {
+	memberships := models.TeamMemberships{}
+	members := models.Members{}
+	err := l.db(func(tx *pop.Connection) error {
+		if err := tx.Where("team_id IN (?)", keyArgs(keys)...).All(&memberships); err != nil {
+			return err
+		}
+		return tx.Where("id IN (SELECT member_id FROM team_memberships WHERE team_id IN (?))", keyArgs(keys)...).Order("name").All(&members)
+	})
+
+	// the members are added in order of their names
+	teams := map[uuid.UUID][]string{}
+	for _, tm := range memberships {
+		teams[tm.MemberID] = append(teams[tm.MemberID], tm.TeamID.String())
+	}
+	membersByTeam := map[string][]*models.Member{}
+	for i := range members {
+		for _, id := range teams[members[i].ID] {
+			membersByTeam[id] = append(membersByTeam[id], &members[i])
+		}
+	}
+	return batchResults(keys, err, func(key string) interface{} { return membersByTeam[key] })
+}
+
+// loadReports loads the direct reports of the members, by manager ID
+func (l *graphqlLoaders) loadReports(ctx context.Context, keys dataloader.Keys) []*dataloader.Result {
+	members := models.Members{}
+	err := l.db(func(tx *pop.Connection) error {
+		return tx.Where("manager_id IN (?)", keyArgs(keys)...).Order("name").All(&members)
+	})
+
+	reports := map[string][]*models.Member{}
+	for i := range members {
+		manager := members[i].ManagerID.UUID.String()
+		reports[manager] = append(reports[manager], &members[i])
+	}
+	return batchResults(keys, err, func(key string) interface{} { return reports[key] })
+}
+
+// loadSubTeams loads the teams right under the teams, by parent ID
+func (l *graphqlLoaders) loadSubTeams(ctx context.Context, keys dataloader.Keys) []*dataloader.Result {
+	teams := models.Teams{}
+	err := l.db(func(tx *pop.Connection) error {
+		return tx.Where("parent_id IN (?)", keyArgs(keys)...).Order("name").All(&teams)
+	})
+
+	subTeams := map[string][]*models.Team{}
+	for i := range teams {
+		parent := teams[i].ParentID.UUID.String()
+		subTeams[parent] = append(subTeams[parent], &teams[i])
+	}
+	return batchResults(keys, err, func(key string) interface{} { return subTeams[key] })
+}
+
+// loadMembersByTag loads the members having the tags, by tag
+func (l *graphqlLoaders) loadMembersByTag(ctx context.Context, keys dataloader.Keys) []*dataloader.Result {
+	tags := slices.String(keys.Keys())
+	members := models.Members{}
+	err := l.db(func(tx *pop.Connection) error {
+		return tx.Where("tags && ?", tags).Order("name").All(&members)
+	})
+
+	membersByTag := map[string][]*models.Member{}
+	for i := range members {
+		for _, t := range members[i].Tags {
+			membersByTag[t] = append(membersByTag[t], &members[i])
+		}
+	}
+	return batchResults(keys, err, func(key string) interface{} { return membersByTag[key] })
+}
+
+// member loads a member by ID, nil if it does not exist
+func (l *graphqlLoaders) member(ctx context.Context, id nulls.UUID) (*models.Member, error) {
+	if !id.Valid {
+		return nil, nil
+	}
+	m, err := l.members.Load(ctx, dataloader.StringKey(id.UUID.String()))()
+	if err != nil {
+		return nil, err
+	}
+	return m.(*models.Member), nil
+}
+
+// team loads a team by ID, nil if it does not exist
+func (l *graphqlLoaders) team(ctx context.Context, id nulls.UUID) (*models.Team, error) {
+	if !id.Valid {
+		return nil, nil
+	}
+	t, err := l.teams.Load(ctx, dataloader.StringKey(id.UUID.String()))()
+	if err != nil {
+		return nil, err
+	}
+	return t.(*models.Team), nil
+}
+
+// memberList loads the members of a key with a loader
+func memberList(ctx context.Context, loader *dataloader.Loader, key string) ([]*models.Member, error) {
+	members, err := loader.Load(ctx, dataloader.StringKey(key))()
+	if err != nil {
+		return nil, err
+	}
+	return members.([]*models.Member), nil
+}
+
+// teamList loads the teams of a key with a loader
+func teamList(ctx context.Context, loader *dataloader.Loader, key string) ([]*models.Team, error) {
+	teams, err := loader.Load(ctx, dataloader.StringKey(key))()
+	if err != nil {
+		return nil, err
+	}
+	return teams.([]*models.Team), nil
+}
@@ -0,0 +1,619 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"team_manager/models"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/slices"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gofrs/uuid"
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+// graphqlResolver resolves the queries and the mutations of the schema
+type graphqlResolver struct{}
+
+// graphqlNullID is an ID that can be null, an input field is either left out,
+// set to null or set to an ID (see graphql.NullString)
+type graphqlNullID struct {
+	Value *graphql.ID
+	Set   bool
+}
+
+// ImplementsGraphQLType tells the ID is an ID
+func (graphqlNullID) ImplementsGraphQLType(name string) bool {
+	return name == "ID"
+}
+
+// UnmarshalGraphQL reads the ID of an input
+func (id *graphqlNullID) UnmarshalGraphQL(input interface{}) error {
+	id.Set = true
+	if input == nil {
+		return nil
+	}
+
+	id.Value = new(graphql.ID)
+	return id.Value.UnmarshalGraphQL(input)
+}
+
+// Nullable tells the ID can be null
+func (id *graphqlNullID) Nullable() {}
+
+// memberFilterArgs are the filters of the members query, the ones of GET /v1/members
+type memberFilterArgs struct {
+	Type    *string
+	Role    *string
+	Name    *string
+	Team    *graphql.ID
+	Unit    *graphql.ID
+	Status  *[]string
+	Tags    *[]string
+	Custom  *[]customValueInput
+	Page    *int32
+	PerPage *int32
+}
+
+type customValueInput struct {
+	Name  string
+	Value string
+}
+
+// filter is the filter of the arguments, like models.MemberFilterFromParams
+func (a memberFilterArgs) filter() models.MemberFilter {
+	f := models.MemberFilter{}
+	for _, v := range []struct {
+		arg *string
+		to  *string
+	}{{a.Type, &f.Type}, {a.Role, &f.Role}, {a.Name, &f.Name}, {(*string)(a.Team), &f.Team}, {(*string)(a.Unit), &f.Unit}} {
+		if v.arg != nil {
+			*v.to = strings.TrimSpace(*v.arg)
+		}
+	}
+
+	if a.Status != nil {
+		for _, s := range *a.Status {
+			f.Status = append(f.Status, strings.ToLower(strings.TrimSpace(s)))
+		}
+	}
+	if a.Tags != nil {
+		for _, t := range *a.Tags {
+			f.Tags = append(f.Tags, strings.ToLower(strings.TrimSpace(t)))
+		}
+	}
+	if a.Custom != nil {
+		f.Custom = map[string]string{}
+		for _, c := range *a.Custom {
+			f.Custom[strings.TrimSpace(c.Name)] = strings.TrimSpace(c.Value)
+		}
+	}
+
+	return f
+}
+
+// Members resolves the members matching the filters, 20 per page by default
+func (*graphqlResolver) Members(ctx context.Context, args memberFilterArgs) ([]*memberResolver, error) {
+	page, perPage := 1, 20
+	if args.Page != nil && *args.Page > 0 {
+		page = int(*args.Page)
+	}
+	if args.PerPage != nil && *args.PerPage > 0 {
+		perPage = int(*args.PerPage)
+	}
+
+	members := models.Members{}
+	err := loadersFrom(ctx).db(func(tx *pop.Connection) error {
+		return tx.Paginate(page, perPage).Scope(args.filter().Scope).All(&members)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*memberResolver, len(members))
+	for i := range members {
+		resolvers[i] = &memberResolver{&members[i]}
+	}
+	return resolvers, nil
+}
+
+// Member resolves a member by ID, null if it does not exist
+func (*graphqlResolver) Member(ctx context.Context, args struct{ ID graphql.ID }) (*memberResolver, error) {
+	id, err := uuid.FromString(string(args.ID))
+	if err != nil {
+		return nil, nil
+	}
+
+	m, err := loadersFrom(ctx).member(ctx, nulls.NewUUID(id))
+	if err != nil || m == nil {
+		return nil, err
+	}
+	return &memberResolver{m}, nil
+}
+
+// Tags resolves the tags of the members
+func (*graphqlResolver) Tags(ctx context.Context) ([]*tagResolver, error) {
+	tags := models.Tags{}
+	err := loadersFrom(ctx).db(func(tx *pop.Connection) error {
+		var err error
+		tags, err = models.FindTags(tx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*tagResolver, len(tags))
+	for i, t := range tags {
+		resolvers[i] = &tagResolver{t.Name}
+	}
+	return resolvers, nil
+}
+
+// Tag resolves a tag by name, null if no member has it
+func (*graphqlResolver) Tag(ctx context.Context, args struct{ Name string }) (*tagResolver, error) {
+	t := &tagResolver{strings.ToLower(strings.TrimSpace(args.Name))}
+	members, err := t.Members(ctx)
+	if err != nil || len(members) == 0 {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Teams resolves the teams
+func (*graphqlResolver) Teams(ctx context.Context) ([]*teamResolver, error) {
+	teams := models.Teams{}
+	err := loadersFrom(ctx).db(func(tx *pop.Connection) error {
+		return tx.Order("name").All(&teams)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*teamResolver, len(teams))
+	for i := range teams {
+		resolvers[i] = &teamResolver{&teams[i]}
+	}
+	return resolvers, nil
+}
+
+// Team resolves a team by ID, null if it does not exist
+func (*graphqlResolver) Team(ctx context.Context, args struct{ ID graphql.ID }) (*teamResolver, error) {
+	id, err := uuid.FromString(string(args.ID))
+	if err != nil {
+		return nil, nil
+	}
+
+	t, err := loadersFrom(ctx).team(ctx, nulls.NewUUID(id))
+	if err != nil || t == nil {
+		return nil, err
+	}
+	return &teamResolver{t}, nil
+}
+
+// memberInput are the fields of a member given to a mutation,
+// the ones left out are nil and not changed
+type memberInput struct {
+	Name          *string
+	Type          *string
+	Role          graphql.NullString
+	ContractStart graphql.NullTime
+	ContractEnd   graphql.NullTime
+	Email         graphql.NullString
+	Phone         graphql.NullString
+	ChatHandle    graphql.NullString
+	Location      graphql.NullString
+	TimeZone      graphql.NullString
+	Status        *string
+	Tags          *[]string
+	ManagerID     graphqlNullID
+}
+
+// applyTo sets the fields of the input on the member
+func (in memberInput) applyTo(m *models.Member) {
+	if in.Name != nil {
+		m.Name = *in.Name
+	}
+	if in.Type != nil {
+		m.Type = *in.Type
+	}
+	if in.Status != nil {
+		m.Status = *in.Status
+	}
+	if in.Tags != nil {
+		m.Tags = slices.String(*in.Tags)
+	}
+
+	if in.Role.Set {
+		m.Role, m.RoleID = "", nulls.UUID{}
+		if in.Role.Value != nil {
+			m.Role = *in.Role.Value
+		}
+	}
+
+	for _, f := range []struct {
+		in graphql.NullString
+		to *nulls.String
+	}{{in.Email, &m.Email}, {in.Phone, &m.Phone}, {in.ChatHandle, &m.ChatHandle}, {in.Location, &m.Location}, {in.TimeZone, &m.TimeZone}} {
+		if f.in.Set {
+			*f.to = nulls.String{}
+			if f.in.Value != nil {
+				*f.to = nulls.NewString(*f.in.Value)
+			}
+		}
+	}
+
+	for _, f := range []struct {
+		in graphql.NullTime
+		to *nulls.Time
+	}{{in.ContractStart, &m.ContractStart}, {in.ContractEnd, &m.ContractEnd}} {
+		if f.in.Set {
+			*f.to = nulls.Time{}
+			if f.in.Value != nil {
+				*f.to = nulls.NewTime(f.in.Value.Time)
+			}
+		}
+	}
+
+	if in.ManagerID.Set {
+		m.ManagerID = nulls.UUID{}
+		if in.ManagerID.Value != nil {
+			// a manager ID that is not an ID is a manager that does not exist,
+			// the validation of the member tells it
+			id, _ := uuid.FromString(string(*in.ManagerID.Value))
+			m.ManagerID = nulls.NewUUID(id)
+		}
+	}
+}
+
+// CreateMember creates a member, like POST /v1/members: a member with probable
+// duplicates is not created unless force is set, the duplicates are returned instead
+func (*graphqlResolver) CreateMember(ctx context.Context, args struct {
+	Input memberInput
+	Force bool
+}) (*memberPayloadResolver, error) {
+	member := &models.Member{}
+	args.Input.applyTo(member)
+
+	payload := &memberPayloadResolver{}
+	var err error
+	payload.verrs, err = loadersFrom(ctx).mutate(func(tx *pop.Connection) (*validate.Errors, error) {
+		if !args.Force {
+			candidates, err := models.FindDuplicates(tx, member)
+			if err != nil {
+				return nil, err
+			}
+			if len(candidates) > 0 {
+				for i := range candidates {
+					payload.duplicates = append(payload.duplicates, &memberResolver{&candidates[i].Member})
+				}
+				return validate.NewErrors(), nil
+			}
+		}
+
+		return models.ConstraintErrors(tx.ValidateAndCreate(member))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(payload.duplicates) == 0 && !payload.verrs.HasAny() {
+		payload.member = &memberResolver{member}
+	}
+	return payload, nil
+}
+
+// UpdateMember changes the fields of the input, like PUT /v1/members/{member_id}:
+// the type only changes with override, and the status with a transition
+func (*graphqlResolver) UpdateMember(ctx context.Context, args struct {
+	ID       graphql.ID
+	Input    memberInput
+	Override bool
+}) (*memberPayloadResolver, error) {
+	payload := &memberPayloadResolver{}
+	var err error
+	payload.verrs, err = loadersFrom(ctx).mutate(func(tx *pop.Connection) (*validate.Errors, error) {
+		// an ID that is not an ID would abort the transaction
+		member := &models.Member{}
+		if _, err := uuid.FromString(string(args.ID)); err != nil || tx.Find(member, string(args.ID)) != nil {
+			verrs := validate.NewErrors()
+			verrs.Add("id", fmt.Sprintf("Member %s not found.", args.ID))
+			return verrs, nil
+		}
+
+		storedType, storedStatus := member.Type, member.Status
+		args.Input.applyTo(member)
+
+		verrs, err := updateMember(tx, member, storedType, storedStatus, args.Override)
+		if err == nil && !verrs.HasAny() {
+			payload.member = &memberResolver{member}
+		}
+		return verrs, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// DeleteMember deletes a member, like DELETE /v1/members/{member_id}
+func (*graphqlResolver) DeleteMember(ctx context.Context, args struct{ ID graphql.ID }) (bool, error) {
+	deleted := false
+	err := loadersFrom(ctx).db(func(tx *pop.Connection) error {
+		member := &models.Member{}
+		if _, err := uuid.FromString(string(args.ID)); err != nil || tx.Find(member, string(args.ID)) != nil {
+			return nil
+		}
+
+		deleted = true
+		return tx.Destroy(member)
+	})
+	return deleted, err
+}
+
+// memberPayloadResolver is the result of a mutation of a member: the member,
+// or why it was not saved
+type memberPayloadResolver struct {
+	member     *memberResolver
+	verrs      *validate.Errors
+	duplicates []*memberResolver
+}
+
+func (p *memberPayloadResolver) Member() *memberResolver {
+	return p.member
+}
+
+func (p *memberPayloadResolver) Errors() []*fieldErrorResolver {
+	errors := []*fieldErrorResolver{}
+	if p.verrs == nil {
+		return errors
+	}
+
+	keys := p.verrs.Keys()
+	sort.Strings(keys)
+	for _, k := range keys {
+		errors = append(errors, &fieldErrorResolver{field: k, messages: p.verrs.Get(k)})
+	}
+	return errors
+}
+
+func (p *memberPayloadResolver) Duplicates() []*memberResolver {
+	if p.duplicates == nil {
+		return []*memberResolver{}
+	}
+	return p.duplicates
+}
+
+type fieldErrorResolver struct {
+	field    string
+	messages []string
+}
+
+func (e *fieldErrorResolver) Field() string {
+	return e.field
+}
+
+func (e *fieldErrorResolver) Messages() []string {
+	return e.messages
+}
+
+// memberResolver resolves the fields of a member,
+// its tags, teams, manager and reports are batched by the loaders
+type memberResolver struct {
+	m *models.Member
+}
+
+func (r *memberResolver) ID() graphql.ID {
+	return graphql.ID(r.m.ID.String())
+}
+
+func (r *memberResolver) Name() string {
+	return r.m.Name
+}
+
+func (r *memberResolver) Type() string {
+	return r.m.Type
+}
+
+func (r *memberResolver) Role() *string {
+	if r.m.Role == "" {
+		return nil
+	}
+	return &r.m.Role
+}
+
+func (r *memberResolver) ContractStart() *graphql.Time {
+	return nullTime(r.m.ContractStart)
+}
+
+func (r *memberResolver) ContractEnd() *graphql.Time {
+	return nullTime(r.m.ContractEnd)
+}
+
+func (r *memberResolver) ContractDuration() *string {
+	if r.m.ContractDuration == "" {
+		return nil
+	}
+	return &r.m.ContractDuration
+}
+
+func (r *memberResolver) Email() *string {
+	return nullString(r.m.Email)
+}
+
+func (r *memberResolver) Phone() *string {
+	return nullString(r.m.Phone)
+}
+
+func (r *memberResolver) ChatHandle() *string {
+	return nullString(r.m.ChatHandle)
+}
+
+func (r *memberResolver) Location() *string {
+	return nullString(r.m.Location)
+}
+
+func (r *memberResolver) TimeZone() *string {
+	return nullString(r.m.TimeZone)
+}
+
+func (r *memberResolver) Status() string {
+	return r.m.Status
+}
+
+func (r *memberResolver) StatusSince() *graphql.Time {
+	return nullTime(r.m.StatusSince)
+}
+
+func (r *memberResolver) CustomFields() []*customValueResolver {
+	names := []string{}
+	for name := range r.m.CustomFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	values := make([]*customValueResolver, len(names))
+	for i, name := range names {
+		values[i] = &customValueResolver{name: name, value: fmt.Sprint(r.m.CustomFields[name])}
+	}
+	return values
+}
+
+func (r *memberResolver) Tags() []*tagResolver {
+	tags := make([]*tagResolver, len(r.m.Tags))
+	for i, t := range r.m.Tags {
+		tags[i] = &tagResolver{t}
+	}
+	return tags
+}
+
+func (r *memberResolver) Teams(ctx context.Context) ([]*teamResolver, error) {
+	teams, err := teamList(ctx, loadersFrom(ctx).teamsByMember, r.m.ID.String())
+	return teamResolvers(teams), err
+}
+
+func (r *memberResolver) Manager(ctx context.Context) (*memberResolver, error) {
+	m, err := loadersFrom(ctx).member(ctx, r.m.ManagerID)
+	if err != nil || m == nil {
+		return nil, err
+	}
+	return &memberResolver{m}, nil
+}
+
+func (r *memberResolver) Reports(ctx context.Context) ([]*memberResolver, error) {
+	members, err := memberList(ctx, loadersFrom(ctx).reports, r.m.ID.String())
+	return memberResolvers(members), err
+}
+
+// tagResolver resolves a tag, its members are batched by the loaders
+type tagResolver struct {
+	name string
+}
+
+func (r *tagResolver) Name() string {
+	return r.name
+}
+
+func (r *tagResolver) MemberCount(ctx context.Context) (int32, error) {
+	members, err := r.Members(ctx)
+	return int32(len(members)), err
+}
+
+func (r *tagResolver) Members(ctx context.Context) ([]*memberResolver, error) {
+	members, err := memberList(ctx, loadersFrom(ctx).membersByTag, r.name)
+	return memberResolvers(members), err
+}
+
+// teamResolver resolves the fields of a team,
+// its lead, parent, sub-teams and members are batched by the loaders
+type teamResolver struct {
+	t *models.Team
+}
+
+func (r *teamResolver) ID() graphql.ID {
+	return graphql.ID(r.t.ID.String())
+}
+
+func (r *teamResolver) Name() string {
+	return r.t.Name
+}
+
+func (r *teamResolver) Description() *string {
+	return nullString(r.t.Description)
+}
+
+func (r *teamResolver) Lead(ctx context.Context) (*memberResolver, error) {
+	m, err := loadersFrom(ctx).member(ctx, r.t.LeadID)
+	if err != nil || m == nil {
+		return nil, err
+	}
+	return &memberResolver{m}, nil
+}
+
+func (r *teamResolver) Parent(ctx context.Context) (*teamResolver, error) {
+	t, err := loadersFrom(ctx).team(ctx, r.t.ParentID)
+	if err != nil || t == nil {
+		return nil, err
+	}
+	return &teamResolver{t}, nil
+}
+
+func (r *teamResolver) SubTeams(ctx context.Context) ([]*teamResolver, error) {
+	teams, err := teamList(ctx, loadersFrom(ctx).subTeams, r.t.ID.String())
+	return teamResolvers(teams), err
+}
+
+func (r *teamResolver) Members(ctx context.Context) ([]*memberResolver, error) {
+	members, err := memberList(ctx, loadersFrom(ctx).membersByTeam, r.t.ID.String())
+	return memberResolvers(members), err
+}
+
+type customValueResolver struct {
+	name  string
+	value string
+}
+
+func (r *customValueResolver) Name() string {
+	return r.name
+}
+
+func (r *customValueResolver) Value() string {
+	return r.value
+}
+
+// memberResolvers resolves the members
+func memberResolvers(members []*models.Member) []*memberResolver {
+	resolvers := make([]*memberResolver, len(members))
+	for i, m := range members {
+		resolvers[i] = &memberResolver{m}
+	}
+	return resolvers
+}
+
+// teamResolvers resolves the teams
+func teamResolvers(teams []*models.Team) []*teamResolver {
+	resolvers := make([]*teamResolver, len(teams))
+	for i, t := range teams {
+		resolvers[i] = &teamResolver{t}
+	}
+	return resolvers
+}
+
+// nullString is the value of a nullable string, nil when it is null
+func nullString(s nulls.String) *string {
+	if !s.Valid {
+		return nil
+	}
+	return &s.String
+}
+
+// nullTime is the value of a nullable time, nil when it is null
+func nullTime(t nulls.Time) *graphql.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &graphql.Time{Time: t.Time}
+}
@@ -0,0 +1,136 @@
+package actions
+
+// graphqlSchema is the schema of /v1/graphql. The members query takes
+// the filters of GET /v1/members, and the mutations validate the members
+// like POST and PUT /v1/members.
+const graphqlSchema = `
+schema {
+	query: Query
+	mutation: Mutation
+}
+` + graphqlMutation + graphqlTypes
+
+// graphqlQuerySchema is the schema of the GETs of /v1/graphql, without the mutations
+const graphqlQuerySchema = `
+schema {
+	query: Query
+}
+` + graphqlTypes
+
+// graphqlTypes are the types of the schemas, the queries included
+const graphqlTypes = `
+scalar Time
+
+type Query {
+	# The members matching the filters, like GET /v1/members, 20 per page by default
+	members(
+		type: String
+		role: String
+		name: String
+		team: ID
+		unit: ID
+		status: [String!]
+		tags: [String!]
+		custom: [CustomValueInput!]
+		page: Int
+		perPage: Int
+	): [Member!]!
+	member(id: ID!): Member
+	# The tags of the members, by name
+	tags: [Tag!]!
+	tag(name: String!): Tag
+	# The teams, by name
+	teams: [Team!]!
+	team(id: ID!): Team
+}
+
+type Member {
+	id: ID!
+	name: String!
+	type: String!
+	role: String
+	contractStart: Time
+	contractEnd: Time
+	contractDuration: String
+	email: String
+	phone: String
+	chatHandle: String
+	location: String
+	timeZone: String
+	status: String!
+	statusSince: Time
+	customFields: [CustomValue!]!
+	tags: [Tag!]!
+	teams: [Team!]!
+	manager: Member
+	reports: [Member!]!
+}
+
+type Tag {
+	name: String!
+	memberCount: Int!
+	members: [Member!]!
+}
+
+type Team {
+	id: ID!
+	name: String!
+	description: String
+	lead: Member
+	parent: Team
+	subTeams: [Team!]!
+	members: [Member!]!
+}
+
+# A custom field value, numbers and booleans as text
+type CustomValue {
+	name: String!
+	value: String!
+}
+
+input CustomValueInput {
+	name: String!
+	value: String!
+}
+
+# The fields of a member, the ones left out are not changed by updateMember
+input MemberInput {
+	name: String
+	type: String
+	role: String
+	contractStart: Time
+	contractEnd: Time
+	email: String
+	phone: String
+	chatHandle: String
+	location: String
+	timeZone: String
+	status: String
+	tags: [String!]
+	managerId: ID
+}
+
+type MemberPayload {
+	member: Member
+	errors: [FieldError!]!
+	duplicates: [Member!]!
+}
+
+# The validation errors of a field
+type FieldError {
+	field: String!
+	messages: [String!]!
+}
+`
+
+// graphqlMutation is the mutation type of the schema
+const graphqlMutation = `
+type Mutation {
+	# Creates a member, unless it has probable duplicates and force is not set
+	createMember(input: MemberInput!, force: Boolean = false): MemberPayload!
+	# Changes the fields of the input, the type only with override
+	updateMember(id: ID!, input: MemberInput!, override: Boolean = false): MemberPayload!
+	# Deletes a member, false when it does not exist
+	deleteMember(id: ID!): Boolean!
+}
+`
@@ -0,0 +1,199 @@
+package actions
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"team_manager/models"
+)
+
+// graphqlResponse is the response of /v1/graphql, its data read into data
+type graphqlResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func (as *ActionSuite) graphql(query string, variables map[string]interface{}, data interface{}) int {
+	res := as.JSON("/v1/graphql").Post(graphqlRequest{Query: query, Variables: variables})
+
+	resp := graphqlResponse{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &resp))
+	if data != nil && resp.Data != nil {
+		as.NoError(json.Unmarshal(resp.Data, data))
+	}
+	return res.Code
+}
+
+func (as *ActionSuite) Test_GraphQL_Members() {
+	as.LoadFixture("teams")
+
+	data := struct {
+		Members []struct {
+			Name  string
+			Tags  []struct{ Name string }
+			Teams []struct {
+				Name    string
+				Lead    *struct{ Name string }
+				Members []struct{ Name string }
+			}
+		}
+	}{}
+	code := as.graphql(`query($tags: [String!]) {
+		members(tags: $tags, type: "employee") {
+			name
+			tags { name }
+			teams { name lead { name } members { name } }
+		}
+	}`, map[string]interface{}{"tags": []string{"GoLang"}}, &data)
+	as.Equal(http.StatusOK, code)
+
+	as.Equal(2, len(data.Members))
+	for _, m := range data.Members {
+		as.Equal(1, len(m.Teams))
+		as.Equal("Platform", m.Teams[0].Name)
+		as.Equal("Team Lead", m.Teams[0].Lead.Name)
+		as.Equal(2, len(m.Teams[0].Members))
+	}
+
+	tag := struct {
+		Tag struct {
+			MemberCount int
+			Members     []struct{ Name string }
+		}
+	}{}
+	as.Equal(http.StatusOK, as.graphql(`{ tag(name: "react") { memberCount members { name } } }`, nil, &tag))
+	as.Equal(1, tag.Tag.MemberCount)
+	as.Equal("Team Contractor", tag.Tag.Members[0].Name)
+
+	// a GET works too, and a query that is not valid is a bad request
+	res := as.JSON("/v1/graphql?query=%s", url.QueryEscape(`{ teams { name subTeams { name } } }`)).Get()
+	as.Equal(http.StatusOK, res.Code)
+	as.Contains(res.Body.String(), "Frontend")
+
+	// a mutation is only run with a POST
+	res = as.JSON("/v1/graphql?query=%s", url.QueryEscape(`mutation { deleteMember(id: "not-an-id") }`)).Get()
+	as.Equal(http.StatusMethodNotAllowed, res.Code)
+	as.Equal(http.MethodPost, res.Header().Get("Allow"))
+
+	as.Equal(http.StatusBadRequest, as.graphql(`{ members { password } }`, nil, nil))
+}
+
+func (as *ActionSuite) Test_GraphQL_MemberMutations() {
+	type payload struct {
+		Member *struct {
+			ID     string
+			Name   string
+			Status string
+		}
+		Errors []struct {
+			Field    string
+			Messages []string
+		}
+		Duplicates []struct{ Name string }
+	}
+	create := `mutation($input: MemberInput!, $force: Boolean) {
+		createMember(input: $input, force: $force) {
+			member { id name status }
+			errors { field messages }
+			duplicates { name }
+		}
+	}`
+
+	created := struct{ CreateMember payload }{}
+	code := as.graphql(create, map[string]interface{}{
+		"input": map[string]interface{}{"name": "Jane Doe", "type": "employee", "role": "DevOps", "email": "jane@example.com"},
+	}, &created)
+	as.Equal(http.StatusOK, code)
+	as.Empty(created.CreateMember.Errors)
+	as.Equal("Jane Doe", created.CreateMember.Member.Name)
+
+	// a member with the same name is a probable duplicate
+	duplicate := struct{ CreateMember payload }{}
+	as.graphql(create, map[string]interface{}{
+		"input": map[string]interface{}{"name": "jane  doe", "type": "employee", "role": "DevOps"},
+	}, &duplicate)
+	as.Nil(duplicate.CreateMember.Member)
+	as.Equal("Jane Doe", duplicate.CreateMember.Duplicates[0].Name)
+
+	// the validation of the members applies
+	invalid := struct{ CreateMember payload }{}
+	as.graphql(create, map[string]interface{}{
+		"input": map[string]interface{}{"name": "John Doe", "type": "unknown"},
+		"force": true,
+	}, &invalid)
+	as.Nil(invalid.CreateMember.Member)
+	as.Equal("type", invalid.CreateMember.Errors[0].Field)
+
+	update := `mutation($id: ID!, $input: MemberInput!) {
+		updateMember(id: $id, input: $input) {
+			member { id name status }
+			errors { field messages }
+		}
+	}`
+	id := created.CreateMember.Member.ID
+
+	updated := struct{ UpdateMember payload }{}
+	as.graphql(update, map[string]interface{}{
+		"id":    id,
+		"input": map[string]interface{}{"name": "Jane Smith", "email": nil},
+	}, &updated)
+	as.Equal("Jane Smith", updated.UpdateMember.Member.Name)
+
+	member := &models.Member{}
+	as.NoError(models.DB.Find(member, id))
+	as.Equal("employee", member.Type)
+	as.False(member.Email.Valid)
+
+	// the type only changes with override
+	updated = struct{ UpdateMember payload }{}
+	as.graphql(update, map[string]interface{}{
+		"id":    id,
+		"input": map[string]interface{}{"type": "contractor"},
+	}, &updated)
+	as.Nil(updated.UpdateMember.Member)
+	as.Equal("type", updated.UpdateMember.Errors[0].Field)
+
+	deleted := struct{ DeleteMember bool }{}
+	as.graphql(`mutation($id: ID!) { deleteMember(id: $id) }`, map[string]interface{}{"id": id}, &deleted)
+	as.True(deleted.DeleteMember)
+
+	as.graphql(`mutation { deleteMember(id: "not-an-id") }`, nil, &deleted)
+	as.False(deleted.DeleteMember)
+}
+
+func (as *ActionSuite) Test_GraphQL_MemberMutations_Email() {
+	type payload struct {
+		Member *struct{ Name string }
+		Errors []struct{ Field string }
+	}
+	data := struct {
+		A payload
+		B payload
+		C payload
+	}{}
+
+	// the email taken by the second mutation does not stop the third one
+	code := as.graphql(`mutation {
+		a: createMember(input: {name: "Jane Doe", type: "employee", role: "DevOps", email: "jane@example.com"}) {
+			member { name } errors { field }
+		}
+		b: createMember(input: {name: "John Doe", type: "employee", role: "DevOps", email: "jane@example.com"}, force: true) {
+			member { name } errors { field }
+		}
+		c: createMember(input: {name: "Jim Doe", type: "employee", role: "DevOps", email: "jim@example.com"}) {
+			member { name } errors { field }
+		}
+	}`, nil, &data)
+	as.Equal(http.StatusOK, code)
+
+	as.Equal("Jane Doe", data.A.Member.Name)
+	as.Nil(data.B.Member)
+	as.Equal("email", data.B.Errors[0].Field)
+	as.Equal("Jim Doe", data.C.Member.Name)
+
+	names := []string{}
+	as.NoError(models.DB.RawQuery("SELECT name FROM members ORDER BY name").All(&names))
+	as.Equal([]string{"Jane Doe", "Jim Doe"}, names)
+}
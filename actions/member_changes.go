@@ -0,0 +1,123 @@
+package actions
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"team_manager/models"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gofrs/uuid"
+)
+
+// memberChangesBatchSize is how many changes are read
+// from the database at a time while streaming the feed
+const memberChangesBatchSize = 100
+
+// MemberChanges streams, as NDJSON, the members created, updated and deleted since a sync token.
+// @Summary Member change feed
+// @Description Returns one line per changed member since the sync token, deleted members are returned as tombstones (without member). Store the X-Sync-Token header, or the sync_token of the last line, and send it back as the since param to only get the next changes.
+// @ID member-changes
+// @Param since query string false "Sync token of a previous call, every change is returned without it"
+// @Produce application/x-ndjson
+// @Success 200 {object} models.MemberChange
+// @Header 200 {string} X-Sync-Token "Token to send as since on the next call"
+// @Failure 400,500
+// @Router /members/changes [get]
+func MemberChanges(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	var since int64
+	if token := c.Param("since"); token != "" {
+		var err error
+		if since, err = strconv.ParseInt(token, 10, 64); err != nil || since < 0 {
+			return c.Error(http.StatusBadRequest, fmt.Errorf("invalid sync token %q", token))
+		}
+	}
+
+	// the feed stops at the last event when the request starts,
+	// that event is the token of the next call
+	until, err := models.LastMemberEventID(tx)
+	if err != nil {
+		return err
+	}
+	if until < since {
+		until = since
+	}
+
+	res := c.Response()
+	res.Header().Set("Content-Type", "application/x-ndjson")
+	res.Header().Set("X-Sync-Token", strconv.FormatInt(until, 10))
+	res.WriteHeader(http.StatusOK)
+
+	enc := json.NewEncoder(res)
+	after := since
+	for {
+		changes, err := models.FindMemberChanges(tx, since, until, after, memberChangesBatchSize)
+		if err != nil {
+			return err
+		}
+
+		if err := loadChangedMembers(tx, changes); err != nil {
+			return err
+		}
+
+		for _, change := range changes {
+			if err := enc.Encode(change); err != nil {
+				return err
+			}
+		}
+
+		if f, ok := res.(http.Flusher); ok {
+			f.Flush()
+		}
+
+		if len(changes) < memberChangesBatchSize {
+			return nil
+		}
+		after = changes[len(changes)-1].EventID
+	}
+}
+
+// loadChangedMembers sets the current state of the members created or updated,
+// and the sync token of each change. A member deleted after the last event
+// of the feed has no current state, it is returned as deleted.
+func loadChangedMembers(tx *pop.Connection, changes models.MemberChanges) error {
+	ids := []interface{}{}
+	for _, change := range changes {
+		if change.Change != "deleted" {
+			ids = append(ids, change.MemberID)
+		}
+	}
+
+	found := map[uuid.UUID]*models.Member{}
+	if len(ids) > 0 {
+		members := models.Members{}
+		if err := tx.Where("id in (?)", ids...).EagerPreload("Teams").All(&members); err != nil {
+			return err
+		}
+
+		for i := range members {
+			found[members[i].ID] = &members[i]
+		}
+	}
+
+	for i := range changes {
+		changes[i].SyncToken = strconv.FormatInt(changes[i].EventID, 10)
+		if changes[i].Change == "deleted" {
+			continue
+		}
+
+		if changes[i].Member = found[changes[i].MemberID]; changes[i].Member == nil {
+			changes[i].Change = "deleted"
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package actions
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"team_manager/models"
+
+	"github.com/gobuffalo/httptest"
+)
+
+func memberChangesFrom(res *httptest.JSONResponse) models.MemberChanges {
+	changes := models.MemberChanges{}
+	scanner := bufio.NewScanner(res.Body)
+	for scanner.Scan() {
+		change := models.MemberChange{}
+		if err := json.Unmarshal(scanner.Bytes(), &change); err == nil {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+func (as *ActionSuite) Test_MemberChanges() {
+	created := &models.Member{Name: "Created", Type: "employee", Role: "DevOps"}
+	as.NoError(models.DB.Create(created))
+
+	deleted := &models.Member{Name: "Deleted", Type: "employee", Role: "DevOps"}
+	as.NoError(models.DB.Create(deleted))
+	as.NoError(models.DB.Destroy(deleted))
+
+	res := as.JSON("/v1/members/changes").Get()
+	as.Equal(http.StatusOK, res.Code)
+	token := res.Header().Get("X-Sync-Token")
+	as.NotEmpty(token)
+
+	changes := memberChangesFrom(res)
+	as.Equal(2, len(changes))
+	as.Equal("created", changes[0].Change)
+	as.Equal(created.ID, changes[0].MemberID)
+	as.Equal(created.Name, changes[0].Member.Name)
+	as.Equal("deleted", changes[1].Change)
+	as.Equal(deleted.ID, changes[1].MemberID)
+	as.Nil(changes[1].Member)
+	as.Equal(token, changes[1].SyncToken)
+
+	created.Name = "Updated"
+	as.NoError(models.DB.Update(created))
+
+	res = as.JSON("/v1/members/changes?since=" + token).Get()
+	as.Equal(http.StatusOK, res.Code)
+
+	changes = memberChangesFrom(res)
+	as.Equal(1, len(changes))
+	as.Equal("updated", changes[0].Change)
+	as.Equal("Updated", changes[0].Member.Name)
+}
+
+func (as *ActionSuite) Test_MemberChanges_InvalidToken() {
+	res := as.JSON("/v1/members/changes?since=abc").Get()
+	as.Equal(http.StatusBadRequest, res.Code)
+}
@@ -0,0 +1,64 @@
+package actions
+
+import (
+	"fmt"
+	"net/http"
+	"team_manager/models"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gobuffalo/x/responder"
+)
+
+// MemberConvert converts a member to another type.
+// @Summary Convert a member to another type
+// @Description An employee converted to a contractor requires contract_start and contract_end, a contractor converted to an employee requires a role. The type changes right away, the member.type_changed event keeps the effective date (today by default, never in the future nor before the contract started) and the discarded values.
+// @ID convert-member
+// @Accept json,xml
+// @Produce json,xml
+// @Param member_id path string true "Member ID"
+// @Param conversion body models.MemberConversion true "Conversion"
+// @Success 200 {object} models.Member
+// @Failure 422 {object} validate.Errors
+// @Failure 404,500
+// @Router /members/{member_id}/convert [post]
+func MemberConvert(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	member := &models.Member{}
+	if err := tx.Find(member, c.Param("member_id")); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	conv := models.MemberConversion{}
+	if err := c.Bind(&conv); err != nil {
+		return err
+	}
+
+	verrs, err := member.Convert(tx, conv)
+	if err != nil {
+		return err
+	}
+
+	if verrs.HasAny() {
+		return responder.Wants("json", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.JSON(verrs))
+		}).Wants("xml", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.XML(verrs))
+		}).Respond(c)
+	}
+
+	if err := tx.Load(member, "Teams"); err != nil {
+		return err
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.JSON(member))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.XML(member))
+	}).Respond(c)
+}
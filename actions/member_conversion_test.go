@@ -0,0 +1,71 @@
+package actions
+
+import (
+	"encoding/json"
+	"net/http"
+	"team_manager/models"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/slices"
+)
+
+func (as *ActionSuite) Test_MemberConvert() {
+	as.LoadFixture("employees")
+
+	target := &models.Member{}
+	as.NoError(as.DB.Where("type = ?", "employee").First(target))
+
+	conv := models.MemberConversion{
+		Type:          "contractor",
+		ContractStart: nulls.NewTime(time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)),
+		ContractEnd:   nulls.NewTime(time.Date(2027, 4, 30, 0, 0, 0, 0, time.UTC)),
+	}
+	res := as.JSON("/v1/members/" + target.ID.String() + "/convert").Post(conv)
+	as.Equal(http.StatusOK, res.Code)
+
+	member := models.Member{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &member))
+	as.Equal("contractor", member.Type)
+	as.Equal("", member.Role)
+
+	count, err := as.DB.Where("member_id = ? AND type = ?", target.ID, models.MemberTypeChanged).Count(&models.MemberEvent{})
+	as.NoError(err)
+	as.Equal(1, count)
+}
+
+func (as *ActionSuite) Test_MemberConvert_WithoutRequiredFields() {
+	as.LoadFixture("employees")
+
+	target := &models.Member{}
+	as.NoError(as.DB.Where("type = ?", "employee").First(target))
+
+	res := as.JSON("/v1/members/" + target.ID.String() + "/convert").Post(models.MemberConversion{Type: "contractor"})
+	as.Equal(http.StatusUnprocessableEntity, res.Code)
+}
+
+func (as *ActionSuite) Test_MembersResource_Update_TypeChange() {
+	as.LoadFixture("employees")
+	as.NoError(as.DB.Create(&models.CustomField{Name: "badge_number", Type: models.CustomFieldNumber, MemberTypes: slices.String{"employee"}}))
+
+	target := &models.Member{}
+	as.NoError(as.DB.Where("type = ?", "employee").First(target))
+	target.CustomFields = models.CustomValues{"badge_number": 42.0}
+	as.NoError(as.DB.Update(target))
+
+	target.Type = "contractor"
+	target.ContractStart = nulls.NewTime(time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC))
+	target.ContractEnd = nulls.NewTime(time.Date(2027, 4, 30, 0, 0, 0, 0, time.UTC))
+
+	res := as.JSON("/v1/members/" + target.ID.String()).Put(target)
+	as.Equal(http.StatusUnprocessableEntity, res.Code)
+
+	// the badge number of the employee is discarded
+	res = as.JSON("/v1/members/" + target.ID.String() + "?override=true").Put(target)
+	as.Equal(http.StatusOK, res.Code)
+
+	member := &models.Member{}
+	as.NoError(as.DB.Find(member, target.ID))
+	as.Equal("contractor", member.Type)
+	as.Empty(member.CustomFields)
+}
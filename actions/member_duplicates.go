@@ -0,0 +1,96 @@
+package actions
+
+import (
+	"fmt"
+	"net/http"
+	"team_manager/models"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gobuffalo/x/responder"
+)
+
+// MemberDuplicates lists the pairs of members that are probably the same person.
+// @Summary List the probable duplicate members
+// @Description Two members with the same name (whatever the case and the spaces) or email are probable duplicates. The member of a pair is the oldest one, the suggested survivor of a merge.
+// @ID list-member-duplicates
+// @Produce json,xml
+// @Success 200 {object} models.DuplicatePairs
+// @Failure 500
+// @Router /members/duplicates [get]
+func MemberDuplicates(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	pairs, err := models.FindDuplicatePairs(tx)
+	if err != nil {
+		return err
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.JSON(pairs))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.XML(pairs))
+	}).Respond(c)
+}
+
+// MemberMerge folds a duplicate into a Member.
+// @Summary Merge a duplicate into a member
+// @Description The member survives: it gets the tags of the duplicate, the custom fields and the contact information it does not have, its contract and status history, assignments, checklists, teams, external IDs and reports. The duplicate is deleted, the member.merged event of the member keeps it.
+// @ID merge-member
+// @Accept json,xml
+// @Produce json,xml
+// @Param member_id path string true "Member ID"
+// @Param merge body models.MemberMerge true "Duplicate"
+// @Success 200 {object} models.Member
+// @Failure 422 {object} validate.Errors
+// @Failure 404,500
+// @Router /members/{member_id}/merge [post]
+func MemberMerge(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	member := &models.Member{}
+	if err := tx.Find(member, c.Param("member_id")); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	merge := models.MemberMerge{}
+	if err := c.Bind(&merge); err != nil {
+		return err
+	}
+
+	duplicate := &models.Member{}
+	if err := tx.Find(duplicate, merge.DuplicateID); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	verrs, err := member.Merge(tx, duplicate)
+	if err != nil {
+		return err
+	}
+
+	if verrs.HasAny() {
+		return responder.Wants("json", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.JSON(verrs))
+		}).Wants("xml", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.XML(verrs))
+		}).Respond(c)
+	}
+
+	if err := tx.Load(member, "Teams"); err != nil {
+		return err
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.JSON(member))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.XML(member))
+	}).Respond(c)
+}
@@ -0,0 +1,36 @@
+package actions
+
+import (
+	"encoding/json"
+	"net/http"
+	"team_manager/models"
+
+	"github.com/gobuffalo/pop/slices"
+)
+
+func (as *ActionSuite) Test_MemberDuplicates() {
+	jane := &models.Member{Name: "Jane Doe", Type: "employee", Role: "DevOps", Tags: slices.String{"golang"}}
+	as.NoError(models.DB.Create(jane))
+	duplicate := &models.Member{Name: "jane doe", Type: "employee", Role: "DevOps", Tags: slices.String{"kubernetes"}}
+	as.NoError(models.DB.Create(duplicate))
+	as.NoError(models.DB.Create(&models.Member{Name: "John Doe", Type: "employee", Role: "DevOps"}))
+
+	res := as.JSON("/v1/members/duplicates").Get()
+	as.Equal(http.StatusOK, res.Code)
+
+	pairs := models.DuplicatePairs{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &pairs))
+	as.Equal(1, len(pairs))
+	as.Equal(jane.ID, pairs[0].Member.ID)
+	as.Equal(duplicate.ID, pairs[0].Duplicate.ID)
+
+	res = as.JSON("/v1/members/" + jane.ID.String() + "/merge").Post(models.MemberMerge{DuplicateID: duplicate.ID})
+	as.Equal(http.StatusOK, res.Code)
+
+	member := models.Member{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &member))
+	as.Equal([]string{"golang", "kubernetes"}, []string(member.Tags))
+
+	res = as.JSON("/v1/members/" + jane.ID.String() + "/merge").Post(models.MemberMerge{DuplicateID: duplicate.ID})
+	as.Equal(http.StatusNotFound, res.Code)
+}
@@ -0,0 +1,97 @@
+package actions
+
+import (
+	"fmt"
+	"net/http"
+	"team_manager/models"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gobuffalo/x/responder"
+)
+
+// MemberTransitions lists the status history of a Member.
+// @Summary List the status transitions of a member
+// @Description Every status of the member, the oldest first, from the status it was created with.
+// @ID list-member-transitions
+// @Param member_id path string true "Member ID"
+// @Produce json,xml
+// @Success 200 {object} models.StatusTransitions
+// @Failure 404,500
+// @Router /members/{member_id}/transitions [get]
+func MemberTransitions(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	member := &models.Member{}
+	if err := tx.Find(member, c.Param("member_id")); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	transitions, err := models.FindStatusTransitions(tx, member.ID)
+	if err != nil {
+		return err
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.JSON(transitions))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.XML(transitions))
+	}).Respond(c)
+}
+
+// MemberTransition moves a Member to another status.
+// @Summary Change the status of a member
+// @Description The allowed transitions are: candidate to onboarding or offboarded, onboarding to active or offboarded, active to on_leave or offboarding, on_leave to active or offboarding, offboarding to active or offboarded, offboarded to onboarding. The effective date is today by default, it can not be in the future.
+// @ID transition-member
+// @Accept json,xml
+// @Produce json,xml
+// @Param member_id path string true "Member ID"
+// @Param change body models.StatusChange true "Transition"
+// @Success 200 {object} models.Member
+// @Failure 422 {object} validate.Errors
+// @Failure 404,500
+// @Router /members/{member_id}/transitions [post]
+func MemberTransition(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	member := &models.Member{}
+	if err := tx.Find(member, c.Param("member_id")); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	change := models.StatusChange{}
+	if err := c.Bind(&change); err != nil {
+		return err
+	}
+
+	verrs, err := member.Transition(tx, change)
+	if err != nil {
+		return err
+	}
+
+	if verrs.HasAny() {
+		return responder.Wants("json", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.JSON(verrs))
+		}).Wants("xml", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.XML(verrs))
+		}).Respond(c)
+	}
+
+	if err := tx.Load(member, "Teams"); err != nil {
+		return err
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.JSON(member))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.XML(member))
+	}).Respond(c)
+}
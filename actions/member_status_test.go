@@ -0,0 +1,45 @@
+package actions
+
+import (
+	"encoding/json"
+	"net/http"
+	"team_manager/models"
+)
+
+func (as *ActionSuite) Test_MemberTransition() {
+	m := &models.Member{Name: "Member Name", Type: "employee", Role: "DevOps"}
+	as.NoError(models.DB.Create(m))
+
+	res := as.JSON("/v1/members/" + m.ID.String() + "/transitions").Post(models.StatusChange{Status: models.StatusOffboarding, Reason: "Moving abroad"})
+	as.Equal(http.StatusOK, res.Code)
+
+	member := models.Member{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &member))
+	as.Equal(models.StatusOffboarding, member.Status)
+
+	res = as.JSON("/v1/members/" + m.ID.String() + "/transitions").Post(models.StatusChange{Status: models.StatusOnLeave})
+	as.Equal(http.StatusUnprocessableEntity, res.Code)
+
+	res = as.JSON("/v1/members/" + m.ID.String() + "/transitions").Get()
+	as.Equal(http.StatusOK, res.Code)
+
+	transitions := models.StatusTransitions{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &transitions))
+	as.Equal(2, len(transitions))
+
+	res = as.JSON("/v1/members?status=offboarding,offboarded").Get()
+	as.Equal(http.StatusOK, res.Code)
+
+	members := models.Members{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &members))
+	as.Equal(1, len(members))
+}
+
+func (as *ActionSuite) Test_MembersResource_Update_Status() {
+	m := &models.Member{Name: "Member Name", Type: "employee", Role: "DevOps"}
+	as.NoError(models.DB.Create(m))
+
+	m.Status = models.StatusOnLeave
+	res := as.JSON("/v1/members/" + m.ID.String()).Put(m)
+	as.Equal(http.StatusUnprocessableEntity, res.Code)
+}
@@ -0,0 +1,67 @@
+package actions
+
+import (
+	"encoding/json"
+	"net/http"
+	"team_manager/models"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/x/responder"
+	"github.com/swaggo/swag"
+)
+
+// MemberTypesList lists the member types.
+// @Summary List the member types
+// @Description The types are declared in config/member_types.toml, each with the fields it requires and the fields it forbids (cleared when the member is saved).
+// @ID list-member-types
+// @Produce json,xml
+// @Success 200 {array} models.MemberType
+// @Failure 500
+// @Router /member-types [get]
+func MemberTypesList(c buffalo.Context) error {
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.JSON(models.MemberTypes))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.XML(models.MemberTypes))
+	}).Respond(c)
+}
+
+// SwaggerDoc serves the swagger document, with the enums of
+// the member types generated from the types declared
+func SwaggerDoc(c buffalo.Context) error {
+	doc, err := swag.ReadDoc()
+	if err != nil {
+		return err
+	}
+
+	spec := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(doc), &spec); err != nil {
+		return err
+	}
+	setMemberTypeEnums(spec, models.MemberTypeNames())
+
+	return c.Render(http.StatusOK, r.JSON(spec))
+}
+
+// setMemberTypeEnums replaces the enums of member types in the swagger document,
+// the enums listing "employee", with the names of the types declared
+func setMemberTypeEnums(node interface{}, names []string) {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		if enum, ok := n["enum"].([]interface{}); ok {
+			for _, v := range enum {
+				if v == "employee" {
+					n["enum"] = names
+					break
+				}
+			}
+		}
+		for _, v := range n {
+			setMemberTypeEnums(v, names)
+		}
+	case []interface{}:
+		for _, v := range n {
+			setMemberTypeEnums(v, names)
+		}
+	}
+}
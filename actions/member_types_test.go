@@ -0,0 +1,33 @@
+package actions
+
+import (
+	"encoding/json"
+	"net/http"
+	"team_manager/models"
+)
+
+func (as *ActionSuite) Test_MemberTypesList() {
+	res := as.JSON("/v1/member-types").Get()
+	as.Equal(http.StatusOK, res.Code)
+
+	types := []models.MemberType{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &types))
+	as.Equal(len(models.MemberTypes), len(types))
+	as.Equal("employee", types[0].Name)
+	as.Equal([]string{"role"}, types[0].Required)
+}
+
+func (as *ActionSuite) Test_SwaggerDoc_MemberTypeEnums() {
+	res := as.JSON("/v1/doc/doc.json").Get()
+	as.Equal(http.StatusOK, res.Code)
+
+	spec := struct {
+		Definitions map[string]struct {
+			Properties map[string]struct {
+				Enum []string `json:"enum"`
+			} `json:"properties"`
+		} `json:"definitions"`
+	}{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &spec))
+	as.Equal(models.MemberTypeNames(), spec.Definitions["models.Member"].Properties["type"].Enum)
+}
@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"strconv"
 	"team_manager/models"
 
 	"github.com/gobuffalo/buffalo"
 	"github.com/gobuffalo/pop/v5"
+	"github.com/gobuffalo/validate/v3"
 	"github.com/gobuffalo/x/responder"
 )
 
@@ -18,10 +20,20 @@ type MembersResource struct {
 
 // List gets all Members.
 // @Summary List members
+// @Description The list can also be exported as CSV, NDJSON or XLSX, either with the Accept header or the format param. Exports stream every member matching the filters, pagination does not apply.
 // @ID list-members
 // @Param page query integer false "Go to the page"
 // @Param per_page query integer false "How many member per pages"
-// @Produce json,xml
+// @Param type query string false "Only members of this type" Enums(employee, contractor, intern, vendor, advisor)
+// @Param role query string false "Only members with this role (case insensitive)"
+// @Param name query string false "Only members whose name contains it (case insensitive)"
+// @Param team query string false "Only members of this team (ID)"
+// @Param unit query string false "Only members of this team (ID) or of its sub-teams"
+// @Param status query string false "Only members in one of these statuses (comma separated)"
+// @Param tags query string false "Only members having all these tags (comma separated)"
+// @Param custom query string false "Only members having these custom field values (comma separated name:value pairs)"
+// @Param format query string false "Export format" Enums(csv, ndjson, xlsx)
+// @Produce json,xml,text/csv,application/x-ndjson,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
 // @Success 200 {object} models.Members
 // @Failure 500
 // @Router /members [get]
@@ -32,11 +44,17 @@ func (v MembersResource) List(c buffalo.Context) error {
 		return fmt.Errorf("no transaction found")
 	}
 
+	filter := models.MemberFilterFromParams(c.Params())
+
+	if export, ok := findMemberExport(c); ok {
+		return streamMembers(c, tx, filter, export)
+	}
+
 	members := models.Members{}
 
 	// Paginate results. Params "page" and "per_page" control pagination.
 	// Default values are "page=1" and "per_page=20".
-	q := tx.PaginateFromParams(c.Params())
+	q := tx.PaginateFromParams(c.Params()).Scope(filter.Scope).EagerPreload("Teams")
 
 	// Retrieve all Members from the DB
 	if err := q.All(&members); err != nil {
@@ -69,7 +87,7 @@ func (v MembersResource) Show(c buffalo.Context) error {
 	member := &models.Member{}
 
 	// To find the Member the parameter member_id is used.
-	if err := tx.Find(member, c.Param("member_id")); err != nil {
+	if err := tx.EagerPreload("Teams").Find(member, c.Param("member_id")); err != nil {
 		return c.Error(http.StatusNotFound, err)
 	}
 
@@ -82,12 +100,14 @@ func (v MembersResource) Show(c buffalo.Context) error {
 
 // Create adds a Member to the DB.
 // @Summary Create a new member
-// @Description Create a new member, employee only accepts role, contractor only accepts contract_duration
+// @Description Create a new member, employee only accepts role, contractor only accepts contract_start and contract_end (contract_duration is derived from them). A member with the same name (whatever the case and the spaces) or email as another one is a probable duplicate, the creation is rejected with the candidates unless force is set.
 // @ID create-member
 // @Accept json,xml
 // @Produce json,xml
 // @Param member body models.Member true "Member Payload"
+// @Param force query boolean false "Create the member even if it has probable duplicates"
 // @Success 201 {object} models.Members
+// @Failure 409 {object} models.DuplicateCandidates
 // @Failure 422 {object} validate.Errors
 // @Failure 500
 // @Router /members [post]
@@ -108,8 +128,25 @@ func (v MembersResource) Create(c buffalo.Context) error {
 
 	log.Printf("%+v", member)
 
-	// Validate the data from the request
-	verrs, err := tx.ValidateAndCreate(member)
+	// a member with the same name or email is probably a duplicate,
+	// the candidates are returned unless the creation is forced
+	if force, _ := strconv.ParseBool(c.Param("force")); !force {
+		candidates, err := models.FindDuplicates(tx, member)
+		if err != nil {
+			return err
+		}
+
+		if len(candidates) > 0 {
+			return responder.Wants("json", func(c buffalo.Context) error {
+				return c.Render(http.StatusConflict, r.JSON(candidates))
+			}).Wants("xml", func(c buffalo.Context) error {
+				return c.Render(http.StatusConflict, r.XML(candidates))
+			}).Respond(c)
+		}
+	}
+
+	// Validate the data from the request, an email already taken is a validation error
+	verrs, err := models.ConstraintErrors(tx.ValidateAndCreate(member))
 	if err != nil {
 		return err
 	}
@@ -131,10 +168,12 @@ func (v MembersResource) Create(c buffalo.Context) error {
 
 // Update changes a Member in the DB.
 // @Summary Update a member
+// @Description The type is changed with /members/{member_id}/convert, a change of type is rejected unless override is set (the fields the new type does not accept are then discarded). The status is changed with /members/{member_id}/transitions.
 // @ID update-member
 // @Accept json,xml
 // @Produce json,xml
 // @Param member_id path string true "Member ID"
+// @Param override query boolean false "Allow the change of type"
 // @Success 200 {object} models.Members
 // @Failure 422 {object} validate.Errors
 // @Failure 500
@@ -153,12 +192,7 @@ func (v MembersResource) Update(c buffalo.Context) error {
 		return c.Error(http.StatusNotFound, err)
 	}
 
-	// Bind Member to the request payload
-	if err := c.Bind(member); err != nil {
-		return err
-	}
-
-	verrs, err := tx.ValidateAndUpdate(member)
+	verrs, err := bindAndUpdateMember(c, tx, member)
 	if err != nil {
 		return err
 	}
@@ -171,6 +205,11 @@ func (v MembersResource) Update(c buffalo.Context) error {
 		}).Respond(c)
 	}
 
+	// teams are managed on /teams/{team_id}/members, not through the member
+	if err := tx.Load(member, "Teams"); err != nil {
+		return err
+	}
+
 	return responder.Wants("json", func(c buffalo.Context) error {
 		return c.Render(http.StatusOK, r.JSON(member))
 	}).Wants("xml", func(c buffalo.Context) error {
@@ -178,6 +217,45 @@ func (v MembersResource) Update(c buffalo.Context) error {
 	}).Respond(c)
 }
 
+// bindAndUpdateMember binds the request payload to the member and saves it.
+// The type is changed with a conversion, which keeps what it discards,
+// unless override is set, and the status with a transition.
+func bindAndUpdateMember(c buffalo.Context, tx *pop.Connection, member *models.Member) (*validate.Errors, error) {
+	storedType, storedStatus := member.Type, member.Status
+
+	// Bind Member to the request payload
+	if err := c.Bind(member); err != nil {
+		return nil, err
+	}
+
+	override, _ := strconv.ParseBool(c.Param("override"))
+	return updateMember(tx, member, storedType, storedStatus, override)
+}
+
+// updateMember saves the changes of the member, its type and status were
+// the stored ones. The type can only change with override, and the status never.
+func updateMember(tx *pop.Connection, member *models.Member, storedType, storedStatus string, override bool) (*validate.Errors, error) {
+	verrs := validate.NewErrors()
+	if member.Type != storedType && !override {
+		verrs.Add("type", "Use /members/{member_id}/convert to change the type, or set override.")
+		return verrs, nil
+	}
+	if member.Status != storedStatus {
+		verrs.Add("status", "Use /members/{member_id}/transitions to change the status.")
+		return verrs, nil
+	}
+
+	// like a conversion, the new type drops the custom fields of the old one
+	if member.Type != storedType {
+		if err := member.DiscardCustomFields(tx); err != nil {
+			return verrs, err
+		}
+	}
+
+	// an email already taken is a validation error
+	return models.ConstraintErrors(tx.ValidateAndUpdate(member))
+}
+
 // Destroy deletes a Member from the DB.
 // @Summary Delete a member
 // @ID delete-member
@@ -0,0 +1,235 @@
+package actions
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"team_manager/models"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/xuri/excelize/v2"
+)
+
+// memberExportBatchSize is how many members are read
+// from the database at a time while streaming an export
+const memberExportBatchSize = 100
+
+// memberEncoder writes members one by one in a given format,
+// Close must be called once all the members have been encoded
+type memberEncoder interface {
+	Encode(m models.Member) error
+	Close() error
+}
+
+// memberExport is a format the member list can be exported to
+type memberExport struct {
+	format      string
+	contentType string
+	attachment  bool
+	newEncoder  func(w io.Writer) (memberEncoder, error)
+}
+
+var memberExports = []memberExport{
+	{format: "csv", contentType: "text/csv", attachment: true, newEncoder: newMemberCSVEncoder},
+	{format: "ndjson", contentType: "application/x-ndjson", newEncoder: newMemberNDJSONEncoder},
+	{format: "xlsx", contentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", attachment: true, newEncoder: newMemberXLSXEncoder},
+}
+
+// memberExportColumns are the header of the tabular exports (CSV and XLSX)
+var memberExportColumns = []string{"id", "name", "type", "status", "role", "email", "phone", "chat_handle", "location", "time_zone", "contract_start", "contract_end", "contract_duration", "tags", "teams", "custom_fields"}
+
+// memberExportRow turns a member into a row matching memberExportColumns
+func memberExportRow(m models.Member) []string {
+	start, end := "", ""
+	if m.ContractStart.Valid {
+		start = m.ContractStart.Time.Format("2006-01-02")
+	}
+	if m.ContractEnd.Valid {
+		end = m.ContractEnd.Time.Format("2006-01-02")
+	}
+
+	teams := make([]string, len(m.Teams))
+	for i, t := range m.Teams {
+		teams[i] = t.Name
+	}
+
+	// the custom fields are a JSON object in a single cell
+	custom := ""
+	if len(m.CustomFields) > 0 {
+		b, _ := json.Marshal(m.CustomFields)
+		custom = string(b)
+	}
+
+	return []string{m.ID.String(), m.Name, m.Type, m.Status, m.Role, m.Email.String, m.Phone.String, m.ChatHandle.String, m.Location.String, m.TimeZone.String, start, end, m.ContractDuration, m.Tags.Format(";"), strings.Join(teams, ";"), custom}
+}
+
+// findMemberExport returns the export requested either by the "format" param
+// or by the Accept header, the JSON and XML responses are not exports
+func findMemberExport(c buffalo.Context) (memberExport, bool) {
+	format := strings.ToLower(c.Param("format"))
+	accept := strings.ToLower(c.Request().Header.Get("Accept"))
+
+	for _, e := range memberExports {
+		if format == e.format || (format == "" && strings.Contains(accept, e.contentType)) {
+			return e, true
+		}
+	}
+
+	return memberExport{}, false
+}
+
+// streamMembers writes every member matching the filter using the export encoder.
+// Members are loaded in batches and the response is flushed after each one,
+// so the whole list is never held in memory. The first batch is loaded before
+// anything is written: an error then is still a 500, a later one truncates the export.
+func streamMembers(c buffalo.Context, tx *pop.Connection, filter models.MemberFilter, e memberExport) error {
+	batch, err := findMemberBatch(tx, filter, nil)
+	if err != nil {
+		return err
+	}
+
+	res := c.Response()
+	res.Header().Set("Content-Type", e.contentType)
+	if e.attachment {
+		res.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"members.%s\"", e.format))
+	}
+
+	enc, err := e.newEncoder(res)
+	if err != nil {
+		return err
+	}
+
+	res.WriteHeader(http.StatusOK)
+
+	for {
+		for _, m := range batch {
+			if err := enc.Encode(m); err != nil {
+				return err
+			}
+		}
+
+		if f, ok := res.(http.Flusher); ok {
+			f.Flush()
+		}
+
+		if len(batch) < memberExportBatchSize {
+			break
+		}
+		if batch, err = findMemberBatch(tx, filter, &batch[len(batch)-1]); err != nil {
+			return err
+		}
+	}
+
+	return enc.Close()
+}
+
+// findMemberBatch returns the members matching the filter after the last one,
+// from the first one when last is nil
+func findMemberBatch(tx *pop.Connection, filter models.MemberFilter, last *models.Member) (models.Members, error) {
+	batch := models.Members{}
+	bq := tx.Q().Scope(filter.Scope)
+
+	// keyset pagination keeps each batch cheap whatever the size of the list
+	if last != nil {
+		bq = bq.Where("(members.created_at, members.id) > (?, ?)", last.CreatedAt, last.ID)
+	}
+
+	err := bq.Order("members.created_at, members.id").Limit(memberExportBatchSize).EagerPreload("Teams").All(&batch)
+	return batch, err
+}
+
+type memberCSVEncoder struct {
+	w *csv.Writer
+}
+
+func newMemberCSVEncoder(w io.Writer) (memberEncoder, error) {
+	enc := &memberCSVEncoder{w: csv.NewWriter(w)}
+	return enc, enc.w.Write(memberExportColumns)
+}
+
+// Encode writes the member as a CSV record
+func (e *memberCSVEncoder) Encode(m models.Member) error {
+	if err := e.w.Write(memberExportRow(m)); err != nil {
+		return err
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// Close flushes the pending records
+func (e *memberCSVEncoder) Close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+type memberNDJSONEncoder struct {
+	enc *json.Encoder
+}
+
+func newMemberNDJSONEncoder(w io.Writer) (memberEncoder, error) {
+	return &memberNDJSONEncoder{enc: json.NewEncoder(w)}, nil
+}
+
+// Encode writes the member as a JSON document on its own line
+func (e *memberNDJSONEncoder) Encode(m models.Member) error {
+	return e.enc.Encode(m)
+}
+
+// Close has nothing to flush, every member is written as soon as it is encoded
+func (e *memberNDJSONEncoder) Close() error {
+	return nil
+}
+
+type memberXLSXEncoder struct {
+	w    io.Writer
+	file *excelize.File
+	sw   *excelize.StreamWriter
+	row  int
+}
+
+func newMemberXLSXEncoder(w io.Writer) (memberEncoder, error) {
+	file := excelize.NewFile()
+	sw, err := file.NewStreamWriter("Sheet1")
+	if err != nil {
+		return nil, err
+	}
+
+	enc := &memberXLSXEncoder{w: w, file: file, sw: sw}
+	return enc, enc.setRow(memberExportColumns)
+}
+
+func (e *memberXLSXEncoder) setRow(values []string) error {
+	e.row++
+	cell, err := excelize.CoordinatesToCellName(1, e.row)
+	if err != nil {
+		return err
+	}
+
+	row := make([]interface{}, len(values))
+	for i, v := range values {
+		row[i] = v
+	}
+
+	return e.sw.SetRow(cell, row)
+}
+
+// Encode appends the member as a row of the sheet.
+// The stream writer spills to a temporary file once it grows large,
+// the workbook itself can only be written once it is complete.
+func (e *memberXLSXEncoder) Encode(m models.Member) error {
+	return e.setRow(memberExportRow(m))
+}
+
+// Close completes the workbook and writes it
+func (e *memberXLSXEncoder) Close() error {
+	if err := e.sw.Flush(); err != nil {
+		return err
+	}
+
+	_, err := e.file.WriteTo(e.w)
+	return err
+}
@@ -0,0 +1,86 @@
+package actions
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"team_manager/models"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/xuri/excelize/v2"
+)
+
+func (as *ActionSuite) Test_MembersResource_List_CSV() {
+	as.LoadFixture("employees")
+	as.LoadFixture("contractors")
+
+	req := as.HTML("/v1/members?type=employee")
+	req.Headers["Accept"] = "text/csv"
+	res := req.Get()
+	as.Equal(http.StatusOK, res.Code)
+	as.Contains(res.Header().Get("Content-Type"), "text/csv")
+
+	records, err := csv.NewReader(res.Body).ReadAll()
+	as.NoError(err)
+	as.Equal(4, len(records))
+	as.Equal(memberExportColumns, records[0])
+	for _, r := range records[1:] {
+		as.Equal("employee", r[2])
+	}
+}
+
+func (as *ActionSuite) Test_MembersResource_List_NDJSON() {
+	as.LoadFixture("employees")
+	as.LoadFixture("contractors")
+
+	res := as.JSON("/v1/members?format=ndjson&type=contractor").Get()
+	as.Equal(http.StatusOK, res.Code)
+	as.Contains(res.Header().Get("Content-Type"), "application/x-ndjson")
+
+	count := 0
+	scanner := bufio.NewScanner(res.Body)
+	for scanner.Scan() {
+		m := models.Member{}
+		as.NoError(json.Unmarshal(scanner.Bytes(), &m))
+		as.Equal("contractor", m.Type)
+		count++
+	}
+	as.Equal(3, count)
+}
+
+func (as *ActionSuite) Test_MembersResource_List_XLSX() {
+	as.LoadFixture("employees")
+	as.LoadFixture("contractors")
+
+	res := as.JSON("/v1/members?format=xlsx").Get()
+	as.Equal(http.StatusOK, res.Code)
+
+	f, err := excelize.OpenReader(res.Body)
+	as.NoError(err)
+
+	rows, err := f.GetRows("Sheet1")
+	as.NoError(err)
+	as.Equal(7, len(rows))
+	as.Equal(memberExportColumns, rows[0])
+}
+
+func (as *ActionSuite) Test_MembersResource_List_Export_Error() {
+	// a database nothing listens to fails the first batch
+	tx, err := pop.NewConnection(&pop.ConnectionDetails{Dialect: "postgres", Host: "127.0.0.1", Port: "1", Database: "none", User: "none"})
+	as.NoError(err)
+	as.NoError(tx.Open())
+	defer tx.Close()
+
+	app := buffalo.New(buffalo.Options{Env: "test"})
+	app.GET("/members", func(c buffalo.Context) error {
+		return streamMembers(c, tx, models.MemberFilter{}, memberExports[0])
+	})
+
+	res := httptest.NewRecorder()
+	app.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/members", nil))
+	as.Equal(http.StatusInternalServerError, res.Code)
+	as.NotContains(res.Header().Get("Content-Type"), "text/csv")
+}
@@ -5,7 +5,9 @@ import (
 	"net/http"
 	"strings"
 	"team_manager/models"
+	"time"
 
+	"github.com/gobuffalo/nulls"
 	"github.com/gobuffalo/pop/slices"
 )
 
@@ -72,11 +74,51 @@ func (as *ActionSuite) Test_MembersResource_Create_EmployeeWithoutRole() {
 	as.Equal(http.StatusUnprocessableEntity, res.Code)
 }
 
+func (as *ActionSuite) Test_MembersResource_Create_EmailTaken() {
+	m := &models.Member{
+		Name:  "Member Name",
+		Type:  "employee",
+		Role:  "DevOps",
+		Email: nulls.NewString("jane@example.com"),
+		Phone: nulls.NewString("+33 6 12 34 56 78"),
+	}
+	res := as.JSON("/v1/members").Post(m)
+	as.Equal(http.StatusCreated, res.Code)
+
+	employee := models.Member{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &employee))
+	as.Equal("+33612345678", employee.Phone.String)
+
+	m.Email = nulls.NewString("JANE@example.com")
+	res = as.JSON("/v1/members?force=true").Post(m)
+	as.Equal(http.StatusUnprocessableEntity, res.Code)
+	as.Contains(res.Body.String(), "Email is already taken.")
+}
+
+func (as *ActionSuite) Test_MembersResource_Create_Duplicate() {
+	existing := &models.Member{Name: "Jane Doe", Type: "employee", Role: "DevOps"}
+	as.NoError(models.DB.Create(existing))
+
+	m := &models.Member{Name: " jane  DOE", Type: "employee", Role: "DevOps"}
+	res := as.JSON("/v1/members").Post(m)
+	as.Equal(http.StatusConflict, res.Code)
+
+	candidates := models.DuplicateCandidates{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &candidates))
+	as.Equal(1, len(candidates))
+	as.Equal(existing.ID, candidates[0].Member.ID)
+	as.Equal([]string{models.DuplicateName}, candidates[0].Reasons)
+
+	res = as.JSON("/v1/members?force=true").Post(m)
+	as.Equal(http.StatusCreated, res.Code)
+}
+
 func (as *ActionSuite) Test_MembersResource_Create_Contractor() {
 	m := &models.Member{
-		Name:             "Member Name",
-		Type:             "contractor",
-		ContractDuration: 500,
+		Name:          "Member Name",
+		Type:          "contractor",
+		ContractStart: nulls.NewTime(time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)),
+		ContractEnd:   nulls.NewTime(time.Date(2028, 3, 1, 0, 0, 0, 0, time.UTC)),
 	}
 	res := as.JSON("/v1/members").Post(m)
 	as.Equal(http.StatusCreated, res.Code)
@@ -87,6 +129,7 @@ func (as *ActionSuite) Test_MembersResource_Create_Contractor() {
 	as.Equal(m.Name, contractor.Name)
 	as.Equal(m.Type, contractor.Type)
 	as.Equal(len(m.Tags), len(contractor.Tags))
+	as.Equal("P1Y4M", contractor.ContractDuration)
 }
 
 func (as *ActionSuite) Test_MembersResource_Create_Contractor_WithoutContractDuration() {
@@ -153,3 +196,29 @@ func (as *ActionSuite) Test_MembersResource_Destroy() {
 	as.Equal(http.StatusNoContent, res.Code)
 	as.Error(as.DB.Find(&models.Member{}, target.ID))
 }
+
+func (as *ActionSuite) Test_MembersResource_List_Filters() {
+	as.LoadFixture("employees")
+	as.LoadFixture("contractors")
+
+	tests := []struct {
+		query string
+		count int
+	}{
+		{"type=contractor", 3},
+		{"type=employee&role=devops", 1},
+		{"name=employee", 3},
+		{"tags=golang,Kubernetes", 1},
+		{"type=employee&tags=golang", 0},
+	}
+
+	for _, tt := range tests {
+		res := as.JSON("/v1/members?" + tt.query).Get()
+		as.Equal(http.StatusOK, res.Code)
+
+		members := models.Members{}
+		err := json.Unmarshal(res.Body.Bytes(), &members)
+		as.NoError(err)
+		as.Equal(tt.count, len(members), tt.query)
+	}
+}
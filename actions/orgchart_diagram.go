@@ -0,0 +1,179 @@
+package actions
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"team_manager/models"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/buffalo/render"
+	"github.com/gobuffalo/pop/v5"
+)
+
+// OrgChartDOT renders the org chart as a Graphviz DOT digraph.
+// @Summary Org chart as Graphviz DOT
+// @Description Each member is a box labelled with its name and its role (employees) or contract (contractors), every arrow goes from a manager to a report. Render it with `dot -Tsvg`.
+// @ID orgchart-dot
+// @Param root query string false "Only the tree under this member"
+// @Produce text/vnd.graphviz
+// @Success 200 {string} string
+// @Failure 404,500
+// @Router /orgchart.dot [get]
+func OrgChartDOT(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	chart, err := findOrgChart(c, tx)
+	if err != nil {
+		return err
+	}
+
+	return c.Render(200, dot(func(w io.Writer, d render.Data) error {
+		return writeOrgChartDOT(w, chart)
+	}))
+}
+
+// OrgChartMermaid renders the org chart as a Mermaid flowchart.
+// @Summary Org chart as Mermaid
+// @Description Each member is a node labelled with its name and its role (employees) or contract (contractors), every arrow goes from a manager to a report. Paste it in a mermaid code block.
+// @ID orgchart-mermaid
+// @Param root query string false "Only the tree under this member"
+// @Produce text/vnd.mermaid
+// @Success 200 {string} string
+// @Failure 404,500
+// @Router /orgchart.mmd [get]
+func OrgChartMermaid(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	chart, err := findOrgChart(c, tx)
+	if err != nil {
+		return err
+	}
+
+	return c.Render(200, mermaid(func(w io.Writer, d render.Data) error {
+		return writeOrgChartMermaid(w, chart)
+	}))
+}
+
+type orgChartStyle struct{ dot, mermaid string }
+
+// orgChartStyles are the node styles of each member type,
+// in DOT attributes and in Mermaid class definition
+var orgChartStyles = map[string]orgChartStyle{
+	"employee":   {dot: `fillcolor="#dbeafe", color="#1e40af"`, mermaid: "fill:#dbeafe,stroke:#1e40af"},
+	"contractor": {dot: `fillcolor="#fef3c7", color="#b45309", style="rounded,filled,dashed"`, mermaid: "fill:#fef3c7,stroke:#b45309,stroke-dasharray:5 5"},
+}
+
+// orgChartDefaultStyle is the node style of the other member types
+var orgChartDefaultStyle = orgChartStyle{dot: `fillcolor="#f3f4f6", color="#4b5563"`, mermaid: "fill:#f3f4f6,stroke:#4b5563"}
+
+// findOrgChartStyle returns the node style of a member type
+func findOrgChartStyle(memberType string) orgChartStyle {
+	if style, ok := orgChartStyles[memberType]; ok {
+		return style
+	}
+	return orgChartDefaultStyle
+}
+
+// orgChartLabel is the lines of the label of a member:
+// its name, then its role and its contract, or else its type
+func orgChartLabel(n *models.OrgChartNode) []string {
+	lines := []string{n.Name}
+	if n.Role != "" {
+		lines = append(lines, n.Role)
+	}
+	switch {
+	case n.ContractEnd != "":
+		lines = append(lines, strings.Title(n.Type)+" until "+n.ContractEnd)
+	case n.Role == "":
+		lines = append(lines, strings.Title(n.Type))
+	}
+	return lines
+}
+
+// walkOrgChart calls fn for each member of the chart, managers before their reports,
+// manager is nil for the members at the top of the chart
+func walkOrgChart(nodes []*models.OrgChartNode, manager *models.OrgChartNode, fn func(n, manager *models.OrgChartNode) error) error {
+	for _, n := range nodes {
+		if err := fn(n, manager); err != nil {
+			return err
+		}
+		if err := walkOrgChart(n.Reports, n, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeOrgChartDOT writes the chart as a DOT digraph
+func writeOrgChartDOT(w io.Writer, chart models.OrgChart) error {
+	if _, err := io.WriteString(w, "digraph orgchart {\n\trankdir=TB;\n\tnode [shape=box, style=\"rounded,filled\", fontname=\"Helvetica\"];\n"); err != nil {
+		return err
+	}
+
+	err := walkOrgChart(chart, nil, func(n, manager *models.OrgChartNode) error {
+		lines := orgChartLabel(n)
+		for i := range lines {
+			lines[i] = strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(lines[i])
+		}
+
+		if _, err := fmt.Fprintf(w, "\t\"%s\" [label=\"%s\", %s];\n", n.ID, strings.Join(lines, `\n`), findOrgChartStyle(n.Type).dot); err != nil {
+			return err
+		}
+
+		if manager == nil {
+			return nil
+		}
+		_, err := fmt.Fprintf(w, "\t\"%s\" -> \"%s\";\n", manager.ID, n.ID)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "}\n")
+	return err
+}
+
+// writeOrgChartMermaid writes the chart as a Mermaid top-down flowchart
+func writeOrgChartMermaid(w io.Writer, chart models.OrgChart) error {
+	if _, err := io.WriteString(w, "graph TD\n"); err != nil {
+		return err
+	}
+
+	for _, t := range models.MemberTypeNames() {
+		if _, err := fmt.Fprintf(w, "\tclassDef %s %s\n", t, findOrgChartStyle(t).mermaid); err != nil {
+			return err
+		}
+	}
+
+	// mermaid node IDs are alphanumeric
+	id := func(n *models.OrgChartNode) string {
+		return "m" + strings.Replace(n.ID.String(), "-", "", -1)
+	}
+
+	return walkOrgChart(chart, nil, func(n, manager *models.OrgChartNode) error {
+		lines := orgChartLabel(n)
+		for i := range lines {
+			lines[i] = strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;").Replace(lines[i])
+		}
+
+		if _, err := fmt.Fprintf(w, "\t%s[\"%s\"]:::%s\n", id(n), strings.Join(lines, "<br/>"), n.Type); err != nil {
+			return err
+		}
+
+		if manager == nil {
+			return nil
+		}
+		_, err := fmt.Fprintf(w, "\t%s --> %s\n", id(manager), id(n))
+		return err
+	})
+}
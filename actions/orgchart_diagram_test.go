@@ -0,0 +1,46 @@
+package actions
+
+import (
+	"net/http"
+	"strings"
+	"team_manager/models"
+)
+
+func (as *ActionSuite) Test_OrgChartDOT() {
+	as.LoadFixture("reporting")
+
+	ceo := &models.Member{}
+	as.NoError(as.DB.Where("name = ?", "Alice CEO").First(ceo))
+	cto := &models.Member{}
+	as.NoError(as.DB.Where("name = ?", "Bob CTO").First(cto))
+
+	res := as.HTML("/v1/orgchart.dot").Get()
+	as.Equal(http.StatusOK, res.Code)
+	as.Contains(res.Header().Get("Content-Type"), "text/vnd.graphviz")
+
+	body := res.Body.String()
+	as.Contains(body, "digraph orgchart {")
+	as.Contains(body, `[label="Bob CTO\nChief Technology Officer", fillcolor="#dbeafe"`)
+	as.Contains(body, `[label="Eve Contractor\nContractor until 2026-12-31"`)
+	as.Contains(body, `style="rounded,filled,dashed"`)
+	as.Contains(body, `"`+ceo.ID.String()+`" -> "`+cto.ID.String()+`";`)
+}
+
+func (as *ActionSuite) Test_OrgChartMermaid() {
+	as.LoadFixture("reporting")
+
+	cto := &models.Member{}
+	as.NoError(as.DB.Where("name = ?", "Bob CTO").First(cto))
+
+	res := as.HTML("/v1/orgchart.mmd?root=" + cto.ID.String()).Get()
+	as.Equal(http.StatusOK, res.Code)
+	as.Contains(res.Header().Get("Content-Type"), "text/vnd.mermaid")
+
+	body := res.Body.String()
+	as.Contains(body, "graph TD\n")
+	as.Contains(body, "classDef contractor")
+	as.Contains(body, `["Bob CTO<br/>Chief Technology Officer"]:::employee`)
+	as.Contains(body, `["Eve Contractor<br/>Contractor until 2026-12-31"]:::contractor`)
+	as.NotContains(body, "Alice CEO")
+	as.Equal(2, strings.Count(body, "-->"))
+}
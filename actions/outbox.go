@@ -0,0 +1,159 @@
+package actions
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"team_manager/models"
+	"time"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/envy"
+	"github.com/gobuffalo/pop/v5"
+)
+
+// dispatchOutboxJob dispatches the pending outbox messages to their sink
+const dispatchOutboxJob = "outbox:dispatch"
+
+// outboxDispatchInterval is how often the pending messages are looked for
+var outboxDispatchInterval = 2 * time.Second
+
+// EventSink handles the member events dispatched from the outbox.
+// An event is dispatched at least once: a sink can get it again
+// when the dispatch failed or was interrupted.
+type EventSink interface {
+	Dispatch(tx *pop.Connection, e *models.MemberEvent) error
+}
+
+// eventSinks are the sinks available, by name,
+// OUTBOX_SINKS tells which ones get the events
+var eventSinks = map[string]EventSink{}
+
+// RegisterEventSink makes a sink available under a name,
+// it gets the events once its name is listed in OUTBOX_SINKS
+func RegisterEventSink(name string, s EventSink) {
+	eventSinks[name] = s
+}
+
+// registerOutboxWorker registers the built-in sinks and the dispatch job on the app worker.
+// OUTBOX_SINKS is the comma separated list of the sinks getting the events
+// (default "webhooks"), the file sink appends them to OUTBOX_FILE.
+func registerOutboxWorker(app *buffalo.App) {
+	RegisterEventSink("webhooks", webhooksSink{})
+	RegisterEventSink("log", logSink{logger: app.Logger})
+	RegisterEventSink("file", &fileSink{path: envy.Get("OUTBOX_FILE", "log/events.ndjson")})
+
+	models.OutboxSinks = splitList(envy.Get("OUTBOX_SINKS", "webhooks"))
+
+	registerPeriodicJob(app, dispatchOutboxJob, outboxDispatchInterval, func() error {
+		return dispatchOutbox(models.DB, time.Now)
+	})
+}
+
+// dispatchOutbox dispatches every message due, one transaction each:
+// the message is marked as dispatched along with what the sink wrote
+func dispatchOutbox(db *pop.Connection, now func() time.Time) error {
+	for {
+		found := false
+		err := db.Transaction(func(tx *pop.Connection) error {
+			msg, ok, err := models.NextDueOutboxMessage(tx, now())
+			if err != nil || !ok {
+				return err
+			}
+			found = true
+
+			if err := dispatchOutboxMessage(tx, msg); err != nil {
+				msg.Failed(err.Error(), now())
+			} else {
+				msg.Dispatched(now())
+			}
+
+			return tx.Update(msg)
+		})
+
+		if err != nil || !found {
+			return err
+		}
+	}
+}
+
+// dispatchOutboxMessage hands the event to the sink of the message.
+// The sink runs in a savepoint, what it wrote is discarded if it fails.
+func dispatchOutboxMessage(tx *pop.Connection, msg *models.OutboxMessage) error {
+	sink, ok := eventSinks[msg.Sink]
+	if !ok {
+		return fmt.Errorf("unknown sink %q", msg.Sink)
+	}
+
+	e := &models.MemberEvent{}
+	if err := tx.Find(e, msg.EventID); err != nil {
+		return err
+	}
+
+	if err := tx.RawQuery("SAVEPOINT outbox_dispatch").Exec(); err != nil {
+		return err
+	}
+
+	if err := sink.Dispatch(tx, e); err != nil {
+		if rerr := tx.RawQuery("ROLLBACK TO SAVEPOINT outbox_dispatch").Exec(); rerr != nil {
+			return rerr
+		}
+		return err
+	}
+
+	return tx.RawQuery("RELEASE SAVEPOINT outbox_dispatch").Exec()
+}
+
+// webhooksSink creates a delivery of the event for each webhook subscribed to it
+type webhooksSink struct{}
+
+// Dispatch enqueues the webhook deliveries
+func (webhooksSink) Dispatch(tx *pop.Connection, e *models.MemberEvent) error {
+	return models.EnqueueWebhookDeliveries(tx, e)
+}
+
+// logSink writes the events to the app log
+type logSink struct {
+	logger buffalo.Logger
+}
+
+// Dispatch logs the event
+func (s logSink) Dispatch(tx *pop.Connection, e *models.MemberEvent) error {
+	s.logger.WithField("event_id", e.ID).WithField("member_id", e.MemberID).Infof("%s", e.Type)
+	return nil
+}
+
+// fileSink appends the events to a file, one JSON document per line
+type fileSink struct {
+	path string
+	mu   sync.Mutex
+}
+
+// Dispatch appends the event to the file
+func (s *fileSink) Dispatch(tx *pop.Connection, e *models.MemberEvent) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
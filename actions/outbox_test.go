@@ -0,0 +1,61 @@
+package actions
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"team_manager/models"
+	"time"
+)
+
+func (as *ActionSuite) Test_DispatchOutbox_File() {
+	dir, err := ioutil.TempDir("", "outbox")
+	as.NoError(err)
+	defer os.RemoveAll(dir)
+
+	sinks := models.OutboxSinks
+	defer func() { models.OutboxSinks = sinks }()
+	models.OutboxSinks = []string{"test-file"}
+	RegisterEventSink("test-file", &fileSink{path: filepath.Join(dir, "events.ndjson")})
+
+	m := &models.Member{Name: "Member Name", Type: "employee", Role: "DevOps"}
+	as.NoError(models.DB.Create(m))
+	as.NoError(models.DB.Destroy(m))
+
+	as.NoError(dispatchOutbox(models.DB, time.Now))
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, "events.ndjson"))
+	as.NoError(err)
+
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	as.Equal(2, len(lines))
+
+	e := models.MemberEvent{}
+	as.NoError(json.Unmarshal([]byte(lines[1]), &e))
+	as.Equal(models.MemberDeleted, e.Type)
+	as.Equal(m.ID, e.MemberID)
+
+	messages := models.OutboxMessages{}
+	as.NoError(models.DB.Where("status = ?", models.OutboxDispatched).All(&messages))
+	as.Equal(2, len(messages))
+}
+
+func (as *ActionSuite) Test_DispatchOutbox_UnknownSink() {
+	sinks := models.OutboxSinks
+	defer func() { models.OutboxSinks = sinks }()
+	models.OutboxSinks = []string{"unknown"}
+
+	as.NoError(models.DB.Create(&models.Member{Name: "Member Name", Type: "employee", Role: "DevOps"}))
+
+	now := time.Now()
+	as.NoError(dispatchOutbox(models.DB, func() time.Time { return now }))
+
+	msg := &models.OutboxMessage{}
+	as.NoError(models.DB.First(msg))
+	as.Equal(models.OutboxPending, msg.Status)
+	as.Equal(1, msg.Attempts)
+	as.Contains(msg.LastError.String, "unknown sink")
+	as.True(msg.NextAttemptAt.Time.After(now))
+}
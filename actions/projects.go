@@ -0,0 +1,214 @@
+package actions
+
+import (
+	"fmt"
+	"net/http"
+	"team_manager/models"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gobuffalo/x/responder"
+)
+
+// ProjectsResource is the resource for the Project model (CRUD)
+type ProjectsResource struct {
+	buffalo.Resource
+}
+
+// List gets all Projects.
+// @Summary List projects
+// @ID list-projects
+// @Param page query integer false "Go to the page"
+// @Param per_page query integer false "How many project per pages"
+// @Produce json,xml
+// @Success 200 {object} models.Projects
+// @Failure 500
+// @Router /projects [get]
+func (v ProjectsResource) List(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	projects := models.Projects{}
+
+	// Paginate results. Params "page" and "per_page" control pagination.
+	// Default values are "page=1" and "per_page=20".
+	q := tx.PaginateFromParams(c.Params()).Order("name")
+
+	// Retrieve all Projects from the DB
+	if err := q.All(&projects); err != nil {
+		return err
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(200, r.JSON(projects))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(200, r.XML(projects))
+	}).Respond(c)
+}
+
+// Show gets the data for one Project, with its assignments.
+// @Summary Show a project
+// @ID show-project
+// @Produce json,xml
+// @Param project_id path string true "Project ID"
+// @Success 200 {object} models.Project
+// @Failure 404,500
+// @Router /projects/{project_id} [get]
+func (v ProjectsResource) Show(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	// Allocate an empty Project
+	project := &models.Project{}
+
+	// To find the Project the parameter project_id is used.
+	if err := tx.Eager("Assignments").Find(project, c.Param("project_id")); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(200, r.JSON(project))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(200, r.XML(project))
+	}).Respond(c)
+}
+
+// Create adds a Project to the DB.
+// @Summary Create a new project
+// @Description Members are staffed with /members/{member_id}/assignments, the assignments of the payload are ignored.
+// @ID create-project
+// @Accept json,xml
+// @Produce json,xml
+// @Param project body models.Project true "Project Payload"
+// @Success 201 {object} models.Project
+// @Failure 422 {object} validate.Errors
+// @Failure 500
+// @Router /projects [post]
+func (v ProjectsResource) Create(c buffalo.Context) error {
+	// Allocate an empty Project
+	project := &models.Project{}
+
+	// Bind project to the request payload
+	if err := c.Bind(project); err != nil {
+		return err
+	}
+
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	// Validate the data from the request
+	verrs, err := tx.ValidateAndCreate(project)
+	if err != nil {
+		return err
+	}
+
+	if verrs.HasAny() {
+		return responder.Wants("json", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.JSON(verrs))
+		}).Wants("xml", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.XML(verrs))
+		}).Respond(c)
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusCreated, r.JSON(project))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusCreated, r.XML(project))
+	}).Respond(c)
+}
+
+// Update changes a Project in the DB.
+// @Summary Update a project
+// @ID update-project
+// @Accept json,xml
+// @Produce json,xml
+// @Param project_id path string true "Project ID"
+// @Param project body models.Project true "Project Payload"
+// @Success 200 {object} models.Project
+// @Failure 422 {object} validate.Errors
+// @Failure 404,500
+// @Router /projects/{project_id} [put]
+func (v ProjectsResource) Update(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	// Allocate an empty Project
+	project := &models.Project{}
+
+	if err := tx.Find(project, c.Param("project_id")); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	// Bind Project to the request payload
+	if err := c.Bind(project); err != nil {
+		return err
+	}
+
+	verrs, err := tx.ValidateAndUpdate(project)
+	if err != nil {
+		return err
+	}
+
+	if verrs.HasAny() {
+		return responder.Wants("json", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.JSON(verrs))
+		}).Wants("xml", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.XML(verrs))
+		}).Respond(c)
+	}
+
+	if err := tx.Load(project, "Assignments"); err != nil {
+		return err
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.JSON(project))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.XML(project))
+	}).Respond(c)
+}
+
+// Destroy deletes a Project from the DB, along with its assignments.
+// @Summary Delete a project
+// @ID delete-project
+// @Param project_id path string true "Project ID"
+// @Success 204
+// @Failure 404,500
+// @Router /projects/{project_id} [delete]
+func (v ProjectsResource) Destroy(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	// Allocate an empty Project
+	project := &models.Project{}
+
+	// To find the Project the parameter project_id is used.
+	if err := tx.Find(project, c.Param("project_id")); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	if err := tx.Destroy(project); err != nil {
+		return err
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusNoContent, nil)
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusNoContent, nil)
+	}).Respond(c)
+}
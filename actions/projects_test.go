@@ -0,0 +1,82 @@
+package actions
+
+import (
+	"encoding/json"
+	"net/http"
+	"team_manager/models"
+)
+
+func (as *ActionSuite) Test_ProjectsResource() {
+	as.LoadFixture("projects")
+
+	res := as.JSON("/v1/projects").Get()
+	as.Equal(http.StatusOK, res.Code)
+	projects := models.Projects{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &projects))
+	as.Equal(2, len(projects))
+	as.Equal("Apollo", projects[0].Name)
+
+	res = as.JSON("/v1/projects/" + projects[0].ID.String()).Get()
+	as.Equal(http.StatusOK, res.Code)
+	project := models.Project{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &project))
+	as.Equal(1, len(project.Assignments))
+
+	res = as.JSON("/v1/projects").Post(map[string]string{"name": "Mercury"})
+	as.Equal(http.StatusCreated, res.Code)
+
+	res = as.JSON("/v1/projects").Post(map[string]string{"name": "mercury"})
+	as.Equal(http.StatusUnprocessableEntity, res.Code)
+
+	// the assignments of the project are deleted with it
+	res = as.JSON("/v1/projects/" + projects[0].ID.String()).Delete()
+	as.Equal(http.StatusNoContent, res.Code)
+	count, err := as.DB.Where("project_id = ?", projects[0].ID).Count(&models.Assignment{})
+	as.NoError(err)
+	as.Equal(0, count)
+}
+
+func (as *ActionSuite) Test_AssignmentsResource() {
+	as.LoadFixture("projects")
+
+	developer := &models.Member{}
+	as.NoError(as.DB.Where("name = ?", "Staffed Developer").First(developer))
+	contractor := &models.Member{}
+	as.NoError(as.DB.Where("name = ?", "Free Contractor").First(contractor))
+	gemini := &models.Project{}
+	as.NoError(as.DB.Where("name = ?", "Gemini").First(gemini))
+
+	res := as.JSON("/v1/members/" + developer.ID.String() + "/assignments").Get()
+	as.Equal(http.StatusOK, res.Code)
+	assignments := models.Assignments{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &assignments))
+	as.Equal(2, len(assignments))
+	as.Equal(60, assignments[0].Allocation)
+
+	// the developer is already allocated 100% from March
+	payload := map[string]interface{}{
+		"project_id": gemini.ID,
+		"allocation": 20,
+		"start_date": "2026-05-01T00:00:00Z",
+		"role":       "Mentor",
+	}
+	res = as.JSON("/v1/members/" + developer.ID.String() + "/assignments").Post(payload)
+	as.Equal(http.StatusUnprocessableEntity, res.Code)
+
+	res = as.JSON("/v1/members/" + contractor.ID.String() + "/assignments").Post(payload)
+	as.Equal(http.StatusCreated, res.Code)
+	assignment := models.Assignment{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &assignment))
+	as.Equal(contractor.ID, assignment.MemberID)
+
+	// the assignment is not found under another member
+	res = as.JSON("/v1/members/" + developer.ID.String() + "/assignments/" + assignment.ID.String()).Get()
+	as.Equal(http.StatusNotFound, res.Code)
+
+	payload["allocation"] = 100
+	res = as.JSON("/v1/members/" + contractor.ID.String() + "/assignments/" + assignment.ID.String()).Put(payload)
+	as.Equal(http.StatusOK, res.Code)
+
+	res = as.JSON("/v1/members/" + contractor.ID.String() + "/assignments/" + assignment.ID.String()).Delete()
+	as.Equal(http.StatusNoContent, res.Code)
+}
@@ -1,13 +1,42 @@
 package actions
 
 import (
+	"encoding/json"
+	"io"
+
 	"github.com/gobuffalo/buffalo/render"
 )
 
 var r *render.Engine
 
+// content types of the diagrams
+const (
+	dotContentType     = "text/vnd.graphviz; charset=utf-8"
+	mermaidContentType = "text/vnd.mermaid; charset=utf-8"
+)
+
+// scimContentType is the content type of the SCIM resources and messages
+const scimContentType = "application/scim+json; charset=utf-8"
+
 func init() {
 	r = render.New(render.Options{
 		DefaultContentType: "application/json",
 	})
 }
+
+// dot renders the Graphviz DOT source written by fn
+func dot(fn render.RendererFunc) render.Renderer {
+	return r.Func(dotContentType, fn)
+}
+
+// mermaid renders the Mermaid source written by fn
+func mermaid(fn render.RendererFunc) render.Renderer {
+	return r.Func(mermaidContentType, fn)
+}
+
+// scim renders v as a SCIM resource or message
+func scim(v interface{}) render.Renderer {
+	return r.Func(scimContentType, func(w io.Writer, d render.Data) error {
+		return json.NewEncoder(w).Encode(v)
+	})
+}
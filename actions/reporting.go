@@ -0,0 +1,123 @@
+package actions
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"team_manager/models"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gobuffalo/x/responder"
+)
+
+// MemberReports gets the members reporting to a Member.
+// @Summary List the reports of a member
+// @Description Every member under the member by default, only its direct reports with direct.
+// @ID list-member-reports
+// @Param member_id path string true "Member ID"
+// @Param direct query boolean false "Only the direct reports"
+// @Produce json,xml
+// @Success 200 {object} models.Members
+// @Failure 404,500
+// @Router /members/{member_id}/reports [get]
+func MemberReports(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	member := &models.Member{}
+	if err := tx.Find(member, c.Param("member_id")); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	direct, _ := strconv.ParseBool(c.Param("direct"))
+	reports, err := models.FindReports(tx, member.ID, direct)
+	if err != nil {
+		return err
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.JSON(reports))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.XML(reports))
+	}).Respond(c)
+}
+
+// MemberChain gets the managers of a Member,
+// from its direct manager up to the top of the organisation.
+// @Summary Management chain of a member
+// @ID member-chain
+// @Param member_id path string true "Member ID"
+// @Produce json,xml
+// @Success 200 {object} models.Members
+// @Failure 404,500
+// @Router /members/{member_id}/chain [get]
+func MemberChain(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	member := &models.Member{}
+	if err := tx.Find(member, c.Param("member_id")); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	chain, err := models.FindManagerChain(tx, member.ID)
+	if err != nil {
+		return err
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.JSON(chain))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.XML(chain))
+	}).Respond(c)
+}
+
+// OrgChart gets the reporting lines as a tree,
+// one root per member without manager.
+// @Summary Org chart
+// @ID orgchart
+// @Param root query string false "Only the tree under this member"
+// @Produce json,xml
+// @Success 200 {object} models.OrgChart
+// @Failure 404,500
+// @Router /orgchart [get]
+func OrgChart(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	chart, err := findOrgChart(c, tx)
+	if err != nil {
+		return err
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.JSON(chart))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.XML(chart))
+	}).Respond(c)
+}
+
+// findOrgChart builds the org chart, from the root member
+// of the request if there is one (404 when it does not exist)
+func findOrgChart(c buffalo.Context, tx *pop.Connection) (models.OrgChart, error) {
+	if c.Param("root") == "" {
+		return models.BuildOrgChart(tx, nil)
+	}
+
+	root := &models.Member{}
+	if err := tx.Find(root, c.Param("root")); err != nil {
+		return nil, c.Error(http.StatusNotFound, err)
+	}
+
+	return models.BuildOrgChart(tx, &root.ID)
+}
@@ -0,0 +1,70 @@
+package actions
+
+import (
+	"encoding/json"
+	"net/http"
+	"team_manager/models"
+
+	"github.com/gobuffalo/nulls"
+)
+
+func (as *ActionSuite) Test_MemberReports() {
+	as.LoadFixture("reporting")
+
+	ceo := &models.Member{}
+	as.NoError(as.DB.Where("name = ?", "Alice CEO").First(ceo))
+
+	res := as.JSON("/v1/members/" + ceo.ID.String() + "/reports").Get()
+	as.Equal(http.StatusOK, res.Code)
+	members := models.Members{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &members))
+	as.Equal(4, len(members))
+
+	res = as.JSON("/v1/members/" + ceo.ID.String() + "/reports?direct=true").Get()
+	as.Equal(http.StatusOK, res.Code)
+	members = models.Members{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &members))
+	as.Equal(2, len(members))
+}
+
+func (as *ActionSuite) Test_MemberChain() {
+	as.LoadFixture("reporting")
+
+	developer := &models.Member{}
+	as.NoError(as.DB.Where("name = ?", "Dave Developer").First(developer))
+
+	res := as.JSON("/v1/members/" + developer.ID.String() + "/chain").Get()
+	as.Equal(http.StatusOK, res.Code)
+	members := models.Members{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &members))
+	as.Equal(2, len(members))
+	as.Equal("Alice CEO", members[1].Name)
+}
+
+func (as *ActionSuite) Test_MemberManager_Update() {
+	as.LoadFixture("reporting")
+
+	ceo := &models.Member{}
+	as.NoError(as.DB.Where("name = ?", "Alice CEO").First(ceo))
+	developer := &models.Member{}
+	as.NoError(as.DB.Where("name = ?", "Dave Developer").First(developer))
+
+	ceo.ManagerID = nulls.NewUUID(developer.ID)
+	res := as.JSON("/v1/members/" + ceo.ID.String()).Put(ceo)
+	as.Equal(http.StatusUnprocessableEntity, res.Code)
+}
+
+func (as *ActionSuite) Test_OrgChart() {
+	as.LoadFixture("reporting")
+
+	res := as.JSON("/v1/orgchart").Get()
+	as.Equal(http.StatusOK, res.Code)
+	chart := models.OrgChart{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &chart))
+	as.Equal(1, len(chart))
+	as.Equal("Alice CEO", chart[0].Name)
+	as.Equal(2, len(chart[0].Reports))
+
+	res = as.JSON("/v1/orgchart?root=00000000-0000-4000-8000-000000000000").Get()
+	as.Equal(http.StatusNotFound, res.Code)
+}
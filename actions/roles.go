@@ -0,0 +1,219 @@
+package actions
+
+import (
+	"fmt"
+	"net/http"
+	"team_manager/models"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gobuffalo/x/responder"
+)
+
+// RolesResource is the resource for the Role model (CRUD)
+type RolesResource struct {
+	buffalo.Resource
+}
+
+// List gets all Roles.
+// @Summary List roles
+// @ID list-roles
+// @Param page query integer false "Go to the page"
+// @Param per_page query integer false "How many role per pages"
+// @Param family query string false "Only roles of this family (case insensitive)"
+// @Param level query string false "Only roles of this level" Enums(junior, mid, senior, lead, principal)
+// @Produce json,xml
+// @Success 200 {object} models.Roles
+// @Failure 500
+// @Router /roles [get]
+func (v RolesResource) List(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	roles := models.Roles{}
+
+	// Paginate results. Params "page" and "per_page" control pagination.
+	// Default values are "page=1" and "per_page=20".
+	q := tx.PaginateFromParams(c.Params()).Order("family, title")
+	if family := c.Param("family"); family != "" {
+		q = q.Where("LOWER(family) = LOWER(?)", family)
+	}
+	if level := c.Param("level"); level != "" {
+		q = q.Where("level = ?", level)
+	}
+
+	// Retrieve all Roles from the DB
+	if err := q.All(&roles); err != nil {
+		return err
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(200, r.JSON(roles))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(200, r.XML(roles))
+	}).Respond(c)
+}
+
+// Show gets the data for one Role.
+// @Summary Show a role
+// @ID show-role
+// @Produce json,xml
+// @Param role_id path string true "Role ID"
+// @Success 200 {object} models.Role
+// @Failure 404,500
+// @Router /roles/{role_id} [get]
+func (v RolesResource) Show(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	// Allocate an empty Role
+	role := &models.Role{}
+
+	// To find the Role the parameter role_id is used.
+	if err := tx.Find(role, c.Param("role_id")); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(200, r.JSON(role))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(200, r.XML(role))
+	}).Respond(c)
+}
+
+// Create adds a Role to the DB.
+// @Summary Create a new role
+// @Description Once the catalog has roles, the role of a member must be the title of one of them (see ROLE_VALIDATION).
+// @ID create-role
+// @Accept json,xml
+// @Produce json,xml
+// @Param role body models.Role true "Role Payload"
+// @Success 201 {object} models.Role
+// @Failure 422 {object} validate.Errors
+// @Failure 500
+// @Router /roles [post]
+func (v RolesResource) Create(c buffalo.Context) error {
+	// Allocate an empty Role
+	role := &models.Role{}
+
+	// Bind role to the request payload
+	if err := c.Bind(role); err != nil {
+		return err
+	}
+
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	// Validate the data from the request
+	verrs, err := tx.ValidateAndCreate(role)
+	if err != nil {
+		return err
+	}
+
+	if verrs.HasAny() {
+		return responder.Wants("json", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.JSON(verrs))
+		}).Wants("xml", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.XML(verrs))
+		}).Respond(c)
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusCreated, r.JSON(role))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusCreated, r.XML(role))
+	}).Respond(c)
+}
+
+// Update changes a Role in the DB.
+// @Summary Update a role
+// @Description The members having the role are renamed with its title.
+// @ID update-role
+// @Accept json,xml
+// @Produce json,xml
+// @Param role_id path string true "Role ID"
+// @Param role body models.Role true "Role Payload"
+// @Success 200 {object} models.Role
+// @Failure 422 {object} validate.Errors
+// @Failure 404,500
+// @Router /roles/{role_id} [put]
+func (v RolesResource) Update(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	// Allocate an empty Role
+	role := &models.Role{}
+
+	if err := tx.Find(role, c.Param("role_id")); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	// Bind Role to the request payload
+	if err := c.Bind(role); err != nil {
+		return err
+	}
+
+	verrs, err := tx.ValidateAndUpdate(role)
+	if err != nil {
+		return err
+	}
+
+	if verrs.HasAny() {
+		return responder.Wants("json", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.JSON(verrs))
+		}).Wants("xml", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.XML(verrs))
+		}).Respond(c)
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.JSON(role))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.XML(role))
+	}).Respond(c)
+}
+
+// Destroy deletes a Role from the DB, the members having it keep its title.
+// @Summary Delete a role
+// @ID delete-role
+// @Param role_id path string true "Role ID"
+// @Success 204
+// @Failure 404,500
+// @Router /roles/{role_id} [delete]
+func (v RolesResource) Destroy(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	// Allocate an empty Role
+	role := &models.Role{}
+
+	// To find the Role the parameter role_id is used.
+	if err := tx.Find(role, c.Param("role_id")); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	if err := tx.Destroy(role); err != nil {
+		return err
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusNoContent, nil)
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusNoContent, nil)
+	}).Respond(c)
+}
@@ -0,0 +1,45 @@
+package actions
+
+import (
+	"encoding/json"
+	"net/http"
+	"team_manager/models"
+)
+
+func (as *ActionSuite) Test_RolesResource_List() {
+	as.LoadFixture("roles")
+
+	res := as.JSON("/v1/roles?family=engineering").Get()
+	as.Equal(http.StatusOK, res.Code)
+
+	roles := models.Roles{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &roles))
+	as.Equal(2, len(roles))
+	as.Equal("DevOps", roles[0].Title)
+}
+
+func (as *ActionSuite) Test_RolesResource_Create() {
+	r := models.Role{Title: "Software Engineer", Family: "Engineering", Level: "mid"}
+	res := as.JSON("/v1/roles").Post(r)
+	as.Equal(http.StatusCreated, res.Code)
+
+	r.Title = "software engineer"
+	res = as.JSON("/v1/roles").Post(r)
+	as.Equal(http.StatusUnprocessableEntity, res.Code)
+}
+
+func (as *ActionSuite) Test_MembersResource_Create_Role_Catalog() {
+	as.LoadFixture("roles")
+
+	res := as.JSON("/v1/members").Post(models.Member{Name: "Member Name", Type: "employee", Role: "software engineer"})
+	as.Equal(http.StatusCreated, res.Code)
+
+	m := models.Member{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &m))
+	as.Equal("Software Engineer", m.Role)
+	as.True(m.RoleID.Valid)
+
+	res = as.JSON("/v1/members").Post(models.Member{Name: "Other Name", Type: "employee", Role: "Sofware Engineer"})
+	as.Equal(http.StatusUnprocessableEntity, res.Code)
+	as.Contains(res.Body.String(), "did you mean Software Engineer?")
+}
@@ -0,0 +1,623 @@
+package actions
+
+import (
+	"crypto/subtle"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"team_manager/models"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/envy"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gobuffalo/validate/v3"
+)
+
+// scimDefaultCount is the size of a page of a SCIM list without a count
+const scimDefaultCount = 100
+
+// The SCIM 2.0 endpoints (RFC 7644) let an identity provider provision the members,
+// as users, and the teams, as groups. They are not part of the /v1 API: they
+// speak application/scim+json and answer with SCIM errors.
+
+// scimAuth requires the bearer token of the SCIM_TOKEN variable, when it is set
+func scimAuth(next buffalo.Handler) buffalo.Handler {
+	return func(c buffalo.Context) error {
+		token := envy.Get("SCIM_TOKEN", "")
+		if token == "" {
+			return next(c)
+		}
+
+		auth := c.Request().Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "Bearer ") || subtle.ConstantTimeCompare([]byte(auth[len("Bearer "):]), []byte(token)) != 1 {
+			c.Response().Header().Set("WWW-Authenticate", `Bearer realm="scim"`)
+			return scimError(c, models.NewScimError(http.StatusUnauthorized, "", "The bearer token is missing or wrong."))
+		}
+		return next(c)
+	}
+}
+
+// scimError renders a SCIM error, or returns the error when it is not one
+func scimError(c buffalo.Context, err error) error {
+	var serr *models.ScimError
+	if !errors.As(err, &serr) {
+		return err
+	}
+
+	status, _ := strconv.Atoi(serr.Status)
+	return c.Render(status, scim(serr))
+}
+
+// scimValidationError is the SCIM error of validation errors
+func scimValidationError(verrs *validate.Errors) *models.ScimError {
+	return models.NewScimError(http.StatusBadRequest, "invalidValue", models.ScimErrorDetail(verrs))
+}
+
+// scimNotFound is the SCIM error of a resource that does not exist
+func scimNotFound(kind, id string) *models.ScimError {
+	return models.NewScimError(http.StatusNotFound, "", kind+" "+id+" not found.")
+}
+
+// scimBaseURL is the URL of the SCIM endpoints, from the host of the request
+func scimBaseURL(c buffalo.Context) string {
+	req := c.Request()
+	scheme := "http"
+	if req.TLS != nil || req.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + req.Host + "/scim/v2"
+}
+
+// scimDecode reads the SCIM resource or message of the request body
+func scimDecode(c buffalo.Context, v interface{}) error {
+	if err := json.NewDecoder(c.Request().Body).Decode(v); err != nil {
+		return models.NewScimError(http.StatusBadRequest, "invalidSyntax", "The body is not valid JSON: "+err.Error())
+	}
+	return nil
+}
+
+// scimPage reads startIndex and count: the first resource of the page,
+// from 1, and the size of the page, at most models.ScimMaxResults
+func scimPage(c buffalo.Context) (int, int, error) {
+	startIndex, count := 1, scimDefaultCount
+	for _, p := range []struct {
+		name  string
+		value *int
+	}{{"startIndex", &startIndex}, {"count", &count}} {
+		if s := c.Param(p.name); s != "" {
+			n, err := strconv.Atoi(s)
+			if err != nil {
+				return 0, 0, models.NewScimError(http.StatusBadRequest, "invalidValue", "The "+p.name+" must be a number.")
+			}
+			*p.value = n
+		}
+	}
+
+	if startIndex < 1 {
+		startIndex = 1
+	}
+	if count < 0 {
+		count = 0
+	}
+	if count > models.ScimMaxResults {
+		count = models.ScimMaxResults
+	}
+	return startIndex, count, nil
+}
+
+// scimList returns the total of the resources of the query and, unless count is 0,
+// loads the page of them starting at startIndex into the list
+func scimList(q *pop.Query, list interface{}, startIndex, count int) (int, error) {
+	if count == 0 {
+		return q.Count(list)
+	}
+
+	q.Paginator = &pop.Paginator{Page: 1, PerPage: count, Offset: startIndex - 1}
+	if err := q.All(list); err != nil {
+		return 0, err
+	}
+	return q.Paginator.TotalEntriesSize, nil
+}
+
+// scimUser returns the member as a SCIM user, with its location
+// and the locations of its groups
+func scimUser(c buffalo.Context, m *models.Member, externalID string) models.ScimUser {
+	u := models.NewScimUser(m, externalID)
+	base := scimBaseURL(c)
+	u.Meta.Location = base + "/Users/" + u.ID
+	for i := range u.Groups {
+		u.Groups[i].Ref = base + "/Groups/" + u.Groups[i].Value
+	}
+	return u
+}
+
+// scimGroup returns the team as a SCIM group, with its location
+// and the locations of its members
+func scimGroup(c buffalo.Context, t *models.Team) models.ScimGroup {
+	g := models.NewScimGroup(t)
+	base := scimBaseURL(c)
+	g.Meta.Location = base + "/Groups/" + g.ID
+	for i := range g.Members {
+		g.Members[i].Ref = base + "/Users/" + g.Members[i].Value
+	}
+	return g
+}
+
+// scimUserConflict tells if the userName or the externalId of the user
+// are taken by another member than id, it is a uniqueness error
+func scimUserConflict(tx *pop.Connection, u models.ScimUser, id interface{}) error {
+	if u.UserName != "" {
+		taken, err := tx.Where("LOWER(email) = LOWER(?) AND id <> ?", strings.TrimSpace(u.UserName), id).Exists(&models.Member{})
+		if err != nil {
+			return err
+		}
+		if taken {
+			return models.NewScimError(http.StatusConflict, "uniqueness", "The userName "+u.UserName+" is already taken.")
+		}
+	}
+
+	if u.ExternalID != "" {
+		taken, err := tx.Where("system = ? AND external_id = ? AND member_id <> ?", models.ScimExternalSystem, u.ExternalID, id).Exists(&models.ExternalID{})
+		if err != nil {
+			return err
+		}
+		if taken {
+			return models.NewScimError(http.StatusConflict, "uniqueness", "The externalId "+u.ExternalID+" is already taken.")
+		}
+	}
+
+	return nil
+}
+
+// findScimUser finds the member of the user_id parameter, with its teams and its SCIM external ID
+func findScimUser(c buffalo.Context, tx *pop.Connection) (*models.Member, string, error) {
+	member := &models.Member{}
+	if err := tx.Eager("Teams").Find(member, c.Param("user_id")); err != nil {
+		return nil, "", scimNotFound("User", c.Param("user_id"))
+	}
+
+	ext, err := models.FindExternalID(tx, member.ID, models.ScimExternalSystem)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, "", err
+	}
+	return member, ext.ExternalID, nil
+}
+
+// saveScimUser saves the member from the user and renders it with the status
+func saveScimUser(c buffalo.Context, tx *pop.Connection, member *models.Member, u models.ScimUser, status int) error {
+	if err := scimUserConflict(tx, u, member.ID); err != nil {
+		return scimError(c, err)
+	}
+
+	verrs, err := models.SaveScimUser(tx, member, u)
+	if err != nil {
+		return scimError(c, err)
+	}
+	if verrs.HasAny() {
+		return scimError(c, scimValidationError(verrs))
+	}
+
+	member.Teams = models.Teams{}
+	if err := tx.Load(member, "Teams"); err != nil {
+		return err
+	}
+
+	user := scimUser(c, member, u.ExternalID)
+	if status == http.StatusCreated {
+		c.Response().Header().Set("Location", user.Meta.Location)
+	}
+	return c.Render(status, scim(user))
+}
+
+// ScimUsersList lists the members as SCIM users, with a filter like
+// userName eq "jane@example.com" and the startIndex and count of the page
+func ScimUsersList(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	startIndex, count, err := scimPage(c)
+	if err != nil {
+		return scimError(c, err)
+	}
+
+	q := tx.EagerPreload("Teams").Order("created_at, id")
+	if filter := c.Param("filter"); filter != "" {
+		f, err := models.ParseScimFilter(filter)
+		if err != nil {
+			return scimError(c, err)
+		}
+		scope, err := f.UserScope()
+		if err != nil {
+			return scimError(c, err)
+		}
+		q = q.Scope(scope)
+	}
+
+	members := models.Members{}
+	total, err := scimList(q, &members, startIndex, count)
+	if err != nil {
+		return err
+	}
+
+	externalIDs, err := models.FindScimExternalIDs(tx, members)
+	if err != nil {
+		return err
+	}
+
+	users := []models.ScimUser{}
+	for i := range members {
+		users = append(users, scimUser(c, &members[i], externalIDs[members[i].ID]))
+	}
+
+	return c.Render(http.StatusOK, scim(models.ScimListResponse{
+		Schemas:      []string{models.ScimListSchema},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(users),
+		Resources:    users,
+	}))
+}
+
+// ScimUsersShow gets the member as a SCIM user
+func ScimUsersShow(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	member, externalID, err := findScimUser(c, tx)
+	if err != nil {
+		return scimError(c, err)
+	}
+
+	return c.Render(http.StatusOK, scim(scimUser(c, member, externalID)))
+}
+
+// ScimUsersCreate creates a member from a SCIM user, it is active unless active is false
+func ScimUsersCreate(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	u := models.ScimUser{}
+	if err := scimDecode(c, &u); err != nil {
+		return scimError(c, err)
+	}
+
+	return saveScimUser(c, tx, &models.Member{}, u, http.StatusCreated)
+}
+
+// ScimUsersReplace replaces the member with a SCIM user, the fields
+// the user does not have are cleared
+func ScimUsersReplace(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	member, _, err := findScimUser(c, tx)
+	if err != nil {
+		return scimError(c, err)
+	}
+
+	u := models.ScimUser{}
+	if err := scimDecode(c, &u); err != nil {
+		return scimError(c, err)
+	}
+
+	return saveScimUser(c, tx, member, u, http.StatusOK)
+}
+
+// ScimUsersPatch applies the operations of a SCIM PATCH request to the member
+func ScimUsersPatch(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	member, externalID, err := findScimUser(c, tx)
+	if err != nil {
+		return scimError(c, err)
+	}
+
+	patch := models.ScimPatch{}
+	if err := scimDecode(c, &patch); err != nil {
+		return scimError(c, err)
+	}
+
+	u := models.ScimUser{}
+	if err := models.ApplyScimPatch(models.NewScimUser(member, externalID), patch, &u); err != nil {
+		return scimError(c, err)
+	}
+
+	return saveScimUser(c, tx, member, u, http.StatusOK)
+}
+
+// ScimUsersDelete deletes the member
+func ScimUsersDelete(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	member := &models.Member{}
+	if err := tx.Find(member, c.Param("user_id")); err != nil {
+		return scimError(c, scimNotFound("User", c.Param("user_id")))
+	}
+
+	if err := tx.Destroy(member); err != nil {
+		return err
+	}
+
+	return c.Render(http.StatusNoContent, nil)
+}
+
+// findScimGroup finds the team of the group_id parameter, with its members
+func findScimGroup(c buffalo.Context, tx *pop.Connection) (*models.Team, error) {
+	team := &models.Team{}
+	if err := tx.Eager("Members").Find(team, c.Param("group_id")); err != nil {
+		return nil, scimNotFound("Group", c.Param("group_id"))
+	}
+	return team, nil
+}
+
+// saveScimGroup saves the team from the group and renders it with the status
+func saveScimGroup(c buffalo.Context, tx *pop.Connection, team *models.Team, g models.ScimGroup, status int) error {
+	taken, err := tx.Where("LOWER(name) = LOWER(?) AND id <> ?", strings.TrimSpace(g.DisplayName), team.ID).Exists(&models.Team{})
+	if err != nil {
+		return err
+	}
+	if taken {
+		return scimError(c, models.NewScimError(http.StatusConflict, "uniqueness", "The displayName "+g.DisplayName+" is already taken."))
+	}
+
+	verrs, err := models.SaveScimGroup(tx, team, g)
+	if err != nil {
+		return err
+	}
+	if verrs.HasAny() {
+		return scimError(c, scimValidationError(verrs))
+	}
+
+	team.Members = models.Members{}
+	if err := tx.Load(team, "Members"); err != nil {
+		return err
+	}
+
+	group := scimGroup(c, team)
+	if status == http.StatusCreated {
+		c.Response().Header().Set("Location", group.Meta.Location)
+	}
+	return c.Render(status, scim(group))
+}
+
+// ScimGroupsList lists the teams as SCIM groups, with a filter like
+// displayName eq "Platform" and the startIndex and count of the page
+func ScimGroupsList(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	startIndex, count, err := scimPage(c)
+	if err != nil {
+		return scimError(c, err)
+	}
+
+	q := tx.EagerPreload("Members").Order("created_at, id")
+	if filter := c.Param("filter"); filter != "" {
+		f, err := models.ParseScimFilter(filter)
+		if err != nil {
+			return scimError(c, err)
+		}
+		scope, err := f.GroupScope()
+		if err != nil {
+			return scimError(c, err)
+		}
+		q = q.Scope(scope)
+	}
+
+	teams := models.Teams{}
+	total, err := scimList(q, &teams, startIndex, count)
+	if err != nil {
+		return err
+	}
+
+	groups := []models.ScimGroup{}
+	for i := range teams {
+		groups = append(groups, scimGroup(c, &teams[i]))
+	}
+
+	return c.Render(http.StatusOK, scim(models.ScimListResponse{
+		Schemas:      []string{models.ScimListSchema},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(groups),
+		Resources:    groups,
+	}))
+}
+
+// ScimGroupsShow gets the team as a SCIM group
+func ScimGroupsShow(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	team, err := findScimGroup(c, tx)
+	if err != nil {
+		return scimError(c, err)
+	}
+
+	return c.Render(http.StatusOK, scim(scimGroup(c, team)))
+}
+
+// ScimGroupsCreate creates a team from a SCIM group
+func ScimGroupsCreate(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	g := models.ScimGroup{}
+	if err := scimDecode(c, &g); err != nil {
+		return scimError(c, err)
+	}
+
+	return saveScimGroup(c, tx, &models.Team{}, g, http.StatusCreated)
+}
+
+// ScimGroupsReplace renames the team and replaces its members with the ones of a SCIM group
+func ScimGroupsReplace(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	team, err := findScimGroup(c, tx)
+	if err != nil {
+		return scimError(c, err)
+	}
+
+	g := models.ScimGroup{}
+	if err := scimDecode(c, &g); err != nil {
+		return scimError(c, err)
+	}
+
+	return saveScimGroup(c, tx, team, g, http.StatusOK)
+}
+
+// ScimGroupsPatch applies the operations of a SCIM PATCH request to the team,
+// like adding members or removing members[value eq "<id>"]
+func ScimGroupsPatch(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	team, err := findScimGroup(c, tx)
+	if err != nil {
+		return scimError(c, err)
+	}
+
+	patch := models.ScimPatch{}
+	if err := scimDecode(c, &patch); err != nil {
+		return scimError(c, err)
+	}
+
+	g := models.ScimGroup{}
+	if err := models.ApplyScimPatch(models.NewScimGroup(team), patch, &g); err != nil {
+		return scimError(c, err)
+	}
+
+	return saveScimGroup(c, tx, team, g, http.StatusOK)
+}
+
+// ScimGroupsDelete deletes the team, unless it has sub-teams
+func ScimGroupsDelete(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	team := &models.Team{}
+	if err := tx.Find(team, c.Param("group_id")); err != nil {
+		return scimError(c, scimNotFound("Group", c.Param("group_id")))
+	}
+
+	hasSubTeams, err := tx.Where("parent_id = ?", team.ID).Exists(&models.Team{})
+	if err != nil {
+		return err
+	}
+	if hasSubTeams {
+		return scimError(c, models.NewScimError(http.StatusConflict, "", "The group has sub-teams, move or delete them first."))
+	}
+
+	if err := tx.Destroy(team); err != nil {
+		return err
+	}
+
+	return c.Render(http.StatusNoContent, nil)
+}
+
+// ScimServiceProviderConfig tells what the SCIM endpoints support
+func ScimServiceProviderConfig(c buffalo.Context) error {
+	config := models.NewScimServiceProviderConfig(envy.Get("SCIM_TOKEN", "") != "")
+	config.Meta.Location = scimBaseURL(c) + "/ServiceProviderConfig"
+	return c.Render(http.StatusOK, scim(config))
+}
+
+// ScimResourceTypesList lists the types of SCIM resources: User and Group
+func ScimResourceTypesList(c buffalo.Context) error {
+	types := models.ScimResourceTypes()
+	for i := range types {
+		types[i].Meta.Location = scimBaseURL(c) + "/ResourceTypes/" + types[i].ID
+	}
+
+	return c.Render(http.StatusOK, scim(models.ScimListResponse{
+		Schemas:      []string{models.ScimListSchema},
+		TotalResults: len(types),
+		StartIndex:   1,
+		ItemsPerPage: len(types),
+		Resources:    types,
+	}))
+}
+
+// ScimResourceTypesShow gets a type of SCIM resources by its name
+func ScimResourceTypesShow(c buffalo.Context) error {
+	for _, t := range models.ScimResourceTypes() {
+		if t.ID == c.Param("resource_type_id") {
+			t.Meta.Location = scimBaseURL(c) + "/ResourceTypes/" + t.ID
+			return c.Render(http.StatusOK, scim(t))
+		}
+	}
+
+	return scimError(c, scimNotFound("Resource type", c.Param("resource_type_id")))
+}
+
+// ScimSchemasList lists the schemas of the SCIM resources
+func ScimSchemasList(c buffalo.Context) error {
+	schemas := models.ScimSchemas()
+	for i := range schemas {
+		schemas[i].Meta.Location = scimBaseURL(c) + "/Schemas/" + schemas[i].ID
+	}
+
+	return c.Render(http.StatusOK, scim(models.ScimListResponse{
+		Schemas:      []string{models.ScimListSchema},
+		TotalResults: len(schemas),
+		StartIndex:   1,
+		ItemsPerPage: len(schemas),
+		Resources:    schemas,
+	}))
+}
+
+// ScimSchemasShow gets a schema of the SCIM resources by its URN
+func ScimSchemasShow(c buffalo.Context) error {
+	for _, s := range models.ScimSchemas() {
+		if s.ID == c.Param("schema_id") {
+			s.Meta.Location = scimBaseURL(c) + "/Schemas/" + s.ID
+			return c.Render(http.StatusOK, scim(s))
+		}
+	}
+
+	return scimError(c, scimNotFound("Schema", c.Param("schema_id")))
+}
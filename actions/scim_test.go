@@ -0,0 +1,173 @@
+package actions
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"team_manager/models"
+
+	"github.com/gobuffalo/envy"
+)
+
+func (as *ActionSuite) Test_ScimUsers() {
+	u := models.ScimUser{
+		Schemas:    []string{models.ScimUserSchema, models.ScimUserExtensionSchema},
+		ExternalID: "00u1",
+		UserName:   "jane@example.com",
+		Name:       &models.ScimName{GivenName: "Jane", FamilyName: "Doe"},
+		Extension:  &models.ScimUserExtension{Type: "employee", Role: "DevOps"},
+	}
+	res := as.JSON("/scim/v2/Users").Post(u)
+	as.Equal(http.StatusCreated, res.Code)
+	as.Contains(res.Header().Get("Content-Type"), "application/scim+json")
+
+	created := models.ScimUser{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &created))
+	as.Equal("Jane Doe", created.DisplayName)
+	as.True(*created.Active)
+	as.Equal(res.Header().Get("Location"), created.Meta.Location)
+
+	// the userName is taken
+	res = as.JSON("/scim/v2/Users").Post(u)
+	as.Equal(http.StatusConflict, res.Code)
+
+	scimErr := models.ScimError{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &scimErr))
+	as.Equal("uniqueness", scimErr.ScimType)
+
+	res = as.JSON("/scim/v2/Users?filter=%s", url.QueryEscape(`userName eq "JANE@example.com"`)).Get()
+	as.Equal(http.StatusOK, res.Code)
+
+	list := struct {
+		TotalResults int               `json:"totalResults"`
+		Resources    []models.ScimUser `json:"Resources"`
+	}{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &list))
+	as.Equal(1, list.TotalResults)
+	as.Equal(created.ID, list.Resources[0].ID)
+	as.Equal("00u1", list.Resources[0].ExternalID)
+
+	res = as.JSON("/scim/v2/Users?filter=%s", url.QueryEscape(`password eq "secret"`)).Get()
+	as.Equal(http.StatusBadRequest, res.Code)
+
+	// deactivated by a PATCH
+	res = as.JSON("/scim/v2/Users/" + created.ID).Patch(models.ScimPatch{
+		Schemas:    []string{models.ScimPatchSchema},
+		Operations: []models.ScimPatchOperation{{Op: "replace", Path: "active", Value: false}},
+	})
+	as.Equal(http.StatusOK, res.Code)
+
+	member := &models.Member{}
+	as.NoError(models.DB.Find(member, created.ID))
+	as.Equal(models.StatusOffboarded, member.Status)
+
+	u.DisplayName = "Jane Smith"
+	res = as.JSON("/scim/v2/Users/" + created.ID).Put(u)
+	as.Equal(http.StatusOK, res.Code)
+	as.NoError(models.DB.Reload(member))
+	as.Equal("Jane Smith", member.Name)
+
+	res = as.JSON("/scim/v2/Users/" + created.ID).Delete()
+	as.Equal(http.StatusNoContent, res.Code)
+
+	res = as.JSON("/scim/v2/Users/" + created.ID).Get()
+	as.Equal(http.StatusNotFound, res.Code)
+}
+
+func (as *ActionSuite) Test_ScimUsers_Pagination() {
+	for _, name := range []string{"Member A", "Member B", "Member C"} {
+		as.NoError(models.DB.Create(&models.Member{Name: name, Type: "employee", Role: "DevOps", Status: models.StatusActive}))
+	}
+
+	res := as.JSON("/scim/v2/Users?startIndex=2&count=1").Get()
+	as.Equal(http.StatusOK, res.Code)
+
+	list := models.ScimListResponse{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &list))
+	as.Equal(3, list.TotalResults)
+	as.Equal(2, list.StartIndex)
+	as.Equal(1, list.ItemsPerPage)
+	as.Equal("Member B", list.Resources.([]interface{})[0].(map[string]interface{})["displayName"])
+
+	res = as.JSON("/scim/v2/Users?count=0").Get()
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &list))
+	as.Equal(3, list.TotalResults)
+	as.Equal(0, list.ItemsPerPage)
+}
+
+func (as *ActionSuite) Test_ScimGroups() {
+	jane := &models.Member{Name: "Jane Doe", Type: "employee", Role: "DevOps"}
+	as.NoError(models.DB.Create(jane))
+	john := &models.Member{Name: "John Doe", Type: "employee", Role: "DevOps"}
+	as.NoError(models.DB.Create(john))
+
+	res := as.JSON("/scim/v2/Groups").Post(models.ScimGroup{
+		Schemas:     []string{models.ScimGroupSchema},
+		DisplayName: "Platform",
+		Members:     []models.ScimReference{{Value: jane.ID.String()}},
+	})
+	as.Equal(http.StatusCreated, res.Code)
+
+	created := models.ScimGroup{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &created))
+	as.Len(created.Members, 1)
+
+	res = as.JSON("/scim/v2/Groups/" + created.ID).Patch(models.ScimPatch{
+		Schemas: []string{models.ScimPatchSchema},
+		Operations: []models.ScimPatchOperation{
+			{Op: "add", Path: "members", Value: []map[string]string{{"value": john.ID.String()}}},
+			{Op: "remove", Path: `members[value eq "` + jane.ID.String() + `"]`},
+		},
+	})
+	as.Equal(http.StatusOK, res.Code)
+
+	patched := models.ScimGroup{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &patched))
+	as.Len(patched.Members, 1)
+	as.Equal(john.ID.String(), patched.Members[0].Value)
+
+	res = as.JSON("/scim/v2/Groups?filter=%s", url.QueryEscape(`members eq "`+john.ID.String()+`"`)).Get()
+	list := models.ScimListResponse{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &list))
+	as.Equal(1, list.TotalResults)
+
+	// the name of a team is unique
+	res = as.JSON("/scim/v2/Groups").Post(models.ScimGroup{DisplayName: "platform"})
+	as.Equal(http.StatusConflict, res.Code)
+
+	res = as.JSON("/scim/v2/Groups/" + created.ID).Delete()
+	as.Equal(http.StatusNoContent, res.Code)
+}
+
+func (as *ActionSuite) Test_ScimDiscovery() {
+	res := as.JSON("/scim/v2/ServiceProviderConfig").Get()
+	as.Equal(http.StatusOK, res.Code)
+
+	config := models.ScimServiceProviderConfig{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &config))
+	as.True(config.Patch.Supported)
+	as.True(config.Filter.Supported)
+
+	res = as.JSON("/scim/v2/Schemas/" + models.ScimUserExtensionSchema).Get()
+	as.Equal(http.StatusOK, res.Code)
+
+	schema := models.ScimSchema{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &schema))
+	as.Equal("type", schema.Attributes[0].Name)
+
+	res = as.JSON("/scim/v2/ResourceTypes").Get()
+	as.Equal(http.StatusOK, res.Code)
+}
+
+func (as *ActionSuite) Test_ScimAuth() {
+	envy.Set("SCIM_TOKEN", "secret")
+	defer envy.Set("SCIM_TOKEN", "")
+
+	res := as.JSON("/scim/v2/Users").Get()
+	as.Equal(http.StatusUnauthorized, res.Code)
+
+	req := as.JSON("/scim/v2/Users")
+	req.Headers["Authorization"] = "Bearer secret"
+	res = req.Get()
+	as.Equal(http.StatusOK, res.Code)
+}
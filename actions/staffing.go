@@ -0,0 +1,54 @@
+package actions
+
+import (
+	"fmt"
+	"net/http"
+	"team_manager/models"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gobuffalo/x/responder"
+)
+
+// StaffingSearch ranks the members available to staff a project.
+// @Summary Search members to staff a project
+// @Description Returns the members having every required tag and at least min_allocation free (default 1%) on each day from from (default today) to to (no end without it). They are ranked by score, the share of the required and nice-to-have tags they have, then the members of the type preferred first, then the most free allocation.
+// @ID staffing-search
+// @Accept json,xml
+// @Produce json,xml
+// @Param search body models.StaffingSearch true "Search"
+// @Success 200 {object} models.StaffingCandidates
+// @Failure 422 {object} validate.Errors
+// @Failure 500
+// @Router /staffing/search [post]
+func StaffingSearch(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	search := &models.StaffingSearch{}
+	if err := c.Bind(search); err != nil {
+		return err
+	}
+
+	if verrs := search.Validate(); verrs.HasAny() {
+		return responder.Wants("json", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.JSON(verrs))
+		}).Wants("xml", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.XML(verrs))
+		}).Respond(c)
+	}
+
+	candidates, err := models.SearchStaffing(tx, *search)
+	if err != nil {
+		return err
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.JSON(candidates))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.XML(candidates))
+	}).Respond(c)
+}
@@ -0,0 +1,31 @@
+package actions
+
+import (
+	"encoding/json"
+	"net/http"
+	"team_manager/models"
+)
+
+func (as *ActionSuite) Test_StaffingSearch() {
+	as.LoadFixture("projects")
+
+	res := as.JSON("/v1/staffing/search").Post(map[string]interface{}{
+		"required":     []string{},
+		"nice_to_have": []string{"golang", "kubernetes"},
+		"from":         "2026-07-01T00:00:00Z",
+	})
+	as.Equal(http.StatusOK, res.Code)
+
+	candidates := models.StaffingCandidates{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &candidates))
+	as.Equal(2, len(candidates))
+	as.Equal("Staffed Developer", candidates[0].Member.Name)
+	as.Equal(50, candidates[0].Score)
+	as.Equal(60, candidates[0].FreeAllocation)
+
+	res = as.JSON("/v1/staffing/search").Post(map[string]interface{}{
+		"from": "2026-07-01T00:00:00Z",
+		"to":   "2026-06-01T00:00:00Z",
+	})
+	as.Equal(http.StatusUnprocessableEntity, res.Code)
+}
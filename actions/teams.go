@@ -0,0 +1,448 @@
+package actions
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"team_manager/models"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gobuffalo/x/responder"
+)
+
+// TeamsResource is the resource for the Team model (CRUD)
+type TeamsResource struct {
+	buffalo.Resource
+}
+
+// List gets all Teams.
+// @Summary List teams
+// @ID list-teams
+// @Param page query integer false "Go to the page"
+// @Param per_page query integer false "How many team per pages"
+// @Param parent_id query string false "Only the teams directly under this team, "root" for the top-level teams"
+// @Produce json,xml
+// @Success 200 {object} models.Teams
+// @Failure 500
+// @Router /teams [get]
+func (v TeamsResource) List(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	teams := models.Teams{}
+
+	// Paginate results. Params "page" and "per_page" control pagination.
+	// Default values are "page=1" and "per_page=20".
+	q := tx.PaginateFromParams(c.Params()).Order("name")
+
+	switch parent := c.Param("parent_id"); parent {
+	case "":
+	case "root":
+		q = q.Where("parent_id IS NULL")
+	default:
+		q = q.Where("parent_id::text = ?", parent)
+	}
+
+	// Retrieve all Teams from the DB
+	if err := q.All(&teams); err != nil {
+		return err
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(200, r.JSON(teams))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(200, r.XML(teams))
+	}).Respond(c)
+}
+
+// Show gets the data for one Team, with its members.
+// @Summary Show a team
+// @ID show-team
+// @Produce json,xml
+// @Param team_id path string true "Team ID"
+// @Success 200 {object} models.Team
+// @Failure 404,500
+// @Router /teams/{team_id} [get]
+func (v TeamsResource) Show(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	// Allocate an empty Team
+	team := &models.Team{}
+
+	// To find the Team the parameter team_id is used.
+	if err := tx.EagerPreload("Members").Find(team, c.Param("team_id")); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(200, r.JSON(team))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(200, r.XML(team))
+	}).Respond(c)
+}
+
+// Create adds a Team to the DB.
+// @Summary Create a new team
+// @Description Members are added with /teams/{team_id}/members, the members of the payload are ignored.
+// @ID create-team
+// @Accept json,xml
+// @Produce json,xml
+// @Param team body models.Team true "Team Payload"
+// @Success 201 {object} models.Team
+// @Failure 422 {object} validate.Errors
+// @Failure 500
+// @Router /teams [post]
+func (v TeamsResource) Create(c buffalo.Context) error {
+	// Allocate an empty Team
+	team := &models.Team{}
+
+	// Bind team to the request payload
+	if err := c.Bind(team); err != nil {
+		return err
+	}
+
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	// Validate the data from the request
+	verrs, err := tx.ValidateAndCreate(team)
+	if err != nil {
+		return err
+	}
+
+	if verrs.HasAny() {
+		return responder.Wants("json", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.JSON(verrs))
+		}).Wants("xml", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.XML(verrs))
+		}).Respond(c)
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusCreated, r.JSON(team))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusCreated, r.XML(team))
+	}).Respond(c)
+}
+
+// Update changes a Team in the DB.
+// @Summary Update a team
+// @ID update-team
+// @Accept json,xml
+// @Produce json,xml
+// @Param team_id path string true "Team ID"
+// @Param team body models.Team true "Team Payload"
+// @Success 200 {object} models.Team
+// @Failure 422 {object} validate.Errors
+// @Failure 404,500
+// @Router /teams/{team_id} [put]
+func (v TeamsResource) Update(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	// Allocate an empty Team
+	team := &models.Team{}
+
+	if err := tx.Find(team, c.Param("team_id")); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	// Bind Team to the request payload
+	if err := c.Bind(team); err != nil {
+		return err
+	}
+
+	verrs, err := tx.ValidateAndUpdate(team)
+	if err != nil {
+		return err
+	}
+
+	if verrs.HasAny() {
+		return responder.Wants("json", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.JSON(verrs))
+		}).Wants("xml", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.XML(verrs))
+		}).Respond(c)
+	}
+
+	if err := tx.Load(team, "Members"); err != nil {
+		return err
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.JSON(team))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.XML(team))
+	}).Respond(c)
+}
+
+// Destroy deletes a Team from the DB, its members are kept.
+// A team with sub-teams is not deleted, they must be moved or deleted first.
+// @Summary Delete a team
+// @ID delete-team
+// @Param team_id path string true "Team ID"
+// @Success 204
+// @Failure 422 {object} validate.Errors
+// @Failure 404,500
+// @Router /teams/{team_id} [delete]
+func (v TeamsResource) Destroy(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	// Allocate an empty Team
+	team := &models.Team{}
+
+	// To find the Team the parameter team_id is used.
+	if err := tx.Find(team, c.Param("team_id")); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	hasSubTeams, err := tx.Where("parent_id = ?", team.ID).Exists(&models.Team{})
+	if err != nil {
+		return err
+	}
+
+	if hasSubTeams {
+		verrs := validate.NewErrors()
+		verrs.Add("parent_id", "Team has sub-teams, move or delete them first.")
+		return responder.Wants("json", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.JSON(verrs))
+		}).Wants("xml", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.XML(verrs))
+		}).Respond(c)
+	}
+
+	if err := tx.Destroy(team); err != nil {
+		return err
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusNoContent, nil)
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusNoContent, nil)
+	}).Respond(c)
+}
+
+// TeamMembersList gets the members of a Team,
+// and of its sub-teams when recursive is set.
+// @Summary List the members of a team
+// @ID list-team-members
+// @Param team_id path string true "Team ID"
+// @Param recursive query boolean false "Also list the members of the sub-teams, at any depth"
+// @Param page query integer false "Go to the page"
+// @Param per_page query integer false "How many member per pages"
+// @Produce json,xml
+// @Success 200 {object} models.Members
+// @Failure 404,500
+// @Router /teams/{team_id}/members [get]
+func TeamMembersList(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	team := &models.Team{}
+	if err := tx.Find(team, c.Param("team_id")); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	filter := models.MemberFilter{Team: team.ID.String()}
+	if recursive, _ := strconv.ParseBool(c.Param("recursive")); recursive {
+		filter = models.MemberFilter{Unit: team.ID.String()}
+	}
+
+	members := models.Members{}
+	q := tx.PaginateFromParams(c.Params()).Scope(filter.Scope).EagerPreload("Teams")
+	if err := q.Order("name").All(&members); err != nil {
+		return err
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.JSON(members))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.XML(members))
+	}).Respond(c)
+}
+
+// TeamMembersAdd adds a Member to a Team.
+// @Summary Add a member to a team
+// @ID add-team-member
+// @Accept json,xml
+// @Produce json,xml
+// @Param team_id path string true "Team ID"
+// @Param membership body models.TeamMembership true "Only member_id is read"
+// @Success 201 {object} models.TeamMembership
+// @Failure 422 {object} validate.Errors
+// @Failure 404,500
+// @Router /teams/{team_id}/members [post]
+func TeamMembersAdd(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	team := &models.Team{}
+	if err := tx.Find(team, c.Param("team_id")); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	membership := &models.TeamMembership{}
+	if err := c.Bind(membership); err != nil {
+		return err
+	}
+	membership.TeamID = team.ID
+
+	verrs, err := tx.ValidateAndCreate(membership)
+	if err != nil {
+		return err
+	}
+
+	if verrs.HasAny() {
+		return responder.Wants("json", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.JSON(verrs))
+		}).Wants("xml", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.XML(verrs))
+		}).Respond(c)
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusCreated, r.JSON(membership))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusCreated, r.XML(membership))
+	}).Respond(c)
+}
+
+// TeamMembersRemove removes a Member from a Team, the member is kept.
+// @Summary Remove a member from a team
+// @ID remove-team-member
+// @Param team_id path string true "Team ID"
+// @Param member_id path string true "Member ID"
+// @Success 204
+// @Failure 404,500
+// @Router /teams/{team_id}/members/{member_id} [delete]
+func TeamMembersRemove(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	membership := &models.TeamMembership{}
+	err := tx.Where("team_id = ? AND member_id = ?", c.Param("team_id"), c.Param("member_id")).First(membership)
+	if err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	if err := tx.Destroy(membership); err != nil {
+		return err
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusNoContent, nil)
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusNoContent, nil)
+	}).Respond(c)
+}
+
+// TeamMove moves a Team under another parent, or to the top of the organisation
+// without parent_id. A team cannot be moved under itself or one of its sub-teams.
+// @Summary Move a team
+// @ID move-team
+// @Accept json,xml
+// @Produce json,xml
+// @Param team_id path string true "Team ID"
+// @Param move body models.Team true "Only parent_id is read"
+// @Success 200 {object} models.Team
+// @Failure 422 {object} validate.Errors
+// @Failure 404,500
+// @Router /teams/{team_id}/move [post]
+func TeamMove(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	team := &models.Team{}
+	if err := tx.Find(team, c.Param("team_id")); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	move := &models.Team{}
+	if err := c.Bind(move); err != nil {
+		return err
+	}
+	team.ParentID = move.ParentID
+
+	verrs, err := tx.ValidateAndUpdate(team)
+	if err != nil {
+		return err
+	}
+
+	if verrs.HasAny() {
+		return responder.Wants("json", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.JSON(verrs))
+		}).Wants("xml", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.XML(verrs))
+		}).Respond(c)
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.JSON(team))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.XML(team))
+	}).Respond(c)
+}
+
+// TeamPath gets the breadcrumbs of a Team,
+// from the top of the organisation down to the team.
+// @Summary Path of a team
+// @ID team-path
+// @Produce json,xml
+// @Param team_id path string true "Team ID"
+// @Success 200 {object} models.Teams
+// @Failure 404,500
+// @Router /teams/{team_id}/path [get]
+func TeamPath(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	team := &models.Team{}
+	if err := tx.Find(team, c.Param("team_id")); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	path, err := models.TeamPath(tx, team.ID)
+	if err != nil {
+		return err
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.JSON(path))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.XML(path))
+	}).Respond(c)
+}
@@ -0,0 +1,138 @@
+package actions
+
+import (
+	"encoding/json"
+	"net/http"
+	"team_manager/models"
+)
+
+func (as *ActionSuite) Test_TeamsResource_List() {
+	as.LoadFixture("teams")
+
+	res := as.JSON("/v1/teams").Get()
+	as.Equal(http.StatusOK, res.Code)
+
+	teams := models.Teams{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &teams))
+	as.Equal(2, len(teams))
+	as.Equal("Frontend", teams[0].Name)
+}
+
+func (as *ActionSuite) Test_TeamsResource_Show() {
+	as.LoadFixture("teams")
+
+	target := &models.Team{}
+	as.NoError(as.DB.Where("name = ?", "Platform").First(target))
+
+	res := as.JSON("/v1/teams/" + target.ID.String()).Get()
+	as.Equal(http.StatusOK, res.Code)
+
+	team := models.Team{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &team))
+	as.Equal("Platform", team.Name)
+	as.True(team.LeadID.Valid)
+	as.Equal(2, len(team.Members))
+}
+
+func (as *ActionSuite) Test_TeamsResource_Create() {
+	res := as.JSON("/v1/teams").Post(map[string]string{"name": "Data", "description": "Data engineering"})
+	as.Equal(http.StatusCreated, res.Code)
+
+	res = as.JSON("/v1/teams").Post(map[string]string{"name": "data"})
+	as.Equal(http.StatusUnprocessableEntity, res.Code)
+}
+
+func (as *ActionSuite) Test_TeamsResource_Destroy() {
+	as.LoadFixture("teams")
+
+	target := &models.Team{}
+	as.NoError(as.DB.Where("name = ?", "Platform").First(target))
+
+	res := as.JSON("/v1/teams/" + target.ID.String()).Delete()
+	as.Equal(http.StatusNoContent, res.Code)
+
+	count, err := as.DB.Where("name = ?", "Team Lead").Count(&models.Member{})
+	as.NoError(err)
+	as.Equal(1, count)
+}
+
+func (as *ActionSuite) Test_TeamMembers() {
+	as.LoadFixture("teams")
+
+	team := &models.Team{}
+	as.NoError(as.DB.Where("name = ?", "Frontend").First(team))
+	member := &models.Member{}
+	as.NoError(as.DB.Where("name = ?", "Team Engineer").First(member))
+
+	res := as.JSON("/v1/teams/" + team.ID.String() + "/members").Post(map[string]string{"member_id": member.ID.String()})
+	as.Equal(http.StatusCreated, res.Code)
+
+	res = as.JSON("/v1/teams/" + team.ID.String() + "/members").Post(map[string]string{"member_id": member.ID.String()})
+	as.Equal(http.StatusUnprocessableEntity, res.Code)
+
+	res = as.JSON("/v1/teams/" + team.ID.String() + "/members").Get()
+	as.Equal(http.StatusOK, res.Code)
+	members := models.Members{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &members))
+	as.Equal(2, len(members))
+
+	// the member payload lists its teams
+	res = as.JSON("/v1/members/" + member.ID.String()).Get()
+	as.Equal(http.StatusOK, res.Code)
+	m := models.Member{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &m))
+	as.Equal(2, len(m.Teams))
+
+	res = as.JSON("/v1/teams/" + team.ID.String() + "/members/" + member.ID.String()).Delete()
+	as.Equal(http.StatusNoContent, res.Code)
+
+	res = as.JSON("/v1/teams/" + team.ID.String() + "/members/" + member.ID.String()).Delete()
+	as.Equal(http.StatusNotFound, res.Code)
+}
+
+func (as *ActionSuite) Test_TeamHierarchy() {
+	as.LoadFixture("org")
+
+	engineering := &models.Team{}
+	as.NoError(as.DB.Where("name = ?", "Engineering").First(engineering))
+	infra := &models.Team{}
+	as.NoError(as.DB.Where("name = ?", "Infra Squad").First(infra))
+	sales := &models.Team{}
+	as.NoError(as.DB.Where("name = ?", "Sales").First(sales))
+
+	res := as.JSON("/v1/teams?parent_id=root").Get()
+	as.Equal(http.StatusOK, res.Code)
+	teams := models.Teams{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &teams))
+	as.Equal(2, len(teams))
+
+	res = as.JSON("/v1/teams/" + engineering.ID.String() + "/members?recursive=true").Get()
+	as.Equal(http.StatusOK, res.Code)
+	members := models.Members{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &members))
+	as.Equal(3, len(members))
+
+	res = as.JSON("/v1/teams/" + infra.ID.String() + "/path").Get()
+	as.Equal(http.StatusOK, res.Code)
+	path := models.Teams{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &path))
+	as.Equal(3, len(path))
+	as.Equal("Engineering", path[0].Name)
+
+	// a team cannot move under one of its sub-teams
+	res = as.JSON("/v1/teams/" + engineering.ID.String() + "/move").Post(map[string]string{"parent_id": infra.ID.String()})
+	as.Equal(http.StatusUnprocessableEntity, res.Code)
+
+	res = as.JSON("/v1/teams/" + infra.ID.String() + "/move").Post(map[string]string{"parent_id": sales.ID.String()})
+	as.Equal(http.StatusOK, res.Code)
+
+	res = as.JSON("/v1/members?unit=" + engineering.ID.String()).Get()
+	as.Equal(http.StatusOK, res.Code)
+	members = models.Members{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &members))
+	as.Equal(2, len(members))
+
+	// a team with sub-teams is not deleted
+	res = as.JSON("/v1/teams/" + sales.ID.String()).Delete()
+	as.Equal(http.StatusUnprocessableEntity, res.Code)
+}
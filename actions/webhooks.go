@@ -0,0 +1,291 @@
+package actions
+
+import (
+	"fmt"
+	"net/http"
+	"team_manager/models"
+	"time"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gobuffalo/x/responder"
+)
+
+// WebhooksResource is the resource for the Webhook model (CRUD)
+type WebhooksResource struct {
+	buffalo.Resource
+}
+
+// List gets all Webhooks.
+// @Summary List webhooks
+// @ID list-webhooks
+// @Param page query integer false "Go to the page"
+// @Param per_page query integer false "How many webhook per pages"
+// @Produce json,xml
+// @Success 200 {object} models.Webhooks
+// @Failure 500
+// @Router /webhooks [get]
+func (v WebhooksResource) List(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	webhooks := models.Webhooks{}
+
+	// Paginate results. Params "page" and "per_page" control pagination.
+	// Default values are "page=1" and "per_page=20".
+	q := tx.PaginateFromParams(c.Params())
+
+	// Retrieve all Webhooks from the DB
+	if err := q.All(&webhooks); err != nil {
+		return err
+	}
+
+	// the secrets are never sent back
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(200, r.JSON(webhooks))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(200, r.XML(webhooks))
+	}).Respond(c)
+}
+
+// Show gets the data for one Webhook.
+// @Summary Show a webhook
+// @ID show-webhook
+// @Produce json,xml
+// @Param webhook_id path string true "Webhook ID"
+// @Success 200 {object} models.Webhook
+// @Failure 404,500
+// @Router /webhooks/{webhook_id} [get]
+func (v WebhooksResource) Show(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	// Allocate an empty Webhook
+	webhook := &models.Webhook{}
+
+	// To find the Webhook the parameter webhook_id is used.
+	if err := tx.Find(webhook, c.Param("webhook_id")); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	return renderWebhook(c, http.StatusOK, webhook)
+}
+
+// Create adds a Webhook to the DB.
+// @Summary Register a webhook
+// @Description Register an URL notified of the member events. Each delivery is a POST of the event signed with the secret: the X-Webhook-Signature header is "sha256=" followed by the hex HMAC-SHA256 of the X-Webhook-Timestamp header, a dot and the body. The secret is never returned.
+// @ID create-webhook
+// @Accept json,xml
+// @Produce json,xml
+// @Param webhook body models.Webhook true "Webhook Payload"
+// @Success 201 {object} models.Webhook
+// @Failure 422 {object} validate.Errors
+// @Failure 500
+// @Router /webhooks [post]
+func (v WebhooksResource) Create(c buffalo.Context) error {
+	// Allocate an empty Webhook
+	webhook := &models.Webhook{}
+
+	// Bind webhook to the request payload
+	if err := c.Bind(webhook); err != nil {
+		return err
+	}
+
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	// Validate the data from the request
+	verrs, err := tx.ValidateAndCreate(webhook)
+	if err != nil {
+		return err
+	}
+
+	if verrs.HasAny() {
+		return responder.Wants("json", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.JSON(verrs))
+		}).Wants("xml", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.XML(verrs))
+		}).Respond(c)
+	}
+
+	return renderWebhook(c, http.StatusCreated, webhook)
+}
+
+// Update changes a Webhook in the DB.
+// @Summary Update a webhook
+// @Description The secret is kept when it is not sent.
+// @ID update-webhook
+// @Accept json,xml
+// @Produce json,xml
+// @Param webhook_id path string true "Webhook ID"
+// @Param webhook body models.Webhook true "Webhook Payload"
+// @Success 200 {object} models.Webhook
+// @Failure 422 {object} validate.Errors
+// @Failure 404,500
+// @Router /webhooks/{webhook_id} [put]
+func (v WebhooksResource) Update(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	// Allocate an empty Webhook
+	webhook := &models.Webhook{}
+
+	if err := tx.Find(webhook, c.Param("webhook_id")); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	// Bind Webhook to the request payload
+	if err := c.Bind(webhook); err != nil {
+		return err
+	}
+
+	verrs, err := tx.ValidateAndUpdate(webhook)
+	if err != nil {
+		return err
+	}
+
+	if verrs.HasAny() {
+		return responder.Wants("json", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.JSON(verrs))
+		}).Wants("xml", func(c buffalo.Context) error {
+			return c.Render(http.StatusUnprocessableEntity, r.XML(verrs))
+		}).Respond(c)
+	}
+
+	return renderWebhook(c, http.StatusOK, webhook)
+}
+
+// Destroy deletes a Webhook and its deliveries from the DB.
+// @Summary Delete a webhook
+// @ID delete-webhook
+// @Param webhook_id path string true "Webhook ID"
+// @Success 204
+// @Failure 404,500
+// @Router /webhooks/{webhook_id} [delete]
+func (v WebhooksResource) Destroy(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	// Allocate an empty Webhook
+	webhook := &models.Webhook{}
+
+	// To find the Webhook the parameter webhook_id is used.
+	if err := tx.Find(webhook, c.Param("webhook_id")); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	if err := tx.Destroy(webhook); err != nil {
+		return err
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusNoContent, nil)
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusNoContent, nil)
+	}).Respond(c)
+}
+
+// WebhookDeliveries lists the deliveries of a webhook, the most recent first.
+// @Summary Delivery log of a webhook
+// @ID list-webhook-deliveries
+// @Param webhook_id path string true "Webhook ID"
+// @Param status query string false "Only the deliveries with this status" Enums(pending, delivered, dead)
+// @Param page query integer false "Go to the page"
+// @Param per_page query integer false "How many delivery per pages"
+// @Produce json,xml
+// @Success 200 {object} models.WebhookDeliveries
+// @Failure 404,500
+// @Router /webhooks/{webhook_id}/deliveries [get]
+func WebhookDeliveries(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	webhook := &models.Webhook{}
+	if err := tx.Find(webhook, c.Param("webhook_id")); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	deliveries := models.WebhookDeliveries{}
+	q := tx.PaginateFromParams(c.Params()).Where("webhook_id = ?", webhook.ID)
+	if status := c.Param("status"); status != "" {
+		q = q.Where("status = ?", status)
+	}
+
+	if err := q.Order("created_at desc").All(&deliveries); err != nil {
+		return err
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.JSON(deliveries))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusOK, r.XML(deliveries))
+	}).Respond(c)
+}
+
+// WebhookRedeliver schedules a delivery again, whatever its status.
+// @Summary Redeliver a webhook delivery
+// @Description The delivery is sent again in the background with a fresh set of attempts, dead deliveries included.
+// @ID redeliver-webhook-delivery
+// @Param webhook_id path string true "Webhook ID"
+// @Param delivery_id path string true "Delivery ID"
+// @Produce json,xml
+// @Success 202 {object} models.WebhookDelivery
+// @Failure 404,500
+// @Router /webhooks/{webhook_id}/deliveries/{delivery_id}/redeliver [post]
+func WebhookRedeliver(c buffalo.Context) error {
+	// Get the DB connection from the context
+	tx, ok := c.Value("tx").(*pop.Connection)
+	if !ok {
+		return fmt.Errorf("no transaction found")
+	}
+
+	delivery := &models.WebhookDelivery{}
+	err := tx.Where("webhook_id = ?", c.Param("webhook_id")).Find(delivery, c.Param("delivery_id"))
+	if err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	delivery.Redeliver(time.Now())
+	if err := tx.Update(delivery); err != nil {
+		return err
+	}
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(http.StatusAccepted, r.JSON(delivery))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(http.StatusAccepted, r.XML(delivery))
+	}).Respond(c)
+}
+
+// renderWebhook responds with the webhook, without its secret
+func renderWebhook(c buffalo.Context, status int, webhook *models.Webhook) error {
+	webhook.Secret = ""
+
+	return responder.Wants("json", func(c buffalo.Context) error {
+		return c.Render(status, r.JSON(webhook))
+	}).Wants("xml", func(c buffalo.Context) error {
+		return c.Render(status, r.XML(webhook))
+	}).Respond(c)
+}
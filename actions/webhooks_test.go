@@ -0,0 +1,125 @@
+package actions
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"team_manager/models"
+	"time"
+
+	"github.com/gobuffalo/pop/slices"
+)
+
+func (as *ActionSuite) createWebhook(url string) *models.Webhook {
+	w := &models.Webhook{URL: url, Secret: "a-secret-long-enough", EventTypes: slices.String{models.MemberCreated}}
+	as.NoError(models.DB.Create(w))
+	return w
+}
+
+func (as *ActionSuite) Test_WebhooksResource_Create() {
+	w := &models.Webhook{
+		URL:        "https://example.com/hooks",
+		Secret:     "a-secret-long-enough",
+		EventTypes: slices.String{models.MemberCreated, models.MemberUpdated},
+	}
+	res := as.JSON("/v1/webhooks").Post(w)
+	as.Equal(http.StatusCreated, res.Code)
+
+	webhook := models.Webhook{}
+	err := json.Unmarshal(res.Body.Bytes(), &webhook)
+	as.NoError(err)
+	as.Equal(w.URL, webhook.URL)
+	as.Equal(w.EventTypes, webhook.EventTypes)
+	as.Empty(webhook.Secret)
+}
+
+func (as *ActionSuite) Test_WebhooksResource_Create_Invalid() {
+	w := &models.Webhook{URL: "https://example.com/hooks"}
+	res := as.JSON("/v1/webhooks").Post(w)
+	as.Equal(http.StatusUnprocessableEntity, res.Code)
+}
+
+func (as *ActionSuite) Test_WebhooksResource_Update_KeepsSecret() {
+	w := as.createWebhook("https://example.com/hooks")
+
+	res := as.JSON("/v1/webhooks/" + w.ID.String()).Put(map[string]interface{}{"disabled": true})
+	as.Equal(http.StatusOK, res.Code)
+
+	as.NoError(models.DB.Reload(w))
+	as.True(w.Disabled)
+	as.Equal("a-secret-long-enough", w.Secret)
+}
+
+func (as *ActionSuite) Test_WebhookDeliveries_Redeliver() {
+	w := as.createWebhook("https://example.com/hooks")
+	as.NoError(models.DB.Create(&models.Member{Name: "Member Name", Type: "employee", Role: "DevOps"}))
+	as.NoError(dispatchOutbox(models.DB, time.Now))
+
+	res := as.JSON("/v1/webhooks/" + w.ID.String() + "/deliveries").Get()
+	as.Equal(http.StatusOK, res.Code)
+
+	deliveries := models.WebhookDeliveries{}
+	as.NoError(json.Unmarshal(res.Body.Bytes(), &deliveries))
+	as.Equal(1, len(deliveries))
+
+	d := deliveries[0]
+	d.Status = models.DeliveryDead
+	d.Attempts = models.WebhookMaxAttempts
+	as.NoError(models.DB.Update(&d))
+
+	res = as.JSON("/v1/webhooks/" + w.ID.String() + "/deliveries/" + d.ID.String() + "/redeliver").Post(nil)
+	as.Equal(http.StatusAccepted, res.Code)
+
+	as.NoError(models.DB.Reload(&d))
+	as.Equal(models.DeliveryPending, d.Status)
+	as.Equal(0, d.Attempts)
+}
+
+func (as *ActionSuite) Test_DeliverWebhooks() {
+	received := make(chan *http.Request, 1)
+	var body []byte
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = ioutil.ReadAll(r.Body)
+		received <- r
+	}))
+	defer receiver.Close()
+
+	as.createWebhook(receiver.URL)
+	as.NoError(models.DB.Create(&models.Member{Name: "Member Name", Type: "employee", Role: "DevOps"}))
+	as.NoError(dispatchOutbox(models.DB, time.Now))
+
+	as.NoError(deliverWebhooks(models.DB, time.Now))
+
+	r := <-received
+	timestamp := r.Header.Get("X-Webhook-Timestamp")
+	as.Equal(models.MemberCreated, r.Header.Get("X-Webhook-Event"))
+	as.Equal(signWebhook("a-secret-long-enough", timestamp, body), r.Header.Get("X-Webhook-Signature"))
+
+	d := &models.WebhookDelivery{}
+	as.NoError(models.DB.First(d))
+	as.Equal(models.DeliveryDelivered, d.Status)
+	as.Equal(http.StatusOK, d.ResponseStatus)
+	as.Equal(1, d.Attempts)
+}
+
+func (as *ActionSuite) Test_DeliverWebhooks_Retry() {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer receiver.Close()
+
+	as.createWebhook(receiver.URL)
+	as.NoError(models.DB.Create(&models.Member{Name: "Member Name", Type: "employee", Role: "DevOps"}))
+	as.NoError(dispatchOutbox(models.DB, time.Now))
+
+	now := time.Now()
+	as.NoError(deliverWebhooks(models.DB, func() time.Time { return now }))
+
+	d := &models.WebhookDelivery{}
+	as.NoError(models.DB.First(d))
+	as.Equal(models.DeliveryPending, d.Status)
+	as.Equal(http.StatusServiceUnavailable, d.ResponseStatus)
+	as.Equal(1, d.Attempts)
+	as.True(d.NextAttemptAt.Time.After(now))
+}
@@ -0,0 +1,118 @@
+package actions
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"team_manager/models"
+	"time"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/pop/v5"
+)
+
+// deliverWebhooksJob sends the pending webhook deliveries
+const deliverWebhooksJob = "webhooks:deliver"
+
+var (
+	// webhookDeliverInterval is how often the pending deliveries are looked for
+	webhookDeliverInterval = 5 * time.Second
+	// webhookClient sends the deliveries, a webhook has 10 seconds to answer
+	webhookClient = &http.Client{Timeout: 10 * time.Second}
+)
+
+// registerWebhooksWorker registers the delivery job on the app worker,
+// it starts running along with the worker
+func registerWebhooksWorker(app *buffalo.App) {
+	registerPeriodicJob(app, deliverWebhooksJob, webhookDeliverInterval, func() error {
+		return deliverWebhooks(models.DB, time.Now)
+	})
+}
+
+// deliverWebhooks sends every delivery due, one transaction each
+// so a delivery is marked as sent as soon as the webhook accepts it
+func deliverWebhooks(db *pop.Connection, now func() time.Time) error {
+	for {
+		found := false
+		err := db.Transaction(func(tx *pop.Connection) error {
+			d, ok, err := models.NextDueWebhookDelivery(tx, now())
+			if err != nil || !ok {
+				return err
+			}
+			found = true
+
+			webhook := &models.Webhook{}
+			if err := tx.Find(webhook, d.WebhookID); err != nil {
+				return err
+			}
+
+			status, err := sendWebhook(webhook, d, now())
+			if err != nil {
+				d.Failed(status, err.Error(), now())
+			} else {
+				d.Delivered(status, now())
+			}
+
+			return tx.Update(d)
+		})
+
+		if err != nil || !found {
+			return err
+		}
+	}
+}
+
+// sendWebhook posts the delivery payload to the webhook, signed with its secret.
+// Any response other than a 2xx is an error.
+func sendWebhook(webhook *models.Webhook, d *models.WebhookDelivery, now time.Time) (int, error) {
+	if webhook.Disabled {
+		return 0, fmt.Errorf("the webhook is disabled")
+	}
+
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "team-manager-webhooks")
+	req.Header.Set("X-Webhook-ID", webhook.ID.String())
+	req.Header.Set("X-Webhook-Delivery", d.ID.String())
+	req.Header.Set("X-Webhook-Event", d.EventType)
+	req.Header.Set("X-Webhook-Timestamp", timestamp)
+	req.Header.Set("X-Webhook-Signature", signWebhook(webhook.Secret, timestamp, d.Payload))
+
+	res, err := webhookClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	// the body is drained so the connection can be reused
+	io.Copy(ioutil.Discard, io.LimitReader(res.Body, 1<<16))
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return res.StatusCode, fmt.Errorf("the webhook answered %s", res.Status)
+	}
+
+	return res.StatusCode, nil
+}
+
+// signWebhook returns the signature of a delivery: the hex HMAC-SHA256,
+// keyed with the webhook secret, of the timestamp, a dot and the payload
+func signWebhook(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
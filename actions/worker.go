@@ -0,0 +1,30 @@
+package actions
+
+import (
+	"time"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/buffalo/worker"
+	"github.com/gobuffalo/events"
+)
+
+// registerPeriodicJob registers a job on the app worker running every interval,
+// the first run happens as soon as the worker starts.
+// An error is logged, the job keeps running.
+func registerPeriodicJob(app *buffalo.App, name string, interval time.Duration, fn func() error) {
+	w := app.Worker
+
+	err := w.Register(name, func(worker.Args) error {
+		defer w.PerformIn(worker.Job{Handler: name}, interval)
+		return fn()
+	})
+	if err != nil {
+		app.Logger.Fatal(err)
+	}
+
+	events.NamedListen(name, func(e events.Event) {
+		if e.Kind == buffalo.EvtWorkerStart {
+			w.Perform(worker.Job{Handler: name})
+		}
+	})
+}
@@ -3,6 +3,7 @@ module team_manager
 go 1.16
 
 require (
+	github.com/BurntSushi/toml v0.4.1
 	github.com/gobuffalo/buffalo v0.17.3
 	github.com/gobuffalo/buffalo-pop/v2 v2.3.0
 	github.com/gobuffalo/envy v1.9.0
@@ -14,13 +15,13 @@ require (
 	github.com/gobuffalo/pop/v5 v5.3.4
 	github.com/gobuffalo/suite v2.8.2+incompatible
 	github.com/gobuffalo/x v0.1.0
+	github.com/jackc/pgconn v1.10.0
 	github.com/markbates/grift v1.5.0
 	github.com/rs/cors v1.8.0
 	github.com/unrolled/secure v1.0.9
 )
 
 require (
-	github.com/BurntSushi/toml v0.4.1 // indirect
 	github.com/KyleBanks/depth v1.2.1 // indirect
 	github.com/Masterminds/semver/v3 v3.1.1 // indirect
 	github.com/PuerkitoBio/purell v1.1.1 // indirect
@@ -43,7 +44,7 @@ require (
 	github.com/go-sql-driver/mysql v1.6.0 // indirect
 	github.com/gobuffalo/attrs v1.0.0 // indirect
 	github.com/gobuffalo/buffalo-heroku v1.0.9 // indirect
-	github.com/gobuffalo/events v1.4.1 // indirect
+	github.com/gobuffalo/events v1.4.1
 	github.com/gobuffalo/fizz v1.13.0 // indirect
 	github.com/gobuffalo/flect v0.2.3 // indirect
 	github.com/gobuffalo/genny v0.6.0 // indirect
@@ -51,28 +52,29 @@ require (
 	github.com/gobuffalo/github_flavored_markdown v1.1.0 // indirect
 	github.com/gobuffalo/gogen v0.2.0 // indirect
 	github.com/gobuffalo/helpers v0.6.2 // indirect
-	github.com/gobuffalo/httptest v1.5.0 // indirect
+	github.com/gobuffalo/httptest v1.5.0
 	github.com/gobuffalo/logger v1.0.4 // indirect
 	github.com/gobuffalo/meta v0.3.0 // indirect
 	github.com/gobuffalo/mw-csrf v0.0.0-20190129204204-25460a055517 // indirect
-	github.com/gobuffalo/nulls v0.4.0 // indirect
+	github.com/gobuffalo/nulls v0.4.0
 	github.com/gobuffalo/packd v1.0.0 // indirect
 	github.com/gobuffalo/plush v3.8.3+incompatible // indirect
 	github.com/gobuffalo/plush/v4 v4.1.6 // indirect
 	github.com/gobuffalo/plushgen v0.1.2 // indirect
-	github.com/gobuffalo/pop v4.13.1+incompatible // indirect
+	github.com/gobuffalo/pop v4.13.1+incompatible
 	github.com/gobuffalo/tags/v3 v3.1.0 // indirect
 	github.com/gobuffalo/validate v2.0.4+incompatible // indirect
-	github.com/gobuffalo/validate/v3 v3.3.0 // indirect
-	github.com/gofrs/uuid v4.0.0+incompatible // indirect
+	github.com/gobuffalo/validate/v3 v3.3.0
+	github.com/gofrs/uuid v4.0.0+incompatible
 	github.com/gorilla/css v1.0.0 // indirect
 	github.com/gorilla/handlers v1.5.1 // indirect
 	github.com/gorilla/mux v1.8.0 // indirect
 	github.com/gorilla/securecookie v1.1.1 // indirect
 	github.com/gorilla/sessions v1.2.1 // indirect
+	github.com/graph-gophers/dataloader v5.0.0+incompatible
+	github.com/graph-gophers/graphql-go v1.3.0
 	github.com/inconshreveable/mousetrap v1.0.0 // indirect
 	github.com/jackc/chunkreader/v2 v2.0.1 // indirect
-	github.com/jackc/pgconn v1.10.0 // indirect
 	github.com/jackc/pgio v1.0.0 // indirect
 	github.com/jackc/pgpassfile v1.0.0 // indirect
 	github.com/jackc/pgproto3/v2 v2.1.1 // indirect
@@ -112,9 +114,10 @@ require (
 	github.com/spf13/cobra v1.2.1 // indirect
 	github.com/spf13/pflag v1.0.5 // indirect
 	github.com/stretchr/testify v1.7.0 // indirect
-	github.com/swaggo/buffalo-swagger v1.0.0 // indirect
-	github.com/swaggo/swag v1.7.3 // indirect
+	github.com/swaggo/buffalo-swagger v1.0.0
+	github.com/swaggo/swag v1.7.3
 	github.com/urfave/cli/v2 v2.3.0 // indirect
+	github.com/xuri/excelize/v2 v2.4.1
 	golang.org/x/crypto v0.0.0-20210711020723-a769d52b0f97 // indirect
 	golang.org/x/mod v0.4.2 // indirect
 	golang.org/x/net v0.0.0-20210726213435-c6fcb2dbf985 // indirect
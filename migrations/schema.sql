@@ -20,6 +20,187 @@ SET default_tablespace = '';
 
 SET default_table_access_method = heap;
 
+--
+-- Name: assignments; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.assignments (
+    id uuid NOT NULL,
+    member_id uuid NOT NULL,
+    project_id uuid NOT NULL,
+    allocation integer NOT NULL,
+    start_date date NOT NULL,
+    end_date date,
+    role character varying(255) DEFAULT ''::character varying NOT NULL,
+    created_at timestamp without time zone NOT NULL,
+    updated_at timestamp without time zone NOT NULL
+);
+
+
+ALTER TABLE public.assignments OWNER TO postgres;
+
+--
+-- Name: checklist_items; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.checklist_items (
+    id uuid NOT NULL,
+    checklist_id uuid NOT NULL,
+    "position" integer NOT NULL,
+    title character varying(255) NOT NULL,
+    owner_id uuid,
+    completed_at timestamp without time zone,
+    created_at timestamp without time zone NOT NULL,
+    updated_at timestamp without time zone NOT NULL
+);
+
+
+ALTER TABLE public.checklist_items OWNER TO postgres;
+
+--
+-- Name: checklist_templates; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.checklist_templates (
+    id uuid NOT NULL,
+    name character varying(255) NOT NULL,
+    kind character varying(12) NOT NULL,
+    member_types text[],
+    items jsonb DEFAULT '[]'::jsonb NOT NULL,
+    created_at timestamp without time zone NOT NULL,
+    updated_at timestamp without time zone NOT NULL
+);
+
+
+ALTER TABLE public.checklist_templates OWNER TO postgres;
+
+--
+-- Name: checklists; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.checklists (
+    id uuid NOT NULL,
+    member_id uuid NOT NULL,
+    template_id uuid,
+    name character varying(255) NOT NULL,
+    kind character varying(12) NOT NULL,
+    completed_at timestamp without time zone,
+    created_at timestamp without time zone NOT NULL,
+    updated_at timestamp without time zone NOT NULL
+);
+
+
+ALTER TABLE public.checklists OWNER TO postgres;
+
+--
+-- Name: contract_alerts; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.contract_alerts (
+    id uuid NOT NULL,
+    member_id uuid NOT NULL,
+    type character varying(20) NOT NULL,
+    contract_end date NOT NULL,
+    event_id bigint NOT NULL,
+    created_at timestamp without time zone NOT NULL,
+    updated_at timestamp without time zone NOT NULL
+);
+
+
+ALTER TABLE public.contract_alerts OWNER TO postgres;
+
+--
+-- Name: contracts; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.contracts (
+    id uuid NOT NULL,
+    member_id uuid NOT NULL,
+    kind character varying(20) NOT NULL,
+    start_date date NOT NULL,
+    end_date date NOT NULL,
+    approved_by character varying(255) DEFAULT ''::character varying NOT NULL,
+    note text,
+    created_at timestamp without time zone NOT NULL,
+    updated_at timestamp without time zone NOT NULL
+);
+
+
+ALTER TABLE public.contracts OWNER TO postgres;
+
+--
+-- Name: custom_fields; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.custom_fields (
+    id uuid NOT NULL,
+    name character varying(64) NOT NULL,
+    description text,
+    type character varying(10) NOT NULL,
+    options text[],
+    required boolean DEFAULT false NOT NULL,
+    member_types text[],
+    created_at timestamp without time zone NOT NULL,
+    updated_at timestamp without time zone NOT NULL
+);
+
+
+ALTER TABLE public.custom_fields OWNER TO postgres;
+
+--
+-- Name: external_ids; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.external_ids (
+    id uuid NOT NULL,
+    member_id uuid NOT NULL,
+    system character varying(64) NOT NULL,
+    external_id character varying(255) NOT NULL,
+    created_at timestamp without time zone NOT NULL,
+    updated_at timestamp without time zone NOT NULL
+);
+
+
+ALTER TABLE public.external_ids OWNER TO postgres;
+
+--
+-- Name: member_events; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.member_events (
+    id bigint NOT NULL,
+    member_id uuid NOT NULL,
+    type character varying(40) NOT NULL,
+    created_at timestamp without time zone NOT NULL,
+    updated_at timestamp without time zone NOT NULL,
+    payload jsonb DEFAULT '{}'::jsonb NOT NULL,
+    details jsonb DEFAULT '{}'::jsonb NOT NULL
+);
+
+
+ALTER TABLE public.member_events OWNER TO postgres;
+
+--
+-- Name: member_events_id_seq; Type: SEQUENCE; Schema: public; Owner: postgres
+--
+
+CREATE SEQUENCE public.member_events_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+ALTER TABLE public.member_events_id_seq OWNER TO postgres;
+
+--
+-- Name: member_events_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: postgres
+--
+
+ALTER SEQUENCE public.member_events_id_seq OWNED BY public.member_events.id;
+
+
 --
 -- Name: members; Type: TABLE; Schema: public; Owner: postgres
 --
@@ -31,13 +212,97 @@ CREATE TABLE public.members (
     tags text[],
     created_at timestamp without time zone NOT NULL,
     updated_at timestamp without time zone NOT NULL,
-    contract_duration integer DEFAULT 0 NOT NULL,
-    role character varying(255)
+    role character varying(255),
+    manager_id uuid,
+    contract_start date,
+    contract_end date,
+    custom_fields jsonb DEFAULT '{}'::jsonb NOT NULL,
+    status character varying(12) DEFAULT 'active'::character varying NOT NULL,
+    status_since date,
+    role_id uuid,
+    email character varying(254),
+    phone character varying(16),
+    chat_handle character varying(100),
+    location character varying(255),
+    time_zone character varying(64)
 );
 
 
 ALTER TABLE public.members OWNER TO postgres;
 
+--
+-- Name: outbox_messages; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.outbox_messages (
+    id bigint NOT NULL,
+    event_id bigint NOT NULL,
+    sink character varying(50) NOT NULL,
+    status character varying(10) NOT NULL,
+    attempts integer DEFAULT 0 NOT NULL,
+    next_attempt_at timestamp without time zone,
+    dispatched_at timestamp without time zone,
+    last_error text,
+    created_at timestamp without time zone NOT NULL,
+    updated_at timestamp without time zone NOT NULL
+);
+
+
+ALTER TABLE public.outbox_messages OWNER TO postgres;
+
+--
+-- Name: outbox_messages_id_seq; Type: SEQUENCE; Schema: public; Owner: postgres
+--
+
+CREATE SEQUENCE public.outbox_messages_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+ALTER TABLE public.outbox_messages_id_seq OWNER TO postgres;
+
+--
+-- Name: outbox_messages_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: postgres
+--
+
+ALTER SEQUENCE public.outbox_messages_id_seq OWNED BY public.outbox_messages.id;
+
+
+--
+-- Name: projects; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.projects (
+    id uuid NOT NULL,
+    name character varying(255) NOT NULL,
+    description text,
+    created_at timestamp without time zone NOT NULL,
+    updated_at timestamp without time zone NOT NULL
+);
+
+
+ALTER TABLE public.projects OWNER TO postgres;
+
+--
+-- Name: roles; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.roles (
+    id uuid NOT NULL,
+    title character varying(255) NOT NULL,
+    family character varying(255) DEFAULT ''::character varying NOT NULL,
+    level character varying(12) DEFAULT ''::character varying NOT NULL,
+    description text,
+    created_at timestamp without time zone NOT NULL,
+    updated_at timestamp without time zone NOT NULL
+);
+
+
+ALTER TABLE public.roles OWNER TO postgres;
+
 --
 -- Name: schema_migration; Type: TABLE; Schema: public; Owner: postgres
 --
@@ -49,6 +314,182 @@ CREATE TABLE public.schema_migration (
 
 ALTER TABLE public.schema_migration OWNER TO postgres;
 
+--
+-- Name: status_transitions; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.status_transitions (
+    id uuid NOT NULL,
+    member_id uuid NOT NULL,
+    from_status character varying(12),
+    to_status character varying(12) NOT NULL,
+    effective_date date NOT NULL,
+    reason text,
+    created_at timestamp without time zone NOT NULL,
+    updated_at timestamp without time zone NOT NULL
+);
+
+
+ALTER TABLE public.status_transitions OWNER TO postgres;
+
+--
+-- Name: team_memberships; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.team_memberships (
+    id uuid NOT NULL,
+    team_id uuid NOT NULL,
+    member_id uuid NOT NULL,
+    created_at timestamp without time zone NOT NULL,
+    updated_at timestamp without time zone NOT NULL
+);
+
+
+ALTER TABLE public.team_memberships OWNER TO postgres;
+
+--
+-- Name: teams; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.teams (
+    id uuid NOT NULL,
+    name character varying(255) NOT NULL,
+    description text,
+    lead_id uuid,
+    created_at timestamp without time zone NOT NULL,
+    updated_at timestamp without time zone NOT NULL,
+    parent_id uuid
+);
+
+
+ALTER TABLE public.teams OWNER TO postgres;
+
+--
+-- Name: webhook_deliveries; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.webhook_deliveries (
+    id uuid NOT NULL,
+    webhook_id uuid NOT NULL,
+    event_id bigint NOT NULL,
+    event_type character varying(40) NOT NULL,
+    payload jsonb NOT NULL,
+    status character varying(10) NOT NULL,
+    attempts integer DEFAULT 0 NOT NULL,
+    next_attempt_at timestamp without time zone,
+    last_attempt_at timestamp without time zone,
+    response_status integer DEFAULT 0 NOT NULL,
+    last_error text,
+    created_at timestamp without time zone NOT NULL,
+    updated_at timestamp without time zone NOT NULL
+);
+
+
+ALTER TABLE public.webhook_deliveries OWNER TO postgres;
+
+--
+-- Name: webhooks; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.webhooks (
+    id uuid NOT NULL,
+    url character varying(2048) NOT NULL,
+    secret character varying(255) NOT NULL,
+    event_types text[],
+    disabled boolean DEFAULT false NOT NULL,
+    created_at timestamp without time zone NOT NULL,
+    updated_at timestamp without time zone NOT NULL
+);
+
+
+ALTER TABLE public.webhooks OWNER TO postgres;
+
+--
+-- Name: member_events id; Type: DEFAULT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.member_events ALTER COLUMN id SET DEFAULT nextval('public.member_events_id_seq'::regclass);
+
+
+--
+-- Name: outbox_messages id; Type: DEFAULT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.outbox_messages ALTER COLUMN id SET DEFAULT nextval('public.outbox_messages_id_seq'::regclass);
+
+
+--
+-- Name: assignments assignments_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.assignments
+    ADD CONSTRAINT assignments_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: checklist_items checklist_items_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.checklist_items
+    ADD CONSTRAINT checklist_items_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: checklist_templates checklist_templates_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.checklist_templates
+    ADD CONSTRAINT checklist_templates_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: checklists checklists_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.checklists
+    ADD CONSTRAINT checklists_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: contract_alerts contract_alerts_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.contract_alerts
+    ADD CONSTRAINT contract_alerts_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: contracts contracts_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.contracts
+    ADD CONSTRAINT contracts_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: custom_fields custom_fields_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.custom_fields
+    ADD CONSTRAINT custom_fields_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: external_ids external_ids_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.external_ids
+    ADD CONSTRAINT external_ids_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: member_events member_events_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.member_events
+    ADD CONSTRAINT member_events_pkey PRIMARY KEY (id);
+
+
 --
 -- Name: members members_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
 --
@@ -57,6 +498,182 @@ ALTER TABLE ONLY public.members
     ADD CONSTRAINT members_pkey PRIMARY KEY (id);
 
 
+--
+-- Name: outbox_messages outbox_messages_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.outbox_messages
+    ADD CONSTRAINT outbox_messages_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: projects projects_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.projects
+    ADD CONSTRAINT projects_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: roles roles_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.roles
+    ADD CONSTRAINT roles_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: status_transitions status_transitions_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.status_transitions
+    ADD CONSTRAINT status_transitions_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: team_memberships team_memberships_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.team_memberships
+    ADD CONSTRAINT team_memberships_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: teams teams_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.teams
+    ADD CONSTRAINT teams_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: webhook_deliveries webhook_deliveries_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.webhook_deliveries
+    ADD CONSTRAINT webhook_deliveries_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: webhooks webhooks_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.webhooks
+    ADD CONSTRAINT webhooks_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: assignments_member_id_start_date_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX assignments_member_id_start_date_idx ON public.assignments USING btree (member_id, start_date);
+
+
+--
+-- Name: assignments_project_id_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX assignments_project_id_idx ON public.assignments USING btree (project_id);
+
+
+--
+-- Name: checklist_items_checklist_id_position_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX checklist_items_checklist_id_position_idx ON public.checklist_items USING btree (checklist_id, "position");
+
+
+--
+-- Name: checklist_items_owner_id_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX checklist_items_owner_id_idx ON public.checklist_items USING btree (owner_id);
+
+
+--
+-- Name: checklist_templates_name_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE UNIQUE INDEX checklist_templates_name_idx ON public.checklist_templates USING btree (name);
+
+
+--
+-- Name: checklists_member_id_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX checklists_member_id_idx ON public.checklists USING btree (member_id);
+
+
+--
+-- Name: contract_alerts_member_id_type_contract_end_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE UNIQUE INDEX contract_alerts_member_id_type_contract_end_idx ON public.contract_alerts USING btree (member_id, type, contract_end);
+
+
+--
+-- Name: contracts_member_id_start_date_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX contracts_member_id_start_date_idx ON public.contracts USING btree (member_id, start_date);
+
+
+--
+-- Name: custom_fields_name_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE UNIQUE INDEX custom_fields_name_idx ON public.custom_fields USING btree (name);
+
+
+--
+-- Name: external_ids_member_id_system_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE UNIQUE INDEX external_ids_member_id_system_idx ON public.external_ids USING btree (member_id, system);
+
+
+--
+-- Name: external_ids_system_external_id_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE UNIQUE INDEX external_ids_system_external_id_idx ON public.external_ids USING btree (system, external_id);
+
+
+--
+-- Name: member_events_member_id_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX member_events_member_id_idx ON public.member_events USING btree (member_id);
+
+
+--
+-- Name: members_email_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE UNIQUE INDEX members_email_idx ON public.members USING btree (lower((email)::text));
+
+
+--
+-- Name: members_manager_id_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX members_manager_id_idx ON public.members USING btree (manager_id);
+
+
+--
+-- Name: members_role_id_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX members_role_id_idx ON public.members USING btree (role_id);
+
+
+--
+-- Name: members_status_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX members_status_idx ON public.members USING btree (status);
+
+
 --
 -- Name: members_tags_idx; Type: INDEX; Schema: public; Owner: postgres
 --
@@ -64,6 +681,27 @@ ALTER TABLE ONLY public.members
 CREATE INDEX members_tags_idx ON public.members USING btree (tags);
 
 
+--
+-- Name: outbox_messages_status_next_attempt_at_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX outbox_messages_status_next_attempt_at_idx ON public.outbox_messages USING btree (status, next_attempt_at);
+
+
+--
+-- Name: projects_name_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE UNIQUE INDEX projects_name_idx ON public.projects USING btree (name);
+
+
+--
+-- Name: roles_title_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE UNIQUE INDEX roles_title_idx ON public.roles USING btree (lower((title)::text));
+
+
 --
 -- Name: schema_migration_version_idx; Type: INDEX; Schema: public; Owner: postgres
 --
@@ -71,6 +709,199 @@ CREATE INDEX members_tags_idx ON public.members USING btree (tags);
 CREATE UNIQUE INDEX schema_migration_version_idx ON public.schema_migration USING btree (version);
 
 
+--
+-- Name: status_transitions_member_id_effective_date_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX status_transitions_member_id_effective_date_idx ON public.status_transitions USING btree (member_id, effective_date);
+
+
+--
+-- Name: team_memberships_member_id_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX team_memberships_member_id_idx ON public.team_memberships USING btree (member_id);
+
+
+--
+-- Name: team_memberships_team_id_member_id_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE UNIQUE INDEX team_memberships_team_id_member_id_idx ON public.team_memberships USING btree (team_id, member_id);
+
+
+--
+-- Name: teams_name_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE UNIQUE INDEX teams_name_idx ON public.teams USING btree (name);
+
+
+--
+-- Name: teams_parent_id_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX teams_parent_id_idx ON public.teams USING btree (parent_id);
+
+
+--
+-- Name: webhook_deliveries_status_next_attempt_at_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX webhook_deliveries_status_next_attempt_at_idx ON public.webhook_deliveries USING btree (status, next_attempt_at);
+
+
+--
+-- Name: webhook_deliveries_webhook_id_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX webhook_deliveries_webhook_id_idx ON public.webhook_deliveries USING btree (webhook_id);
+
+
+--
+-- Name: assignments assignments_members_id_fk; Type: FK CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.assignments
+    ADD CONSTRAINT assignments_members_id_fk FOREIGN KEY (member_id) REFERENCES public.members(id) ON DELETE CASCADE;
+
+
+--
+-- Name: assignments assignments_projects_id_fk; Type: FK CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.assignments
+    ADD CONSTRAINT assignments_projects_id_fk FOREIGN KEY (project_id) REFERENCES public.projects(id) ON DELETE CASCADE;
+
+
+--
+-- Name: checklist_items checklist_items_checklists_id_fk; Type: FK CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.checklist_items
+    ADD CONSTRAINT checklist_items_checklists_id_fk FOREIGN KEY (checklist_id) REFERENCES public.checklists(id) ON DELETE CASCADE;
+
+
+--
+-- Name: checklist_items checklist_items_members_id_fk; Type: FK CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.checklist_items
+    ADD CONSTRAINT checklist_items_members_id_fk FOREIGN KEY (owner_id) REFERENCES public.members(id) ON DELETE SET NULL;
+
+
+--
+-- Name: checklists checklists_checklist_templates_id_fk; Type: FK CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.checklists
+    ADD CONSTRAINT checklists_checklist_templates_id_fk FOREIGN KEY (template_id) REFERENCES public.checklist_templates(id) ON DELETE SET NULL;
+
+
+--
+-- Name: checklists checklists_members_id_fk; Type: FK CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.checklists
+    ADD CONSTRAINT checklists_members_id_fk FOREIGN KEY (member_id) REFERENCES public.members(id) ON DELETE CASCADE;
+
+
+--
+-- Name: contract_alerts contract_alerts_members_id_fk; Type: FK CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.contract_alerts
+    ADD CONSTRAINT contract_alerts_members_id_fk FOREIGN KEY (member_id) REFERENCES public.members(id) ON DELETE CASCADE;
+
+
+--
+-- Name: contracts contracts_members_id_fk; Type: FK CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.contracts
+    ADD CONSTRAINT contracts_members_id_fk FOREIGN KEY (member_id) REFERENCES public.members(id) ON DELETE CASCADE;
+
+
+--
+-- Name: external_ids external_ids_members_id_fk; Type: FK CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.external_ids
+    ADD CONSTRAINT external_ids_members_id_fk FOREIGN KEY (member_id) REFERENCES public.members(id) ON DELETE CASCADE;
+
+
+--
+-- Name: members members_members_id_fk; Type: FK CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.members
+    ADD CONSTRAINT members_members_id_fk FOREIGN KEY (manager_id) REFERENCES public.members(id) ON DELETE SET NULL;
+
+
+--
+-- Name: members members_roles_id_fk; Type: FK CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.members
+    ADD CONSTRAINT members_roles_id_fk FOREIGN KEY (role_id) REFERENCES public.roles(id) ON DELETE SET NULL;
+
+
+--
+-- Name: outbox_messages outbox_messages_member_events_id_fk; Type: FK CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.outbox_messages
+    ADD CONSTRAINT outbox_messages_member_events_id_fk FOREIGN KEY (event_id) REFERENCES public.member_events(id) ON DELETE CASCADE;
+
+
+--
+-- Name: status_transitions status_transitions_members_id_fk; Type: FK CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.status_transitions
+    ADD CONSTRAINT status_transitions_members_id_fk FOREIGN KEY (member_id) REFERENCES public.members(id) ON DELETE CASCADE;
+
+
+--
+-- Name: team_memberships team_memberships_members_id_fk; Type: FK CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.team_memberships
+    ADD CONSTRAINT team_memberships_members_id_fk FOREIGN KEY (member_id) REFERENCES public.members(id) ON DELETE CASCADE;
+
+
+--
+-- Name: team_memberships team_memberships_teams_id_fk; Type: FK CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.team_memberships
+    ADD CONSTRAINT team_memberships_teams_id_fk FOREIGN KEY (team_id) REFERENCES public.teams(id) ON DELETE CASCADE;
+
+
+--
+-- Name: teams teams_members_id_fk; Type: FK CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.teams
+    ADD CONSTRAINT teams_members_id_fk FOREIGN KEY (lead_id) REFERENCES public.members(id) ON DELETE SET NULL;
+
+
+--
+-- Name: teams teams_teams_id_fk; Type: FK CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.teams
+    ADD CONSTRAINT teams_teams_id_fk FOREIGN KEY (parent_id) REFERENCES public.teams(id) ON DELETE RESTRICT;
+
+
+--
+-- Name: webhook_deliveries webhook_deliveries_webhooks_id_fk; Type: FK CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.webhook_deliveries
+    ADD CONSTRAINT webhook_deliveries_webhooks_id_fk FOREIGN KEY (webhook_id) REFERENCES public.webhooks(id) ON DELETE CASCADE;
+
+
 --
 -- PostgreSQL database dump complete
 --
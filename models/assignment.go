@@ -0,0 +1,170 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gofrs/uuid"
+)
+
+const (
+	// MaxAllocation is the most a member can be allocated at a time, in percent
+	MaxAllocation = 100
+
+	// assignmentsLock is the first key of the advisory lock taken,
+	// with the member, while its allocation is checked
+	assignmentsLock = 7264
+)
+
+// Assignment staffs a member on a project, for a part of its time (allocation, in percent),
+// from the start date to the end date (included) or with no end
+type Assignment struct {
+	ID         uuid.UUID  `json:"id" db:"id"`
+	CreatedAt  time.Time  `json:"-" db:"created_at"`
+	UpdatedAt  time.Time  `json:"-" db:"updated_at"`
+	MemberID   uuid.UUID  `json:"member_id" db:"member_id"`
+	ProjectID  uuid.UUID  `json:"project_id" db:"project_id"`
+	Allocation int        `json:"allocation" db:"allocation" minimum:"1" maximum:"100"`
+	StartDate  time.Time  `json:"start_date" db:"start_date"`
+	EndDate    nulls.Time `json:"end_date" db:"end_date" swaggertype:"string" format:"date-time"`
+	Role       string     `json:"role" db:"role"`
+}
+
+// Assignments is a list of assignments
+type Assignments []Assignment
+
+// Validate the assignment: the member and the project exist, the dates are in order,
+// and the member is never allocated more than 100% with its other assignments
+func (a *Assignment) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	verrs := validate.NewErrors()
+
+	if a.Allocation < 1 || a.Allocation > MaxAllocation {
+		verrs.Add("allocation", "Allocation must be between 1 and 100.")
+	}
+
+	if a.StartDate.IsZero() {
+		verrs.Add("start_date", "Start date can not be blank.")
+	}
+
+	if a.EndDate.Valid && a.EndDate.Time.Before(a.StartDate) {
+		verrs.Add("end_date", "End date must not be before the start date.")
+	}
+
+	exists, err := tx.Where("id = ?", a.MemberID).Exists(&Member{})
+	if err != nil {
+		return verrs, err
+	}
+	if !exists {
+		verrs.Add("member_id", "Member does not exist.")
+	}
+
+	exists, err = tx.Where("id = ?", a.ProjectID).Exists(&Project{})
+	if err != nil {
+		return verrs, err
+	}
+	if !exists {
+		verrs.Add("project_id", "Project does not exist.")
+	}
+
+	if verrs.HasAny() {
+		return verrs, nil
+	}
+
+	// two assignments of the member saved at the same time
+	// must not both pass the check, the lock is held until the transaction ends
+	if err := tx.RawQuery("SELECT pg_advisory_xact_lock(?, hashtext(?))", assignmentsLock, a.MemberID.String()).Exec(); err != nil {
+		return verrs, err
+	}
+
+	peak, err := a.allocationPeak(tx)
+	if err != nil {
+		return verrs, err
+	}
+	if peak > MaxAllocation {
+		verrs.Add("allocation", fmt.Sprintf("Member would be allocated %d%%, more than 100%%.", peak))
+	}
+
+	return verrs, nil
+}
+
+// BeforeSave (create or update), keep only the date of the start and end
+func (a *Assignment) BeforeSave(tx *pop.Connection) error {
+	a.StartDate = truncateDate(a.StartDate)
+	if a.EndDate.Valid {
+		a.EndDate.Time = truncateDate(a.EndDate.Time)
+	}
+	return nil
+}
+
+// activeOn tells if the assignment runs on the day
+func (a Assignment) activeOn(day time.Time) bool {
+	return !day.Before(a.StartDate) && (!a.EndDate.Valid || !day.After(a.EndDate.Time))
+}
+
+// allocationPeak is the highest allocation of the member while the assignment runs,
+// this one included
+func (a *Assignment) allocationPeak(tx *pop.Connection) (int, error) {
+	candidate := *a
+	candidate.StartDate = truncateDate(a.StartDate)
+	if candidate.EndDate.Valid {
+		candidate.EndDate.Time = truncateDate(a.EndDate.Time)
+	}
+
+	others, err := findAssignmentsBetween(tx.Where("member_id = ? AND id <> ?", a.MemberID, a.ID), candidate.StartDate, candidate.EndDate)
+	if err != nil {
+		return 0, err
+	}
+
+	return peakAllocation(append(others, candidate), candidate.StartDate, candidate.EndDate), nil
+}
+
+// findAssignmentsBetween returns the assignments of the query running on a day
+// from start to end (included), or from start on when end is not valid
+func findAssignmentsBetween(q *pop.Query, start time.Time, end nulls.Time) (Assignments, error) {
+	// pop joins the clauses with AND, the OR must not leak out of its own
+	q = q.Where("(end_date IS NULL OR end_date >= ?)", start)
+	if end.Valid {
+		q = q.Where("start_date <= ?", end.Time)
+	}
+
+	assignments := Assignments{}
+	err := q.All(&assignments)
+	return assignments, err
+}
+
+// peakAllocation is the highest total allocation of the assignments on a day
+// from start to end (included), or from start on when end is not valid.
+// The allocation only goes up on the day an assignment starts,
+// so the peak is on the start or on the start of one of the assignments.
+func peakAllocation(assignments Assignments, start time.Time, end nulls.Time) int {
+	days := []time.Time{start}
+	for _, a := range assignments {
+		if a.StartDate.After(start) && (!end.Valid || !a.StartDate.After(end.Time)) {
+			days = append(days, a.StartDate)
+		}
+	}
+
+	peak := 0
+	for _, day := range days {
+		total := 0
+		for _, a := range assignments {
+			if a.activeOn(day) {
+				total += a.Allocation
+			}
+		}
+		if total > peak {
+			peak = total
+		}
+	}
+
+	return peak
+}
+
+// truncateDate drops the time of the day, dates are stored in UTC
+func truncateDate(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
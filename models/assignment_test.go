@@ -0,0 +1,99 @@
+package models
+
+import (
+	"time"
+
+	"github.com/gobuffalo/nulls"
+)
+
+func (ms *ModelSuite) Test_Assignment_Allocation() {
+	ms.LoadFixture("projects")
+
+	developer := &Member{}
+	ms.NoError(DB.Where("name = ?", "Staffed Developer").First(developer))
+	apollo := &Project{}
+	ms.NoError(DB.Where("name = ?", "Apollo").First(apollo))
+
+	date := func(s string) time.Time {
+		t, err := time.Parse("2006-01-02", s)
+		ms.NoError(err)
+		return t
+	}
+
+	// 60% on Apollo and 40% on Gemini from March to June
+	a := &Assignment{MemberID: developer.ID, ProjectID: apollo.ID, Allocation: 10, StartDate: date("2026-04-01"), EndDate: nulls.NewTime(date("2026-04-30"))}
+	verrs, err := DB.ValidateAndCreate(a)
+	ms.NoError(err)
+	ms.Contains(verrs.Get("allocation"), "Member would be allocated 110%, more than 100%.")
+
+	// only 40% on Gemini after June
+	a.StartDate, a.EndDate = date("2026-07-01"), nulls.Time{}
+	a.Allocation = 60
+	verrs, err = DB.ValidateAndCreate(a)
+	ms.NoError(err)
+	ms.Equal(false, verrs.HasAny())
+
+	// 60% on Apollo before March
+	b := &Assignment{MemberID: developer.ID, ProjectID: apollo.ID, Allocation: 40, StartDate: date("2026-01-01"), EndDate: nulls.NewTime(date("2026-02-28"))}
+	verrs, err = DB.ValidateAndCreate(b)
+	ms.NoError(err)
+	ms.Equal(false, verrs.HasAny())
+}
+
+func (ms *ModelSuite) Test_Assignment_Allocation_Members() {
+	ms.LoadFixture("projects")
+
+	developer := &Member{}
+	ms.NoError(DB.Where("name = ?", "Staffed Developer").First(developer))
+	contractor := &Member{}
+	ms.NoError(DB.Where("name = ?", "Free Contractor").First(contractor))
+	apollo := &Project{}
+	ms.NoError(DB.Where("name = ?", "Apollo").First(apollo))
+
+	start, err := time.Parse("2006-01-02", "2026-02-01")
+	ms.NoError(err)
+
+	// the assignments of the developer do not count for the contractor
+	a := &Assignment{MemberID: contractor.ID, ProjectID: apollo.ID, Allocation: 70, StartDate: start}
+	verrs, err := DB.ValidateAndCreate(a)
+	ms.NoError(err)
+	ms.Equal(false, verrs.HasAny())
+
+	// the stored assignment does not count along with its new allocation:
+	// 50% on Apollo and 40% on Gemini, not 60% more
+	stored := &Assignment{}
+	ms.NoError(DB.Where("member_id = ? AND project_id = ?", developer.ID, apollo.ID).First(stored))
+	stored.Allocation = 50
+	verrs, err = DB.ValidateAndUpdate(stored)
+	ms.NoError(err)
+	ms.Equal(false, verrs.HasAny())
+
+	stored.Allocation = 70
+	verrs, err = DB.ValidateAndUpdate(stored)
+	ms.NoError(err)
+	ms.Contains(verrs.Get("allocation"), "Member would be allocated 110%, more than 100%.")
+}
+
+func (ms *ModelSuite) Test_Assignment_Invalid() {
+	ms.LoadFixture("projects")
+
+	developer := &Member{}
+	ms.NoError(DB.Where("name = ?", "Staffed Developer").First(developer))
+	apollo := &Project{}
+	ms.NoError(DB.Where("name = ?", "Apollo").First(apollo))
+
+	now := time.Now()
+	a := &Assignment{MemberID: developer.ID, ProjectID: apollo.ID, Allocation: 120, StartDate: now, EndDate: nulls.NewTime(now.AddDate(0, 0, -1))}
+	verrs, err := DB.ValidateAndCreate(a)
+	ms.NoError(err)
+	ms.Contains(verrs.Get("allocation"), "Allocation must be between 1 and 100.")
+	ms.Contains(verrs.Get("end_date"), "End date must not be before the start date.")
+}
+
+func (ms *ModelSuite) Test_Project_Name() {
+	ms.LoadFixture("projects")
+
+	verrs, err := DB.ValidateAndCreate(&Project{Name: "apollo"})
+	ms.NoError(err)
+	ms.Contains(verrs.Get("name"), "Name is already taken.")
+}
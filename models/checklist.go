@@ -0,0 +1,302 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/slices"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gobuffalo/validate/v3/validators"
+	"github.com/gofrs/uuid"
+)
+
+// Kinds of the checklists: an onboarding checklist is created with the member,
+// an offboarding one when the member moves to offboarding
+const (
+	ChecklistOnboarding  = "onboarding"
+	ChecklistOffboarding = "offboarding"
+)
+
+var checklistKinds = []string{ChecklistOnboarding, ChecklistOffboarding}
+
+// ChecklistTemplate is the list of the things to do when a member of
+// the types listed (all of them when there is none) is onboarded or offboarded
+type ChecklistTemplate struct {
+	ID          uuid.UUID              `json:"id" db:"id"`
+	CreatedAt   time.Time              `json:"-" db:"created_at"`
+	UpdatedAt   time.Time              `json:"-" db:"updated_at"`
+	Name        string                 `json:"name" db:"name"`
+	Kind        string                 `json:"kind" db:"kind" enums:"onboarding,offboarding"`
+	MemberTypes slices.String          `json:"member_types" db:"member_types" swaggertype:"array,string"`
+	Items       ChecklistTemplateItems `json:"items" db:"items"`
+}
+
+// ChecklistTemplates is a list of checklist templates
+type ChecklistTemplates []ChecklistTemplate
+
+// ChecklistTemplateItem is a thing to do, with the member in charge of it by default
+type ChecklistTemplateItem struct {
+	Title   string     `json:"title" xml:"title" example:"Laptop"`
+	OwnerID nulls.UUID `json:"owner_id" xml:"owner_id" swaggertype:"string"`
+}
+
+// ChecklistTemplateItems are the items of a template, saved as a JSON array
+type ChecklistTemplateItems []ChecklistTemplateItem
+
+// Value saves the items as a JSON array
+func (items ChecklistTemplateItems) Value() (driver.Value, error) {
+	if items == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(items)
+	return string(b), err
+}
+
+// Scan reads the items from a JSON array
+func (items *ChecklistTemplateItems) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		return json.Unmarshal(s, items)
+	case string:
+		return json.Unmarshal([]byte(s), items)
+	case nil:
+		*items = ChecklistTemplateItems{}
+		return nil
+	}
+	return fmt.Errorf("checklist template items: cannot scan %T", src)
+}
+
+// Checklist is the list of the things to do to onboard or offboard a member,
+// it is completed once all its items are
+type Checklist struct {
+	ID          uuid.UUID      `json:"id" db:"id"`
+	CreatedAt   time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time      `json:"-" db:"updated_at"`
+	MemberID    uuid.UUID      `json:"member_id" db:"member_id"`
+	TemplateID  nulls.UUID     `json:"template_id" db:"template_id" swaggertype:"string"`
+	Name        string         `json:"name" db:"name"`
+	Kind        string         `json:"kind" db:"kind" enums:"onboarding,offboarding"`
+	CompletedAt nulls.Time     `json:"completed_at" db:"completed_at" swaggertype:"string" format:"date-time"`
+	Items       ChecklistItems `json:"items" has_many:"checklist_items" order_by:"position"`
+}
+
+// Checklists is a list of checklists
+type Checklists []Checklist
+
+// ChecklistItem is a thing to do in a checklist, done once it has a completion time
+type ChecklistItem struct {
+	ID          uuid.UUID  `json:"id" db:"id"`
+	CreatedAt   time.Time  `json:"-" db:"created_at"`
+	UpdatedAt   time.Time  `json:"-" db:"updated_at"`
+	ChecklistID uuid.UUID  `json:"-" db:"checklist_id"`
+	Position    int        `json:"position" db:"position"`
+	Title       string     `json:"title" db:"title"`
+	OwnerID     nulls.UUID `json:"owner_id" db:"owner_id" swaggertype:"string"`
+	CompletedAt nulls.Time `json:"completed_at" db:"completed_at" swaggertype:"string" format:"date-time"`
+}
+
+// ChecklistItems is a list of checklist items
+type ChecklistItems []ChecklistItem
+
+// ChecklistItemChange assigns an item to its owner (none when blank)
+// and marks it done or not
+type ChecklistItemChange struct {
+	OwnerID nulls.UUID `json:"owner_id" xml:"owner_id" swaggertype:"string"`
+	Done    bool       `json:"done" xml:"done"`
+}
+
+// Validate the checklist template
+func (t *ChecklistTemplate) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	verrs := validate.Validate(
+		&validators.StringIsPresent{Name: "Name", Field: t.Name},
+		&validators.StringInclusion{Name: "Kind", Field: t.Kind, List: checklistKinds, Message: "The kind must be onboarding or offboarding"},
+	)
+
+	exists, err := tx.Where("LOWER(name) = LOWER(?) AND id <> ?", strings.TrimSpace(t.Name), t.ID).Exists(&ChecklistTemplate{})
+	if err != nil {
+		return verrs, err
+	}
+	if exists {
+		verrs.Add("name", "Name is already taken.")
+	}
+
+	for _, mt := range t.MemberTypes {
+		if !IsMemberType(mt) {
+			verrs.Add("member_types", mt+" is not a member type.")
+		}
+	}
+
+	if len(t.Items) == 0 {
+		verrs.Add("items", "Items can not be blank.")
+	}
+
+	for i, item := range t.Items {
+		if strings.TrimSpace(item.Title) == "" {
+			verrs.Add("items", fmt.Sprintf("Title of the item %d can not be blank.", i+1))
+		}
+		if err := validateOwner(tx, item.OwnerID, "items", verrs); err != nil {
+			return verrs, err
+		}
+	}
+
+	return verrs, nil
+}
+
+// BeforeSave (create or update), trim the name and the titles
+func (t *ChecklistTemplate) BeforeSave(tx *pop.Connection) error {
+	t.Name = strings.TrimSpace(t.Name)
+	for i := range t.Items {
+		t.Items[i].Title = strings.TrimSpace(t.Items[i].Title)
+	}
+	return nil
+}
+
+// validateOwner checks the owner of an item is a member
+func validateOwner(tx *pop.Connection, ownerID nulls.UUID, key string, verrs *validate.Errors) error {
+	if !ownerID.Valid {
+		return nil
+	}
+
+	exists, err := tx.Where("id = ?", ownerID.UUID).Exists(&Member{})
+	if err != nil {
+		return err
+	}
+	if !exists {
+		verrs.Add(key, "Owner must be a member.")
+	}
+	return nil
+}
+
+// createChecklists creates the checklists of a kind for a member,
+// from the templates of its type
+func createChecklists(tx *pop.Connection, m *Member, kind string) error {
+	templates := ChecklistTemplates{}
+	err := tx.Where("kind = ? AND (COALESCE(cardinality(member_types), 0) = 0 OR ? = ANY(member_types))", kind, m.Type).
+		Order("name").All(&templates)
+	if err != nil {
+		return err
+	}
+
+	// the owners are kept in the items of the templates, with no foreign key:
+	// the members deleted since are left out and the items have no owner
+	ids := []interface{}{}
+	for _, t := range templates {
+		for _, item := range t.Items {
+			if item.OwnerID.Valid {
+				ids = append(ids, item.OwnerID.UUID)
+			}
+		}
+	}
+	owners := map[uuid.UUID]bool{}
+	if len(ids) > 0 {
+		found := []uuid.UUID{}
+		if err := tx.RawQuery("SELECT id FROM members WHERE id IN (?)", ids...).All(&found); err != nil {
+			return err
+		}
+		for _, id := range found {
+			owners[id] = true
+		}
+	}
+
+	for _, t := range templates {
+		checklist := &Checklist{MemberID: m.ID, TemplateID: nulls.NewUUID(t.ID), Name: t.Name, Kind: kind}
+		if err := tx.Create(checklist); err != nil {
+			return err
+		}
+
+		for i, item := range t.Items {
+			owner := item.OwnerID
+			if owner.Valid && !owners[owner.UUID] {
+				owner = nulls.UUID{}
+			}
+			if err := tx.Create(&ChecklistItem{ChecklistID: checklist.ID, Position: i + 1, Title: item.Title, OwnerID: owner}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// moveTemplateOwner gives the items of the templates owned by a member to another one
+func moveTemplateOwner(tx *pop.Connection, from, to uuid.UUID) error {
+	owned, err := json.Marshal([]map[string]uuid.UUID{{"owner_id": from}})
+	if err != nil {
+		return err
+	}
+
+	templates := ChecklistTemplates{}
+	if err := tx.Where("items @> ?::jsonb", string(owned)).All(&templates); err != nil {
+		return err
+	}
+
+	for i := range templates {
+		t := &templates[i]
+		for j := range t.Items {
+			if t.Items[j].OwnerID.Valid && t.Items[j].OwnerID.UUID == from {
+				t.Items[j].OwnerID = nulls.NewUUID(to)
+			}
+		}
+		if err := tx.Update(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FindChecklists returns the checklists of a member with their items, the oldest first
+func FindChecklists(tx *pop.Connection, memberID uuid.UUID) (Checklists, error) {
+	checklists := Checklists{}
+	err := tx.Eager("Items").Where("member_id = ?", memberID).Order("created_at, name").All(&checklists)
+	return checklists, err
+}
+
+// UpdateItem applies the change to an item of the checklist, the checklist is
+// completed when all its items are done, and open again when one is not
+func (c *Checklist) UpdateItem(tx *pop.Connection, itemID uuid.UUID, change ChecklistItemChange) (*validate.Errors, error) {
+	verrs := validate.NewErrors()
+
+	var item *ChecklistItem
+	for i := range c.Items {
+		if c.Items[i].ID == itemID {
+			item = &c.Items[i]
+		}
+	}
+	if item == nil {
+		verrs.Add("item_id", "Item is not in the checklist.")
+		return verrs, nil
+	}
+
+	if err := validateOwner(tx, change.OwnerID, "owner_id", verrs); err != nil || verrs.HasAny() {
+		return verrs, err
+	}
+
+	item.OwnerID = change.OwnerID
+	switch {
+	case change.Done && !item.CompletedAt.Valid:
+		item.CompletedAt = nulls.NewTime(time.Now())
+	case !change.Done:
+		item.CompletedAt = nulls.Time{}
+	}
+	if err := tx.Update(item); err != nil {
+		return verrs, err
+	}
+
+	completed := true
+	for _, i := range c.Items {
+		completed = completed && i.CompletedAt.Valid
+	}
+	switch {
+	case completed && !c.CompletedAt.Valid:
+		c.CompletedAt = nulls.NewTime(time.Now())
+	case !completed:
+		c.CompletedAt = nulls.Time{}
+	}
+
+	return verrs, tx.Update(c)
+}
@@ -0,0 +1,108 @@
+package models
+
+import (
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/slices"
+)
+
+func (ms *ModelSuite) createChecklistTemplates() {
+	ms.NoError(DB.Create(&ChecklistTemplate{Name: "Onboarding", Kind: ChecklistOnboarding, Items: ChecklistTemplateItems{{Title: "Laptop"}, {Title: "Accounts"}}}))
+	ms.NoError(DB.Create(&ChecklistTemplate{Name: "Contract signed", Kind: ChecklistOnboarding, MemberTypes: slices.String{"contractor"}, Items: ChecklistTemplateItems{{Title: "Contract signed"}}}))
+	ms.NoError(DB.Create(&ChecklistTemplate{Name: "Offboarding", Kind: ChecklistOffboarding, Items: ChecklistTemplateItems{{Title: "Return the laptop"}}}))
+}
+
+func (ms *ModelSuite) Test_ChecklistTemplate_Validate() {
+	tests := []struct {
+		template ChecklistTemplate
+		key      string
+	}{
+		{ChecklistTemplate{Kind: ChecklistOnboarding, Items: ChecklistTemplateItems{{Title: "Laptop"}}}, "name"},
+		{ChecklistTemplate{Name: "Onboarding", Kind: "hiring", Items: ChecklistTemplateItems{{Title: "Laptop"}}}, "kind"},
+		{ChecklistTemplate{Name: "Onboarding", Kind: ChecklistOnboarding}, "items"},
+		{ChecklistTemplate{Name: "Onboarding", Kind: ChecklistOnboarding, Items: ChecklistTemplateItems{{Title: " "}}}, "items"},
+		{ChecklistTemplate{Name: "Onboarding", Kind: ChecklistOnboarding, MemberTypes: slices.String{"robot"}, Items: ChecklistTemplateItems{{Title: "Laptop"}}}, "member_types"},
+	}
+
+	for _, tt := range tests {
+		verrs, err := tt.template.Validate(DB)
+		ms.NoError(err)
+		ms.NotEmpty(verrs.Get(tt.key), tt.template.Name)
+	}
+}
+
+func (ms *ModelSuite) Test_Member_Checklists() {
+	ms.createChecklistTemplates()
+
+	m := &Member{Name: "Member Name", Type: "employee", Role: "DevOps"}
+	ms.NoError(DB.Create(m))
+
+	checklists, err := FindChecklists(DB, m.ID)
+	ms.NoError(err)
+	ms.Equal(1, len(checklists))
+	ms.Equal("Onboarding", checklists[0].Name)
+	ms.Equal(2, len(checklists[0].Items))
+	ms.Equal("Laptop", checklists[0].Items[0].Title)
+
+	verrs, err := m.Transition(DB, StatusChange{Status: StatusOffboarding})
+	ms.NoError(err)
+	ms.False(verrs.HasAny())
+
+	checklists, err = FindChecklists(DB, m.ID)
+	ms.NoError(err)
+	ms.Equal(2, len(checklists))
+	ms.Equal(ChecklistOffboarding, checklists[1].Kind)
+}
+
+func (ms *ModelSuite) Test_Member_Checklists_DeletedOwner() {
+	owner := &Member{Name: "Owner Name", Type: "employee", Role: "DevOps"}
+	ms.NoError(DB.Create(owner))
+	ms.NoError(DB.Create(&ChecklistTemplate{Name: "Onboarding", Kind: ChecklistOnboarding, Items: ChecklistTemplateItems{{Title: "Laptop", OwnerID: nulls.NewUUID(owner.ID)}}}))
+	ms.NoError(DB.Destroy(owner))
+
+	// the template still names the owner deleted, the item is left without owner
+	m := &Member{Name: "Member Name", Type: "employee", Role: "DevOps"}
+	ms.NoError(DB.Create(m))
+
+	checklists, err := FindChecklists(DB, m.ID)
+	ms.NoError(err)
+	ms.Equal(1, len(checklists))
+	ms.False(checklists[0].Items[0].OwnerID.Valid)
+}
+
+func (ms *ModelSuite) Test_Checklist_UpdateItem() {
+	ms.createChecklistTemplates()
+
+	owner := &Member{Name: "IT Person", Type: "employee", Role: "IT"}
+	ms.NoError(DB.Create(owner))
+	m := &Member{Name: "Contractor", Type: "contractor", ContractStart: nulls.NewTime(time.Now()), ContractEnd: nulls.NewTime(time.Now().AddDate(0, 6, 0))}
+	ms.NoError(DB.Create(m))
+
+	checklists, err := FindChecklists(DB, m.ID)
+	ms.NoError(err)
+	ms.Equal(2, len(checklists))
+
+	c := checklists[1]
+	ms.Equal("Onboarding", c.Name)
+
+	verrs, err := c.UpdateItem(DB, c.Items[0].ID, ChecklistItemChange{OwnerID: nulls.NewUUID(owner.ID), Done: true})
+	ms.NoError(err)
+	ms.False(verrs.HasAny())
+	ms.False(c.CompletedAt.Valid)
+	ms.Equal(nulls.NewUUID(owner.ID), c.Items[0].OwnerID)
+
+	verrs, err = c.UpdateItem(DB, c.Items[1].ID, ChecklistItemChange{Done: true})
+	ms.NoError(err)
+	ms.False(verrs.HasAny())
+	ms.True(c.CompletedAt.Valid)
+
+	verrs, err = c.UpdateItem(DB, c.Items[1].ID, ChecklistItemChange{Done: false})
+	ms.NoError(err)
+	ms.False(verrs.HasAny())
+	ms.False(c.CompletedAt.Valid)
+
+	verrs, err = c.UpdateItem(DB, c.Items[1].ID, ChecklistItemChange{OwnerID: nulls.NewUUID(c.ID)})
+	ms.NoError(err)
+	ms.NotEmpty(verrs.Get("owner_id"))
+}
@@ -0,0 +1,209 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gofrs/uuid"
+)
+
+// Kinds of the contracts in the history of a contractor
+const (
+	ContractInitial   = "initial"
+	ContractExtension = "extension"
+	ContractRenewal   = "renewal"
+	ContractAmendment = "amendment"
+)
+
+// Contract is a period of the contract of a contractor, its history keeps every one:
+// the initial contract, the extensions and renewals and the periods changed by an update.
+// The current contract is the contract_start and contract_end of the member.
+type Contract struct {
+	ID         uuid.UUID    `json:"id" db:"id"`
+	CreatedAt  time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time    `json:"-" db:"updated_at"`
+	MemberID   uuid.UUID    `json:"member_id" db:"member_id"`
+	Kind       string       `json:"kind" db:"kind" enums:"initial,extension,renewal,amendment"`
+	StartDate  time.Time    `json:"start_date" db:"start_date"`
+	EndDate    time.Time    `json:"end_date" db:"end_date"`
+	ApprovedBy string       `json:"approved_by" db:"approved_by"`
+	Note       nulls.String `json:"note" db:"note" swaggertype:"string"`
+}
+
+// Contracts is a list of contracts
+type Contracts []Contract
+
+// ContractChange extends the contract of a contractor to a new end, or renews it
+// from a new start after the current end. Someone has to approve it.
+type ContractChange struct {
+	ContractStart nulls.Time `json:"contract_start" xml:"contract_start" swaggertype:"string" format:"date-time"`
+	ContractEnd   time.Time  `json:"contract_end" xml:"contract_end"`
+	ApprovedBy    string     `json:"approved_by" xml:"approved_by"`
+	Note          string     `json:"note" xml:"note"`
+}
+
+// validateContractPeriod checks the contract end is after the start,
+// the types of member tell when they are required
+func validateContractPeriod(m *Member, verrs *validate.Errors) {
+	if m.ContractStart.Valid && m.ContractEnd.Valid && !truncateDate(m.ContractEnd.Time).After(truncateDate(m.ContractStart.Time)) {
+		verrs.Add("contract_end", "Contract end must be after the contract start.")
+	}
+}
+
+// setContractDuration derives the contract duration from the contract period,
+// it is blank without a complete period
+func (m *Member) setContractDuration() {
+	m.ContractDuration = ""
+	if m.ContractStart.Valid && m.ContractEnd.Valid {
+		m.ContractDuration = isoPeriod(m.ContractStart.Time, m.ContractEnd.Time)
+	}
+}
+
+// isoPeriod is the ISO 8601 duration, in years, months and days,
+// from a date to a later one: from 2026-01-15 to 2027-03-20 is P1Y2M5D.
+// A month from the end of a month ends at the end of a shorter one:
+// from 2026-01-31 to 2026-03-01 is P1M1D, a month to 2026-02-28 and a day.
+func isoPeriod(from, to time.Time) string {
+	from, to = truncateDate(from), truncateDate(to)
+	if to.Before(from) {
+		return "P0D"
+	}
+
+	// the most months from the start not going past the end, then the days left
+	months := (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month())
+	anchor := addMonths(from, months)
+	if anchor.After(to) {
+		months--
+		anchor = addMonths(from, months)
+	}
+	years, months := months/12, months%12
+	days := int(to.Sub(anchor).Hours() / 24)
+
+	var b strings.Builder
+	b.WriteString("P")
+	if years > 0 {
+		fmt.Fprintf(&b, "%dY", years)
+	}
+	if months > 0 {
+		fmt.Fprintf(&b, "%dM", months)
+	}
+	if days > 0 || b.Len() == 1 {
+		fmt.Fprintf(&b, "%dD", days)
+	}
+
+	return b.String()
+}
+
+// addMonths adds months to a date, the day is the last of the month when it is too short
+func addMonths(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1).Day()
+	day := t.Day()
+	if day > last {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+// ExtendContract applies the change to the contract of a member and saves it.
+// An extension adds the days from the current end to the new end, a renewal
+// (with a contract start) starts a new period after the current end.
+func (m *Member) ExtendContract(tx *pop.Connection, change ContractChange) (*validate.Errors, error) {
+	verrs := validate.NewErrors()
+
+	if !m.ContractEnd.Valid {
+		verrs.Add("type", "Only a member with a contract end can have its contract extended.")
+		return verrs, nil
+	}
+
+	if strings.TrimSpace(change.ApprovedBy) == "" {
+		verrs.Add("approved_by", "Approved by can not be blank.")
+	}
+
+	current := truncateDate(m.ContractEnd.Time)
+	contract := &Contract{
+		Kind:       ContractExtension,
+		StartDate:  current.AddDate(0, 0, 1),
+		EndDate:    truncateDate(change.ContractEnd),
+		ApprovedBy: strings.TrimSpace(change.ApprovedBy),
+	}
+	if note := strings.TrimSpace(change.Note); note != "" {
+		contract.Note = nulls.NewString(note)
+	}
+
+	if change.ContractStart.Valid {
+		contract.Kind = ContractRenewal
+		contract.StartDate = truncateDate(change.ContractStart.Time)
+		if !contract.StartDate.After(current) {
+			verrs.Add("contract_start", "Contract start must be after the current contract end.")
+		}
+	}
+
+	if !contract.EndDate.After(current) || contract.EndDate.Before(contract.StartDate) {
+		verrs.Add("contract_end", "Contract end must be after the current contract end and the contract start.")
+	}
+
+	if verrs.HasAny() {
+		return verrs, nil
+	}
+
+	if contract.Kind == ContractRenewal {
+		m.ContractStart = nulls.NewTime(contract.StartDate)
+	}
+	m.ContractEnd = nulls.NewTime(contract.EndDate)
+
+	m.contractChange = contract
+	defer func() { m.contractChange = nil }()
+
+	return tx.ValidateAndUpdate(m)
+}
+
+// FindContracts returns the contract history of a member, the oldest first
+func FindContracts(tx *pop.Connection, memberID uuid.UUID) (Contracts, error) {
+	contracts := Contracts{}
+	err := tx.Where("member_id = ?", memberID).Order("start_date, created_at").All(&contracts)
+	return contracts, err
+}
+
+// recordContract adds the contract of a member to its history when it changed:
+// the contract prepared by ExtendContract, or else its contract period
+// (the initial contract, or an amendment when it has a history already)
+func recordContract(tx *pop.Connection, m *Member) error {
+	contract := m.contractChange
+	m.contractChange = nil
+
+	if contract == nil {
+		if !m.ContractStart.Valid || !m.ContractEnd.Valid {
+			return nil
+		}
+
+		if m.stored != nil && sameDate(m.stored.ContractStart, m.ContractStart) && sameDate(m.stored.ContractEnd, m.ContractEnd) {
+			return nil
+		}
+
+		recorded, err := tx.Where("member_id = ?", m.ID).Exists(&Contract{})
+		if err != nil {
+			return err
+		}
+
+		contract = &Contract{Kind: ContractInitial, StartDate: m.ContractStart.Time, EndDate: m.ContractEnd.Time}
+		if recorded {
+			contract.Kind = ContractAmendment
+		}
+	}
+
+	contract.MemberID = m.ID
+	return tx.Create(contract)
+}
+
+// sameDate tells if both dates are blank or the same day
+func sameDate(a, b nulls.Time) bool {
+	if !a.Valid || !b.Valid {
+		return a.Valid == b.Valid
+	}
+	return truncateDate(a.Time).Equal(truncateDate(b.Time))
+}
@@ -0,0 +1,120 @@
+package models
+
+import (
+	"time"
+
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gofrs/uuid"
+)
+
+// ContractAlert records that a contract.expiring or contract.expired event
+// was sent for the end of a contract: each is sent once per contract end,
+// a contract extended is alerted again
+type ContractAlert struct {
+	ID          uuid.UUID `json:"id" db:"id"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"-" db:"updated_at"`
+	MemberID    uuid.UUID `json:"member_id" db:"member_id"`
+	Type        string    `json:"type" db:"type" enums:"contract.expiring,contract.expired"`
+	ContractEnd time.Time `json:"contract_end" db:"contract_end"`
+	EventID     int64     `json:"event_id" db:"event_id"`
+}
+
+// ContractAlerts is a list of contract alerts
+type ContractAlerts []ContractAlert
+
+// ExpiringContract is a member whose contract ends soon
+type ExpiringContract struct {
+	Member   Member `json:"member" xml:"member"`
+	DaysLeft int    `json:"days_left" xml:"days_left"`
+}
+
+// ExpiringContracts is a list of expiring contracts, the first to end first
+type ExpiringContracts []ExpiringContract
+
+// FindExpiringContracts returns the contracts ending from today
+// to within days after it, both included
+func FindExpiringContracts(tx *pop.Connection, today time.Time, within int) (ExpiringContracts, error) {
+	today = truncateDate(today)
+
+	members := Members{}
+	err := tx.Where("contract_end >= ? AND contract_end <= ?", today, today.AddDate(0, 0, within)).
+		Order("contract_end, name").All(&members)
+	if err != nil {
+		return nil, err
+	}
+
+	contracts := make(ExpiringContracts, len(members))
+	for i, m := range members {
+		contracts[i] = ExpiringContract{Member: m, DaysLeft: daysBetween(today, m.ContractEnd.Time)}
+	}
+
+	return contracts, nil
+}
+
+// RecordContractAlerts records a contract.expiring event for each contract ending
+// within days from today, and a contract.expired event for each contract that ended
+// in the last within days. The events not alerted yet are recorded, it returns how many.
+// Older contracts are never alerted, so the first run does not go through all the past ones.
+func RecordContractAlerts(tx *pop.Connection, today time.Time, within int) (int, error) {
+	today = truncateDate(today)
+
+	expiring, err := contractsNotAlerted(tx, ContractExpiring, today, today.AddDate(0, 0, within))
+	if err != nil {
+		return 0, err
+	}
+
+	expired, err := contractsNotAlerted(tx, ContractExpired, today.AddDate(0, 0, -within), today.AddDate(0, 0, -1))
+	if err != nil {
+		return 0, err
+	}
+
+	alerts := 0
+	for _, list := range []struct {
+		eventType string
+		members   Members
+	}{{ContractExpiring, expiring}, {ContractExpired, expired}} {
+		for i := range list.members {
+			m := &list.members[i]
+			details := map[string]interface{}{
+				"contract_end": m.ContractEnd.Time.Format("2006-01-02"),
+				"days_left":    daysBetween(today, m.ContractEnd.Time),
+			}
+
+			if err := recordMemberEvent(tx, m, list.eventType, details); err != nil {
+				return alerts, err
+			}
+
+			eventID, err := LastMemberEventID(tx)
+			if err != nil {
+				return alerts, err
+			}
+
+			alert := &ContractAlert{MemberID: m.ID, Type: list.eventType, ContractEnd: m.ContractEnd.Time, EventID: eventID}
+			if err := tx.Create(alert); err != nil {
+				return alerts, err
+			}
+			alerts++
+		}
+	}
+
+	return alerts, nil
+}
+
+// contractsNotAlerted returns the members whose contract ends from one day
+// to another, both included, without an alert of the type for that end
+func contractsNotAlerted(tx *pop.Connection, alertType string, from, to time.Time) (Members, error) {
+	members := Members{}
+	err := tx.Where("contract_end >= ? AND contract_end <= ?", from, to).
+		Where(`NOT EXISTS (SELECT 1 FROM contract_alerts
+			WHERE contract_alerts.member_id = members.id
+			AND contract_alerts.type = ?
+			AND contract_alerts.contract_end = members.contract_end)`, alertType).
+		Order("contract_end, name").All(&members)
+	return members, err
+}
+
+// daysBetween is the number of days from a date to another, negative if it is before
+func daysBetween(from, to time.Time) int {
+	return int(truncateDate(to).Sub(truncateDate(from)).Hours() / 24)
+}
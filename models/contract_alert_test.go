@@ -0,0 +1,71 @@
+package models
+
+import (
+	"time"
+
+	"github.com/gobuffalo/nulls"
+)
+
+// createContractor creates a contractor whose contract ends days after today
+func (ms *ModelSuite) createContractor(name string, today time.Time, days int) *Member {
+	m := &Member{
+		Name:          name,
+		Type:          "contractor",
+		ContractStart: nulls.NewTime(today.AddDate(-1, 0, 0)),
+		ContractEnd:   nulls.NewTime(today.AddDate(0, 0, days)),
+	}
+
+	verrs, err := DB.ValidateAndCreate(m)
+	ms.NoError(err)
+	ms.Equal(false, verrs.HasAny())
+	return m
+}
+
+func (ms *ModelSuite) Test_FindExpiringContracts() {
+	today := truncateDate(time.Now())
+	ms.createContractor("Ends Soon", today, 10)
+	ms.createContractor("Ends Today", today, 0)
+	ms.createContractor("Ends Later", today, 60)
+	ms.createContractor("Ended", today, -5)
+
+	contracts, err := FindExpiringContracts(DB, today, 30)
+	ms.NoError(err)
+	ms.Equal(2, len(contracts))
+	ms.Equal("Ends Today", contracts[0].Member.Name)
+	ms.Equal(0, contracts[0].DaysLeft)
+	ms.Equal("Ends Soon", contracts[1].Member.Name)
+	ms.Equal(10, contracts[1].DaysLeft)
+}
+
+func (ms *ModelSuite) Test_RecordContractAlerts() {
+	today := truncateDate(time.Now())
+	soon := ms.createContractor("Ends Soon", today, 10)
+	ms.createContractor("Ends Later", today, 60)
+	ended := ms.createContractor("Ended", today, -5)
+	ms.createContractor("Ended Long Ago", today, -90)
+
+	alerts, err := RecordContractAlerts(DB, today, 30)
+	ms.NoError(err)
+	ms.Equal(2, alerts)
+
+	e := &MemberEvent{}
+	ms.NoError(DB.Where("member_id = ? AND type = ?", soon.ID, ContractExpiring).First(e))
+	ms.JSONEq(`{"contract_end":"`+soon.ContractEnd.Time.Format("2006-01-02")+`","days_left":10}`, string(e.Details))
+	ms.NoError(DB.Where("member_id = ? AND type = ?", ended.ID, ContractExpired).First(e))
+
+	// every contract was alerted
+	alerts, err = RecordContractAlerts(DB, today, 30)
+	ms.NoError(err)
+	ms.Equal(0, alerts)
+
+	// the contract extended ends soon again
+	soon.ContractEnd = nulls.NewTime(today.AddDate(0, 0, 20))
+	ms.NoError(DB.Update(soon))
+	alerts, err = RecordContractAlerts(DB, today, 30)
+	ms.NoError(err)
+	ms.Equal(1, alerts)
+
+	count, err := DB.Where("member_id = ?", soon.ID).Count(&ContractAlert{})
+	ms.NoError(err)
+	ms.Equal(2, count)
+}
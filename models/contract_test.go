@@ -0,0 +1,93 @@
+package models
+
+import (
+	"time"
+
+	"github.com/gobuffalo/nulls"
+)
+
+func (ms *ModelSuite) Test_isoPeriod() {
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	ms.Equal("P1Y2M5D", isoPeriod(date(2026, 1, 15), date(2027, 3, 20)))
+	ms.Equal("P6M", isoPeriod(date(2026, 1, 15), date(2026, 7, 15)))
+	ms.Equal("P29D", isoPeriod(date(2026, 11, 1), date(2026, 11, 30)))
+	ms.Equal("P1M3D", isoPeriod(date(2026, 1, 31), date(2026, 2, 28).AddDate(0, 0, 3)))
+	ms.Equal("P1M28D", isoPeriod(date(2026, 1, 31), date(2026, 3, 28)))
+
+	// from the end of a month
+	ms.Equal("P1M", isoPeriod(date(2026, 1, 31), date(2026, 2, 28)))
+	ms.Equal("P1M1D", isoPeriod(date(2026, 1, 31), date(2026, 3, 1)))
+	ms.Equal("P1M1D", isoPeriod(date(2026, 1, 30), date(2026, 3, 1)))
+	ms.Equal("P2M", isoPeriod(date(2026, 1, 31), date(2026, 3, 31)))
+	ms.Equal("P25D", isoPeriod(date(2026, 1, 31), date(2026, 2, 28).AddDate(0, 0, -3)))
+	ms.Equal("P1Y", isoPeriod(date(2028, 2, 29), date(2029, 2, 28)))
+	ms.Equal("P11M30D", isoPeriod(date(2026, 3, 31), date(2027, 3, 30)))
+	ms.Equal("P0D", isoPeriod(date(2026, 1, 1), date(2026, 1, 1)))
+}
+
+func (ms *ModelSuite) Test_Member_ContractHistory() {
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	m := &Member{Name: "Contractor", Type: "contractor", ContractStart: nulls.NewTime(date(2026, 1, 1)), ContractEnd: nulls.NewTime(date(2026, 6, 30))}
+	verrs, err := DB.ValidateAndCreate(m)
+	ms.NoError(err)
+	ms.False(verrs.HasAny())
+
+	// an update leaving the contract alone is not recorded
+	m.Name = "Renamed Contractor"
+	verrs, err = DB.ValidateAndUpdate(m)
+	ms.NoError(err)
+	ms.False(verrs.HasAny())
+
+	verrs, err = m.ExtendContract(DB, ContractChange{ContractEnd: date(2026, 9, 30), ApprovedBy: "Jane Manager", Note: "Phase 2"})
+	ms.NoError(err)
+	ms.False(verrs.HasAny())
+	ms.Equal(date(2026, 9, 30), m.ContractEnd.Time)
+
+	verrs, err = m.ExtendContract(DB, ContractChange{ContractStart: nulls.NewTime(date(2027, 1, 1)), ContractEnd: date(2027, 12, 31), ApprovedBy: "Jane Manager"})
+	ms.NoError(err)
+	ms.False(verrs.HasAny())
+	ms.Equal(date(2027, 1, 1), m.ContractStart.Time)
+
+	m.ContractEnd = nulls.NewTime(date(2027, 11, 30))
+	verrs, err = DB.ValidateAndUpdate(m)
+	ms.NoError(err)
+	ms.False(verrs.HasAny())
+
+	contracts, err := FindContracts(DB, m.ID)
+	ms.NoError(err)
+	ms.Equal(4, len(contracts))
+	ms.Equal(ContractInitial, contracts[0].Kind)
+	ms.Equal(ContractExtension, contracts[1].Kind)
+	ms.Equal(date(2026, 7, 1), contracts[1].StartDate)
+	ms.Equal("Jane Manager", contracts[1].ApprovedBy)
+	ms.Equal("Phase 2", contracts[1].Note.String)
+	ms.Equal(ContractRenewal, contracts[2].Kind)
+	ms.Equal(ContractAmendment, contracts[3].Kind)
+}
+
+func (ms *ModelSuite) Test_Member_ExtendContract_Invalid() {
+	m := &Member{Name: "Contractor", Type: "contractor", ContractStart: nulls.NewTime(time.Now()), ContractEnd: nulls.NewTime(time.Now().AddDate(0, 6, 0))}
+	verrs, err := DB.ValidateAndCreate(m)
+	ms.NoError(err)
+	ms.False(verrs.HasAny())
+
+	verrs, err = m.ExtendContract(DB, ContractChange{ContractEnd: time.Now()})
+	ms.NoError(err)
+	ms.Contains(verrs.Get("approved_by"), "Approved by can not be blank.")
+	ms.Contains(verrs.Get("contract_end"), "Contract end must be after the current contract end and the contract start.")
+
+	e := &Member{Name: "Employee", Type: "employee", Role: "Developer"}
+	verrs, err = DB.ValidateAndCreate(e)
+	ms.NoError(err)
+	ms.False(verrs.HasAny())
+
+	verrs, err = e.ExtendContract(DB, ContractChange{ContractEnd: time.Now(), ApprovedBy: "Jane Manager"})
+	ms.NoError(err)
+	ms.Contains(verrs.Get("type"), "Only a member with a contract end can have its contract extended.")
+}
@@ -0,0 +1,327 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/slices"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gobuffalo/validate/v3/validators"
+	"github.com/gofrs/uuid"
+)
+
+// Types of the custom fields
+const (
+	CustomFieldString = "string"
+	CustomFieldNumber = "number"
+	CustomFieldDate   = "date"
+	CustomFieldEnum   = "enum"
+)
+
+var (
+	customFieldTypes = []string{CustomFieldString, CustomFieldNumber, CustomFieldDate, CustomFieldEnum}
+	customFieldName  = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+)
+
+// CustomField is an attribute admins add to the members, like a GitHub handle
+// or a T-shirt size. It applies to the member types listed, or to all of them.
+// The values are kept in the custom_fields of the members, by the field name.
+type CustomField struct {
+	ID          uuid.UUID     `json:"id" db:"id"`
+	CreatedAt   time.Time     `json:"-" db:"created_at"`
+	UpdatedAt   time.Time     `json:"-" db:"updated_at"`
+	Name        string        `json:"name" db:"name" example:"tshirt_size"`
+	Description nulls.String  `json:"description" db:"description" swaggertype:"string"`
+	Type        string        `json:"type" db:"type" enums:"string,number,date,enum"`
+	Options     slices.String `json:"options,omitempty" db:"options" swaggertype:"array,string"`
+	Required    bool          `json:"required" db:"required"`
+	MemberTypes slices.String `json:"member_types" db:"member_types" swaggertype:"array,string"`
+}
+
+// CustomFields is a list of custom fields
+type CustomFields []CustomField
+
+// CustomValues are the values of the custom fields of a member, by field name,
+// saved as a JSON object
+type CustomValues map[string]interface{}
+
+// Value saves the values as a JSON object
+func (v CustomValues) Value() (driver.Value, error) {
+	if v == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(v)
+	return string(b), err
+}
+
+// Scan reads the values from a JSON object
+func (v *CustomValues) Scan(src interface{}) error {
+	var b []byte
+	switch s := src.(type) {
+	case []byte:
+		b = s
+	case string:
+		b = []byte(s)
+	case nil:
+		*v = CustomValues{}
+		return nil
+	default:
+		return fmt.Errorf("custom values: cannot scan %T", src)
+	}
+	return json.Unmarshal(b, v)
+}
+
+// MarshalXML writes the values as elements named after their field, in name order
+func (v CustomValues) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(v))
+	for name := range v {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := e.EncodeElement(fmt.Sprint(v[name]), xml.StartElement{Name: xml.Name{Local: name}}); err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+// UnmarshalXML reads the values from elements named after their field,
+// the values are text, see validateCustomFields for the numbers
+func (v *CustomValues) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	*v = CustomValues{}
+	for {
+		t, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch el := t.(type) {
+		case xml.StartElement:
+			var value string
+			if err := d.DecodeElement(&value, &el); err != nil {
+				return err
+			}
+			(*v)[el.Name.Local] = value
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// Validate the custom field
+func (f *CustomField) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	verrs := validate.Validate(
+		&validators.StringInclusion{Name: "Type", Field: f.Type, List: customFieldTypes, Message: "The type must be one of string, number, date, enum"},
+	)
+
+	if !customFieldName.MatchString(f.Name) {
+		verrs.Add("name", "Name must be lower case letters, digits and underscores, starting with a letter.")
+	}
+
+	if f.Type == CustomFieldEnum && len(f.Options) == 0 {
+		verrs.Add("options", "Options can not be blank for an enum.")
+	}
+	if f.Type != CustomFieldEnum && len(f.Options) > 0 {
+		verrs.Add("options", "Options are only for an enum.")
+	}
+
+	for _, t := range f.MemberTypes {
+		if _, ok := findMemberType(t); !ok {
+			verrs.Add("member_types", t+" is not a member type.")
+		}
+	}
+
+	exists, err := tx.Where("name = ? AND id <> ?", f.Name, f.ID).Exists(&CustomField{})
+	if err != nil {
+		return verrs, err
+	}
+	if exists {
+		verrs.Add("name", "Name is already taken.")
+	}
+
+	// the values of the members are kept by name
+	if f.ID != uuid.Nil {
+		stored := &CustomField{}
+		if err := tx.Select("name").Find(stored, f.ID); err != nil {
+			return verrs, err
+		}
+		if stored.Name != f.Name {
+			verrs.Add("name", "Name can not be changed.")
+		}
+	}
+
+	return verrs, nil
+}
+
+// BeforeSave (create or update), trim the options
+func (f *CustomField) BeforeSave(tx *pop.Connection) error {
+	for i, o := range f.Options {
+		f.Options[i] = strings.TrimSpace(o)
+	}
+	return nil
+}
+
+// AfterDestroy removes the values of the field from the members
+func (f *CustomField) AfterDestroy(tx *pop.Connection) error {
+	return tx.RawQuery("UPDATE members SET custom_fields = custom_fields - ? WHERE custom_fields <> custom_fields - ?", f.Name, f.Name).Exec()
+}
+
+// appliesTo tells if the field applies to the members of a type
+func (f CustomField) appliesTo(memberType string) bool {
+	return len(f.MemberTypes) == 0 || contains(f.MemberTypes, memberType)
+}
+
+// DiscardCustomFields removes the values of the custom fields that do not apply
+// to the type of the member, for a change of type. The values of unknown fields
+// are left to the validation.
+func (m *Member) DiscardCustomFields(tx *pop.Connection) error {
+	fields := CustomFields{}
+	if err := tx.All(&fields); err != nil {
+		return err
+	}
+
+	for _, f := range fields {
+		if !f.appliesTo(m.Type) {
+			delete(m.CustomFields, f.Name)
+		}
+	}
+	return nil
+}
+
+// validateCustomFields checks the custom values of a member against the
+// custom fields of its type: the required ones are set, the values have the type
+// of their field, and there is no value for another field
+func validateCustomFields(tx *pop.Connection, m *Member, verrs *validate.Errors) error {
+	fields := CustomFields{}
+	if err := tx.All(&fields); err != nil {
+		return err
+	}
+
+	known := []string{}
+	for _, f := range fields {
+		if !f.appliesTo(m.Type) {
+			continue
+		}
+		known = append(known, f.Name)
+
+		key := "custom_fields." + f.Name
+		v, ok := m.CustomFields[f.Name]
+		if !ok || v == nil || v == "" {
+			if f.Required {
+				verrs.Add(key, f.Name+" can not be blank.")
+			}
+			continue
+		}
+
+		// the numbers read from XML are text
+		if s, ok := v.(string); ok && f.Type == CustomFieldNumber {
+			if n, err := strconv.ParseFloat(s, 64); err == nil {
+				v = n
+				m.CustomFields[f.Name] = n
+			}
+		}
+
+		if msg := f.checkValue(v); msg != "" {
+			verrs.Add(key, f.Name+" "+msg)
+		}
+	}
+
+	for name := range m.CustomFields {
+		if !contains(known, name) {
+			verrs.Add("custom_fields."+name, fmt.Sprintf("%s is not a custom field of the type %s.", name, m.Type))
+		}
+	}
+
+	return nil
+}
+
+// checkValue returns why the value does not fit the field, blank if it fits
+func (f CustomField) checkValue(v interface{}) string {
+	switch f.Type {
+	case CustomFieldNumber:
+		if _, ok := v.(float64); !ok {
+			return "must be a number."
+		}
+	case CustomFieldDate:
+		s, ok := v.(string)
+		if _, err := time.Parse("2006-01-02", s); !ok || err != nil {
+			return "must be a date (YYYY-MM-DD)."
+		}
+	case CustomFieldEnum:
+		s, ok := v.(string)
+		if !ok || !contains(f.Options, s) {
+			return "must be one of " + strings.Join(f.Options, ", ") + "."
+		}
+	default:
+		if _, ok := v.(string); !ok {
+			return "must be a string."
+		}
+	}
+	return ""
+}
+
+// CustomFieldsSchema describes the custom_fields of the members of a type
+// as a JSON Schema, of all the types when the type is blank
+// (the fields required by some types only are not required then)
+func CustomFieldsSchema(tx *pop.Connection, memberType string) (map[string]interface{}, error) {
+	fields := CustomFields{}
+	if err := tx.Order("name").All(&fields); err != nil {
+		return nil, err
+	}
+
+	properties := map[string]interface{}{}
+	required := []string{}
+	for _, f := range fields {
+		if memberType != "" && !f.appliesTo(memberType) {
+			continue
+		}
+
+		p := map[string]interface{}{"type": "string"}
+		switch f.Type {
+		case CustomFieldNumber:
+			p["type"] = "number"
+		case CustomFieldDate:
+			p["format"] = "date"
+		case CustomFieldEnum:
+			p["enum"] = f.Options
+		}
+		if f.Description.Valid {
+			p["description"] = f.Description.String
+		}
+		properties[f.Name] = p
+
+		if f.Required && (memberType != "" || len(f.MemberTypes) == 0) {
+			required = append(required, f.Name)
+		}
+	}
+
+	title := "Custom fields of the members"
+	if memberType != "" {
+		title = "Custom fields of the members of the type " + memberType
+	}
+
+	return map[string]interface{}{
+		"$schema":              "https://json-schema.org/draft/2020-12/schema",
+		"title":                title,
+		"type":                 "object",
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": false,
+	}, nil
+}
@@ -0,0 +1,110 @@
+package models
+
+import (
+	"encoding/xml"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/slices"
+)
+
+func (ms *ModelSuite) createCustomFields() {
+	ms.NoError(DB.Create(&CustomField{Name: "github", Type: CustomFieldString}))
+	ms.NoError(DB.Create(&CustomField{Name: "tshirt_size", Type: CustomFieldEnum, Options: slices.String{"S", "M", "L"}, Required: true}))
+	ms.NoError(DB.Create(&CustomField{Name: "badge_number", Type: CustomFieldNumber, Required: true, MemberTypes: slices.String{"employee"}}))
+	ms.NoError(DB.Create(&CustomField{Name: "start_date", Type: CustomFieldDate, Description: nulls.NewString("First day")}))
+}
+
+func (ms *ModelSuite) Test_CustomField_Validate() {
+	ms.createCustomFields()
+
+	tests := []struct {
+		field CustomField
+		key   string
+	}{
+		{CustomField{Name: "GitHub", Type: CustomFieldString}, "name"},
+		{CustomField{Name: "github", Type: CustomFieldString}, "name"},
+		{CustomField{Name: "size", Type: "list"}, "type"},
+		{CustomField{Name: "size", Type: CustomFieldEnum}, "options"},
+		{CustomField{Name: "size", Type: CustomFieldString, Options: slices.String{"S"}}, "options"},
+		{CustomField{Name: "size", Type: CustomFieldString, MemberTypes: slices.String{"robot"}}, "member_types"},
+	}
+
+	for _, tt := range tests {
+		verrs, err := tt.field.Validate(DB)
+		ms.NoError(err)
+		ms.NotEmpty(verrs.Get(tt.key), tt.field.Name)
+	}
+}
+
+func (ms *ModelSuite) Test_Member_CustomFields() {
+	ms.createCustomFields()
+
+	m := &Member{Name: "Member Name", Type: "employee", Role: "DevOps", CustomFields: CustomValues{
+		"github":      "octocat",
+		"tshirt_size": "XL",
+		"start_date":  "2026-13-01",
+		"badge":       "B-12",
+	}}
+	verrs, err := DB.ValidateAndCreate(m)
+	ms.NoError(err)
+	ms.NotEmpty(verrs.Get("custom_fields.tshirt_size"))
+	ms.NotEmpty(verrs.Get("custom_fields.badge_number"))
+	ms.NotEmpty(verrs.Get("custom_fields.start_date"))
+	ms.NotEmpty(verrs.Get("custom_fields.badge"))
+	ms.Empty(verrs.Get("custom_fields.github"))
+
+	m.CustomFields = CustomValues{"github": "octocat", "tshirt_size": "L", "badge_number": float64(42), "start_date": "2026-11-02"}
+	verrs, err = DB.ValidateAndCreate(m)
+	ms.NoError(err)
+	ms.False(verrs.HasAny())
+
+	stored := &Member{}
+	ms.NoError(DB.Find(stored, m.ID))
+	ms.Equal(m.CustomFields, stored.CustomFields)
+
+	// the badge number is only for the employees
+	c := &Member{Name: "Advisor Name", Type: "advisor", Role: "Security", CustomFields: CustomValues{"tshirt_size": "M", "badge_number": float64(7)}}
+	verrs, err = DB.ValidateAndCreate(c)
+	ms.NoError(err)
+	ms.NotEmpty(verrs.Get("custom_fields.badge_number"))
+
+	members := Members{}
+	ms.NoError(DB.Scope(MemberFilter{Custom: map[string]string{"tshirt_size": "L", "badge_number": "42"}}.Scope).All(&members))
+	ms.Equal(1, len(members))
+
+	// deleting the field deletes the values
+	field := &CustomField{}
+	ms.NoError(DB.Where("name = ?", "github").First(field))
+	ms.NoError(DB.Destroy(field))
+	ms.NoError(DB.Find(stored, m.ID))
+	ms.NotContains(stored.CustomFields, "github")
+}
+
+func (ms *ModelSuite) Test_CustomFieldsSchema() {
+	ms.createCustomFields()
+
+	schema, err := CustomFieldsSchema(DB, "employee")
+	ms.NoError(err)
+	ms.Equal([]string{"badge_number", "tshirt_size"}, schema["required"])
+	ms.Len(schema["properties"], 4)
+
+	schema, err = CustomFieldsSchema(DB, "")
+	ms.NoError(err)
+	ms.Equal([]string{"tshirt_size"}, schema["required"])
+	ms.Equal(map[string]interface{}{"type": "string", "format": "date", "description": "First day"}, schema["properties"].(map[string]interface{})["start_date"])
+}
+
+func (ms *ModelSuite) Test_CustomValues_XML() {
+	b, err := xml.Marshal(struct {
+		XMLName      xml.Name     `xml:"member"`
+		CustomFields CustomValues `xml:"custom_fields"`
+	}{CustomFields: CustomValues{"tshirt_size": "L", "badge_number": float64(42)}})
+	ms.NoError(err)
+	ms.Equal(`<member><custom_fields><badge_number>42</badge_number><tshirt_size>L</tshirt_size></custom_fields></member>`, string(b))
+
+	read := struct {
+		CustomFields CustomValues `xml:"custom_fields"`
+	}{}
+	ms.NoError(xml.Unmarshal(b, &read))
+	ms.Equal(CustomValues{"tshirt_size": "L", "badge_number": "42"}, read.CustomFields)
+}
@@ -0,0 +1,103 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gobuffalo/validate/v3/validators"
+	"github.com/gofrs/uuid"
+)
+
+// externalIDsLock is the first key of the advisory lock taken,
+// with the system and the ID, while a member is upserted by its external ID
+const externalIDsLock = 7265
+
+// externalSystem is the name of a system identifying the members, like hris or payroll
+var externalSystem = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,63}$`)
+
+// ExternalID is the ID of a member in another system (an HRIS, a payroll...),
+// an ID belongs to a single member and a member has a single ID per system
+type ExternalID struct {
+	ID         uuid.UUID `json:"-" db:"id"`
+	CreatedAt  time.Time `json:"-" db:"created_at"`
+	UpdatedAt  time.Time `json:"-" db:"updated_at"`
+	MemberID   uuid.UUID `json:"member_id" db:"member_id"`
+	System     string    `json:"system" db:"system" example:"hris"`
+	ExternalID string    `json:"external_id" db:"external_id" example:"E-1042"`
+}
+
+// ExternalIDs is a list of external IDs
+type ExternalIDs []ExternalID
+
+// Validate the external ID
+func (e *ExternalID) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	e.System = normalizeSystem(e.System)
+	e.ExternalID = strings.TrimSpace(e.ExternalID)
+
+	verrs := validate.Validate(
+		&validators.StringIsPresent{Name: "ExternalID", Field: e.ExternalID},
+	)
+
+	if !externalSystem.MatchString(e.System) {
+		verrs.Add("system", "System must start with a letter, followed by letters, digits, - or _.")
+		return verrs, nil
+	}
+
+	taken, err := tx.Where("system = ? AND external_id = ? AND id <> ?", e.System, e.ExternalID, e.ID).Exists(&ExternalID{})
+	if err != nil {
+		return verrs, err
+	}
+	if taken {
+		verrs.Add("external_id", "External ID is already taken in the system.")
+	}
+
+	other, err := tx.Where("member_id = ? AND system = ? AND id <> ?", e.MemberID, e.System, e.ID).Exists(&ExternalID{})
+	if err != nil {
+		return verrs, err
+	}
+	if other {
+		verrs.Add("system", "Member already has an ID in the system.")
+	}
+
+	return verrs, nil
+}
+
+// FindExternalIDs returns the external IDs of a member, by system
+func FindExternalIDs(tx *pop.Connection, memberID uuid.UUID) (ExternalIDs, error) {
+	ids := ExternalIDs{}
+	err := tx.Where("member_id = ?", memberID).Order("system").All(&ids)
+	return ids, err
+}
+
+// FindExternalID returns the ID of a member in a system,
+// sql.ErrNoRows if it has none
+func FindExternalID(tx *pop.Connection, memberID uuid.UUID, system string) (*ExternalID, error) {
+	e := &ExternalID{}
+	err := tx.Where("member_id = ? AND system = ?", memberID, normalizeSystem(system)).First(e)
+	return e, err
+}
+
+// FindMemberByExternalID returns the member with the ID in the system,
+// sql.ErrNoRows if there is none
+func FindMemberByExternalID(tx *pop.Connection, system, externalID string) (*Member, error) {
+	m := &Member{}
+	err := tx.Where("id = (SELECT member_id FROM external_ids WHERE system = ? AND external_id = ?)",
+		normalizeSystem(system), strings.TrimSpace(externalID)).First(m)
+	return m, err
+}
+
+// LockExternalID locks the ID in the system until the transaction ends:
+// two upserts of a new member by its external ID do not both create it,
+// the second one waits and updates the member created by the first one
+func LockExternalID(tx *pop.Connection, system, externalID string) error {
+	return tx.RawQuery("SELECT pg_advisory_xact_lock(?, hashtext(?))", externalIDsLock,
+		normalizeSystem(system)+"/"+strings.TrimSpace(externalID)).Exec()
+}
+
+// normalizeSystem is the name of a system in lower case
+func normalizeSystem(system string) string {
+	return strings.ToLower(strings.TrimSpace(system))
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/gobuffalo/pop/v5"
+)
+
+func (ms *ModelSuite) Test_ExternalID_Validate() {
+	m := &Member{Name: "Member Name", Type: "employee", Role: "DevOps"}
+	ms.NoError(DB.Create(m))
+	ms.NoError(DB.Create(&ExternalID{MemberID: m.ID, System: "hris", ExternalID: "E-1042"}))
+
+	other := &Member{Name: "Other Name", Type: "employee", Role: "DevOps"}
+	ms.NoError(DB.Create(other))
+
+	tests := []struct {
+		id  ExternalID
+		key string
+	}{
+		{ExternalID{MemberID: other.ID, System: "HR system", ExternalID: "E-1"}, "system"},
+		{ExternalID{MemberID: other.ID, System: "hris", ExternalID: " "}, "external_id"},
+		{ExternalID{MemberID: other.ID, System: "hris", ExternalID: "E-1042"}, "external_id"},
+		{ExternalID{MemberID: m.ID, System: "HRIS", ExternalID: "E-1043"}, "system"},
+	}
+
+	for _, tt := range tests {
+		verrs, err := tt.id.Validate(DB)
+		ms.NoError(err)
+		ms.NotEmpty(verrs.Get(tt.key), tt.id.ExternalID)
+	}
+
+	// the same ID in another system
+	verrs, err := DB.ValidateAndCreate(&ExternalID{MemberID: other.ID, System: "payroll", ExternalID: "E-1042"})
+	ms.NoError(err)
+	ms.False(verrs.HasAny())
+}
+
+func (ms *ModelSuite) Test_FindMemberByExternalID() {
+	m := &Member{Name: "Member Name", Type: "employee", Role: "DevOps"}
+	ms.NoError(DB.Create(m))
+	ms.NoError(DB.Create(&ExternalID{MemberID: m.ID, System: "hris", ExternalID: "E-1042"}))
+
+	found, err := FindMemberByExternalID(DB, "HRIS", "E-1042")
+	ms.NoError(err)
+	ms.Equal(m.ID, found.ID)
+
+	_, err = FindMemberByExternalID(DB, "payroll", "E-1042")
+	ms.True(errors.Is(err, sql.ErrNoRows))
+}
+
+func (ms *ModelSuite) Test_LockExternalID() {
+	ms.NoError(DB.Rollback(func(tx *pop.Connection) {
+		ms.NoError(LockExternalID(tx, " HRIS", "E-1042 "))
+
+		// another transaction can not take the lock of the same ID
+		locked := true
+		ms.NoError(DB.RawQuery("SELECT pg_try_advisory_xact_lock(?, hashtext(?))", externalIDsLock, "hris/E-1042").First(&locked))
+		ms.False(locked)
+	}))
+}
@@ -4,6 +4,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/gobuffalo/nulls"
 	"github.com/gobuffalo/pop/slices"
 	"github.com/gobuffalo/pop/v5"
 	"github.com/gobuffalo/validate/v3"
@@ -11,27 +12,44 @@ import (
 	"github.com/gofrs/uuid"
 )
 
-const (
-	memberTypeInvalid = "The member type must be 'employee' or 'contractor'"
-)
-
-var (
-	memberTypes = []string{"employee", "contractor"}
-)
-
-// Member can have a name
-// and can be an employee and have a role
-// or can be an contractor and have a contract duration
-// all members can have tags
+// Member can have a name and a type, see MemberType:
+// an employee has a role (of the catalog, see Role), a contractor has a contract period,
+// and so on for the other types declared
+// all members can have contact information (see validateContact), tags, custom fields (see CustomField),
+// be in teams and report to a manager, and have a status (see Transition)
 type Member struct {
 	ID               uuid.UUID     `json:"id" db:"id"`
 	CreatedAt        time.Time     `json:"-" db:"created_at"`
 	UpdatedAt        time.Time     `json:"-" db:"updated_at"`
 	Name             string        `json:"name" db:"name"`
-	Type             string        `json:"type" db:"type" enums:"employee,contractor"`
-	ContractDuration int64         `json:"contract_duration,omitempty" db:"contract_duration"`
+	Type             string        `json:"type" db:"type" enums:"employee,contractor,intern,vendor,advisor"`
+	ContractStart    nulls.Time    `json:"contract_start" db:"contract_start" swaggertype:"string" format:"date-time"`
+	ContractEnd      nulls.Time    `json:"contract_end" db:"contract_end" swaggertype:"string" format:"date-time"`
+	ContractDuration string        `json:"contract_duration,omitempty" db:"-" example:"P6M"`
 	Role             string        `json:"role,omitempty" db:"role"`
+	RoleID           nulls.UUID    `json:"role_id" db:"role_id" swaggertype:"string"`
+	RoleSuggestion   string        `json:"role_suggestion,omitempty" db:"-"`
+	Email            nulls.String  `json:"email" db:"email" swaggertype:"string" format:"email" example:"jane@example.com"`
+	Phone            nulls.String  `json:"phone" db:"phone" swaggertype:"string" example:"+14155552671"`
+	ChatHandle       nulls.String  `json:"chat_handle" db:"chat_handle" swaggertype:"string" example:"@jane"`
+	Location         nulls.String  `json:"location" db:"location" swaggertype:"string" example:"Paris, France"`
+	TimeZone         nulls.String  `json:"time_zone" db:"time_zone" swaggertype:"string" example:"Europe/Paris"`
 	Tags             slices.String `json:"tags" db:"tags"`
+	ManagerID        nulls.UUID    `json:"manager_id" db:"manager_id" swaggertype:"string"`
+	CustomFields     CustomValues  `json:"custom_fields" db:"custom_fields" swaggertype:"object"`
+	Status           string        `json:"status" db:"status" enums:"candidate,onboarding,active,on_leave,offboarding,offboarded"`
+	StatusSince      nulls.Time    `json:"status_since" db:"status_since" swaggertype:"string" format:"date-time"`
+	Teams            Teams         `json:"teams" many_to_many:"team_memberships"`
+
+	// stored is the type, role, contract and custom values before an update, see BeforeUpdate
+	stored *Member `db:"-"`
+	// contractChange is the contract recorded in the history
+	// by the update, see ExtendContract
+	contractChange *Contract `db:"-"`
+	// effectiveDate is when the change of type takes effect, see Convert
+	effectiveDate nulls.Time `db:"-"`
+	// statusChange is the status transition recorded by the update, see Transition
+	statusChange *StatusTransition `db:"-"`
 }
 
 // Members is a list of members
@@ -41,35 +59,187 @@ type Members []Member
 func (m *Member) Validate(tx *pop.Connection) (*validate.Errors, error) {
 	verrs := validate.Validate(
 		&validators.StringIsPresent{Name: "Name", Field: m.Name},
-		&validators.StringInclusion{Name: "Type", Field: m.Type, List: memberTypes, Message: memberTypeInvalid},
+		&validators.StringInclusion{Name: "Type", Field: m.Type, List: MemberTypeNames(), Message: memberTypeInvalid()},
 	)
 
-	if m.Type == "employee" && strings.TrimSpace(m.Role) == "" {
-		verrs.Add("role", "Role can not be blank.")
+	if err := matchRole(tx, m, verrs); err != nil {
+		return verrs, err
+	}
+
+	// the fields required by the type
+	if t, ok := findMemberType(m.Type); ok {
+		for _, f := range t.Required {
+			if !memberFields[f].isSet(m) {
+				verrs.Add(f, memberFields[f].blank)
+			}
+		}
+	}
+
+	validateContractPeriod(m, verrs)
+	validateStatus(m, verrs)
+	validateContact(m, verrs)
+
+	if err := validateCustomFields(tx, m, verrs); err != nil {
+		return verrs, err
 	}
 
-	if m.Type == "contractor" && m.ContractDuration == 0 {
-		verrs.Add("contract_duration", "contract duration can not be blank.")
+	if m.ManagerID.Valid {
+		if err := m.validateManager(tx, verrs); err != nil {
+			return verrs, err
+		}
 	}
 
 	return verrs, nil
 }
 
+// validateManager checks the manager exists and is not
+// the member itself or one of its reports
+func (m *Member) validateManager(tx *pop.Connection, verrs *validate.Errors) error {
+	if m.ManagerID.UUID == m.ID {
+		verrs.Add("manager_id", "Member cannot be their own manager.")
+		return nil
+	}
+
+	exists, err := tx.Where("id = ?", m.ManagerID.UUID).Exists(&Member{})
+	if err != nil {
+		return err
+	}
+	if !exists {
+		verrs.Add("manager_id", "Manager must be a member.")
+		return nil
+	}
+
+	if m.ID == uuid.Nil {
+		return nil
+	}
+
+	ids, err := ReportIDs(tx, m.ID)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if id == m.ManagerID.UUID {
+			verrs.Add("manager_id", "Manager cannot be one of the reports of the member.")
+		}
+	}
+
+	return nil
+}
+
 // BeforeSave (create or update), change the tag to lower case
 func (m *Member) BeforeSave(tx *pop.Connection) error {
 	for i, t := range m.Tags {
 		m.Tags[i] = strings.ToLower(t)
 	}
 
-	// zero the contract duration in case the type changed from contractor to employee
-	if m.Type == "employee" && m.ContractDuration != 0 {
-		m.ContractDuration = 0
+	// clear the fields forbidden by the type, in case the type changed
+	// (from contractor to employee, the contract is cleared)
+	if t, ok := findMemberType(m.Type); ok {
+		for _, f := range t.Forbidden {
+			memberFields[f].clear(m)
+		}
+	}
+
+	if m.ContractStart.Valid {
+		m.ContractStart.Time = truncateDate(m.ContractStart.Time)
+	}
+	if m.ContractEnd.Valid {
+		m.ContractEnd.Time = truncateDate(m.ContractEnd.Time)
+	}
+	m.setContractDuration()
+
+	if m.CustomFields == nil {
+		m.CustomFields = CustomValues{}
+	}
+
+	if m.Status == "" {
+		m.Status = StatusActive
+	}
+	if !m.StatusSince.Valid {
+		m.StatusSince = nulls.NewTime(time.Now())
+	}
+	m.StatusSince.Time = truncateDate(m.StatusSince.Time)
+
+	return nil
+}
+
+// AfterFind derives the contract duration from the contract period
+func (m *Member) AfterFind(tx *pop.Connection) error {
+	m.setContractDuration()
+	return nil
+}
+
+// BeforeUpdate keeps the type, the role, the contract, the custom values and the status
+// stored before the update, to tell if they changed once the member is saved
+func (m *Member) BeforeUpdate(tx *pop.Connection) error {
+	stored := &Member{}
+	if err := tx.Select("type", "role", "contract_start", "contract_end", "custom_fields", "status").Find(stored, m.ID); err != nil {
+		return err
+	}
+
+	m.stored = stored
+	return nil
+}
+
+// AfterCreate records the creation in the member events,
+// the contract and the status in their history,
+// and creates the onboarding checklists of the member
+func (m *Member) AfterCreate(tx *pop.Connection) error {
+	if err := recordContract(tx, m); err != nil {
+		return err
+	}
+
+	if err := recordStatusTransition(tx, m); err != nil {
+		return err
+	}
+
+	if err := createChecklists(tx, m, ChecklistOnboarding); err != nil {
+		return err
 	}
 
-	// delete rolein case the type changed from employee to contractor
-	if m.Type == "contractor" && m.Role != "" {
-		m.Role = ""
+	return recordMemberEvent(tx, m, MemberCreated, nil)
+}
+
+// AfterUpdate records the update in the member events,
+// along with the change of type if there is one (see typeChangeDetails),
+// and the changes of contract and of status in their history.
+// A member moving to offboarding gets its offboarding checklists.
+func (m *Member) AfterUpdate(tx *pop.Connection) error {
+	if err := recordContract(tx, m); err != nil {
+		return err
+	}
+
+	if err := recordMemberEvent(tx, m, MemberUpdated, nil); err != nil {
+		return err
+	}
+
+	if m.stored == nil {
+		return nil
+	}
+
+	if m.stored.Type != m.Type {
+		if err := recordMemberEvent(tx, m, MemberTypeChanged, m.typeChangeDetails()); err != nil {
+			return err
+		}
+	}
+
+	if m.stored.Status == m.Status {
+		return nil
+	}
+
+	if err := recordStatusTransition(tx, m); err != nil {
+		return err
+	}
+
+	if m.Status == StatusOffboarding {
+		return createChecklists(tx, m, ChecklistOffboarding)
 	}
 
 	return nil
 }
+
+// AfterDestroy records the deletion in the member events,
+// the event is the tombstone of the member in the change feed
+func (m *Member) AfterDestroy(tx *pop.Connection) error {
+	return recordMemberEvent(tx, m, MemberDeleted, nil)
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"errors"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+
+	// the IANA time zones, whether or not the system has them
+	_ "time/tzdata"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gobuffalo/validate/v3/validators"
+	"github.com/jackc/pgconn"
+)
+
+// phoneE164 is a phone number in the E.164 format: +, the country code and the number, 15 digits at most
+var phoneE164 = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+
+// phoneSeparators are the characters commonly used to write phone numbers, dropped before checking them
+var phoneSeparators = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "")
+
+// memberConstraints are the unique constraints of the members, with the field and the message of their violation
+var memberConstraints = map[string][2]string{
+	"members_email_idx": {"email", "Email is already taken."},
+}
+
+// validateContact checks the format of the contact information of a member:
+// an RFC 5322 email address, an E.164 phone number and an IANA time zone,
+// none of them longer than its column.
+// Blank values are cleared and the phone number loses its separators.
+func validateContact(m *Member, verrs *validate.Errors) {
+	m.Email = trimString(m.Email)
+	m.Phone = trimString(m.Phone)
+	m.ChatHandle = trimString(m.ChatHandle)
+	m.Location = trimString(m.Location)
+	m.TimeZone = trimString(m.TimeZone)
+
+	// the sizes of the columns
+	verrs.Append(validate.Validate(
+		&validators.StringLengthInRange{Name: "Email", Field: m.Email.String, Max: 254, Message: "Email must be at most 254 characters."},
+		&validators.StringLengthInRange{Name: "ChatHandle", Field: m.ChatHandle.String, Max: 100, Message: "Chat handle must be at most 100 characters."},
+		&validators.StringLengthInRange{Name: "Location", Field: m.Location.String, Max: 255, Message: "Location must be at most 255 characters."},
+	))
+
+	if m.Email.Valid {
+		if addr, err := mail.ParseAddress(m.Email.String); err != nil || addr.Address != m.Email.String {
+			verrs.Add("email", "Email must be a valid email address, like jane@example.com.")
+		}
+	}
+
+	if m.Phone.Valid {
+		m.Phone.String = phoneSeparators.Replace(m.Phone.String)
+		if !phoneE164.MatchString(m.Phone.String) {
+			verrs.Add("phone", "Phone must be in the E.164 format, like +14155552671.")
+		}
+	}
+
+	if m.TimeZone.Valid {
+		if _, err := time.LoadLocation(m.TimeZone.String); err != nil || m.TimeZone.String == "Local" {
+			verrs.Add("time_zone", "Time zone must be an IANA time zone, like Europe/Paris.")
+		}
+	}
+}
+
+// trimString trims a string, a blank one is null
+func trimString(s nulls.String) nulls.String {
+	if v := strings.TrimSpace(s.String); s.Valid && v != "" {
+		return nulls.NewString(v)
+	}
+	return nulls.String{}
+}
+
+// ConstraintErrors turns the violation of a unique constraint of the members,
+// like an email already taken, into validation errors. It takes the result
+// of saving a member, and returns it as is for the other errors.
+func ConstraintErrors(verrs *validate.Errors, err error) (*validate.Errors, error) {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != "23505" {
+		return verrs, err
+	}
+
+	c, ok := memberConstraints[pgErr.ConstraintName]
+	if !ok {
+		return verrs, err
+	}
+
+	verrs = validate.NewErrors()
+	verrs.Add(c[0], c[1])
+	return verrs, nil
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/gobuffalo/nulls"
+)
+
+func (ms *ModelSuite) Test_Member_Contact() {
+	m := &Member{
+		Name:       "Member Name",
+		Type:       "employee",
+		Role:       "DevOps",
+		Email:      nulls.NewString(" jane@example.com "),
+		Phone:      nulls.NewString("+1 (415) 555-2671"),
+		ChatHandle: nulls.NewString(" "),
+		TimeZone:   nulls.NewString("Europe/Paris"),
+	}
+
+	verrs, err := DB.ValidateAndCreate(m)
+	ms.NoError(err)
+	ms.False(verrs.HasAny())
+	ms.Equal("jane@example.com", m.Email.String)
+	ms.Equal("+14155552671", m.Phone.String)
+	ms.False(m.ChatHandle.Valid)
+}
+
+func (ms *ModelSuite) Test_Member_Contact_Invalid() {
+	tests := []struct {
+		field string
+		value string
+		key   string
+	}{
+		{"email", "jane", "email"},
+		{"email", "Jane <jane@example.com>", "email"},
+		{"phone", "0612345678", "phone"},
+		{"phone", "+0123456", "phone"},
+		{"time_zone", "Mars/Olympus", "time_zone"},
+		{"time_zone", "Local", "time_zone"},
+		{"email", strings.Repeat("j", 250) + "@example.com", "email"},
+		{"chat_handle", "@" + strings.Repeat("j", 100), "chat_handle"},
+		{"location", strings.Repeat("Paris ", 50), "location"},
+	}
+
+	for _, tt := range tests {
+		m := &Member{Name: "Member Name", Type: "employee", Role: "DevOps"}
+		switch tt.field {
+		case "email":
+			m.Email = nulls.NewString(tt.value)
+		case "phone":
+			m.Phone = nulls.NewString(tt.value)
+		case "time_zone":
+			m.TimeZone = nulls.NewString(tt.value)
+		case "chat_handle":
+			m.ChatHandle = nulls.NewString(tt.value)
+		case "location":
+			m.Location = nulls.NewString(tt.value)
+		}
+
+		verrs, err := m.Validate(DB)
+		ms.NoError(err)
+		ms.NotEmpty(verrs.Get(tt.key), tt.value)
+	}
+}
+
+func (ms *ModelSuite) Test_Member_Contact_EmailTaken() {
+	ms.NoError(DB.Create(&Member{Name: "Member Name", Type: "employee", Role: "DevOps", Email: nulls.NewString("jane@example.com")}))
+
+	verrs, err := ConstraintErrors(DB.ValidateAndCreate(&Member{Name: "Other Name", Type: "employee", Role: "DevOps", Email: nulls.NewString("Jane@Example.com")}))
+	ms.NoError(err)
+	ms.Equal([]string{"Email is already taken."}, verrs.Get("email"))
+}
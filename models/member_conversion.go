@@ -0,0 +1,95 @@
+package models
+
+import (
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gobuffalo/validate/v3"
+)
+
+// MemberConversion changes the type of a member, along with the fields
+// the new type requires: the role of an employee, the contract of a contractor.
+// The type changes right away, the effective date only records the day
+// the conversion took effect: today by default, never in the future.
+type MemberConversion struct {
+	Type          string     `json:"type" xml:"type" enums:"employee,contractor,intern,vendor,advisor"`
+	Role          string     `json:"role,omitempty" xml:"role,omitempty"`
+	ContractStart nulls.Time `json:"contract_start" xml:"contract_start" swaggertype:"string" format:"date-time"`
+	ContractEnd   nulls.Time `json:"contract_end" xml:"contract_end" swaggertype:"string" format:"date-time"`
+	EffectiveDate nulls.Time `json:"effective_date" xml:"effective_date" swaggertype:"string" format:"date-time"`
+}
+
+// Convert changes the type of the member and saves it, the member.type_changed
+// event keeps the effective date and the values the old type had and the new one discards,
+// the values of the custom fields of the old type included
+func (m *Member) Convert(tx *pop.Connection, conv MemberConversion) (*validate.Errors, error) {
+	verrs := validate.NewErrors()
+	if conv.Type == m.Type {
+		verrs.Add("type", "Member is already of this type.")
+	}
+
+	if conv.EffectiveDate.Valid {
+		effective := truncateDate(conv.EffectiveDate.Time)
+		if effective.After(truncateDate(time.Now())) {
+			verrs.Add("effective_date", "Effective date can not be in the future.")
+		}
+		if m.ContractStart.Valid && effective.Before(truncateDate(m.ContractStart.Time)) {
+			verrs.Add("effective_date", "Effective date can not be before the contract started.")
+		}
+	}
+
+	if verrs.HasAny() {
+		return verrs, nil
+	}
+
+	m.Type = conv.Type
+	m.Role = conv.Role
+	m.ContractStart, m.ContractEnd = conv.ContractStart, conv.ContractEnd
+
+	if err := m.DiscardCustomFields(tx); err != nil {
+		return verrs, err
+	}
+
+	m.effectiveDate = conv.EffectiveDate
+	defer func() { m.effectiveDate = nulls.Time{} }()
+
+	return tx.ValidateAndUpdate(m)
+}
+
+// typeChangeDetails are the details of the member.type_changed event:
+// the types, the day it takes effect and the values discarded by the new type
+func (m *Member) typeChangeDetails() map[string]interface{} {
+	effective := time.Now()
+	if m.effectiveDate.Valid {
+		effective = m.effectiveDate.Time
+	}
+
+	discarded := map[string]interface{}{}
+	if m.stored.Role != "" && m.Role == "" {
+		discarded["role"] = m.stored.Role
+	}
+	if m.stored.ContractStart.Valid && !m.ContractStart.Valid {
+		discarded["contract_start"] = m.stored.ContractStart.Time.Format("2006-01-02")
+	}
+	if m.stored.ContractEnd.Valid && !m.ContractEnd.Valid {
+		discarded["contract_end"] = m.stored.ContractEnd.Time.Format("2006-01-02")
+	}
+
+	custom := map[string]interface{}{}
+	for k, v := range m.stored.CustomFields {
+		if _, ok := m.CustomFields[k]; !ok {
+			custom[k] = v
+		}
+	}
+	if len(custom) > 0 {
+		discarded["custom_fields"] = custom
+	}
+
+	return map[string]interface{}{
+		"from":           m.stored.Type,
+		"to":             m.Type,
+		"effective_date": effective.Format("2006-01-02"),
+		"discarded":      discarded,
+	}
+}
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/slices"
+)
+
+func (ms *ModelSuite) Test_Member_Convert() {
+	m := &Member{Name: "Member Name", Type: "employee", Role: "DevOps"}
+	ms.NoError(DB.Create(m))
+
+	effective := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	verrs, err := m.Convert(DB, MemberConversion{
+		Type:          "contractor",
+		ContractStart: nulls.NewTime(effective),
+		ContractEnd:   nulls.NewTime(time.Date(2027, 4, 30, 0, 0, 0, 0, time.UTC)),
+		EffectiveDate: nulls.NewTime(effective),
+	})
+	ms.NoError(err)
+	ms.False(verrs.HasAny())
+	ms.Equal("", m.Role)
+
+	e := &MemberEvent{}
+	ms.NoError(DB.Where("member_id = ? AND type = ?", m.ID, MemberTypeChanged).First(e))
+
+	details := map[string]interface{}{}
+	ms.NoError(json.Unmarshal(e.Details, &details))
+	ms.Equal("employee", details["from"])
+	ms.Equal("contractor", details["to"])
+	ms.Equal("2026-10-01", details["effective_date"])
+	ms.Equal(map[string]interface{}{"role": "DevOps"}, details["discarded"])
+}
+
+func (ms *ModelSuite) Test_Member_Convert_CustomFields() {
+	ms.NoError(DB.Create(&CustomField{Name: "github", Type: CustomFieldString}))
+	ms.NoError(DB.Create(&CustomField{Name: "badge_number", Type: CustomFieldNumber, MemberTypes: slices.String{"employee"}}))
+
+	m := &Member{Name: "Member Name", Type: "employee", Role: "DevOps", CustomFields: CustomValues{"github": "jdoe", "badge_number": 42.0}}
+	ms.NoError(DB.Create(m))
+
+	// the badge number only applies to the employees, the contractor drops it
+	verrs, err := m.Convert(DB, MemberConversion{
+		Type:          "contractor",
+		ContractStart: nulls.NewTime(time.Now()),
+		ContractEnd:   nulls.NewTime(time.Now().AddDate(0, 6, 0)),
+	})
+	ms.NoError(err)
+	ms.False(verrs.HasAny())
+	ms.Equal(CustomValues{"github": "jdoe"}, m.CustomFields)
+
+	e := &MemberEvent{}
+	ms.NoError(DB.Where("member_id = ? AND type = ?", m.ID, MemberTypeChanged).First(e))
+
+	details := struct {
+		Discarded map[string]interface{} `json:"discarded"`
+	}{}
+	ms.NoError(json.Unmarshal(e.Details, &details))
+	ms.Equal(map[string]interface{}{"badge_number": 42.0}, details.Discarded["custom_fields"])
+}
+
+func (ms *ModelSuite) Test_Member_Convert_Invalid() {
+	m := &Member{Name: "Member Name", Type: "employee", Role: "DevOps"}
+	ms.NoError(DB.Create(m))
+
+	// same type
+	verrs, err := m.Convert(DB, MemberConversion{Type: "employee", Role: "Software Engineer"})
+	ms.NoError(err)
+	ms.True(verrs.HasAny())
+
+	// a contractor requires a contract period
+	verrs, err = m.Convert(DB, MemberConversion{Type: "contractor"})
+	ms.NoError(err)
+	ms.True(verrs.HasAny())
+
+	// the conversion can not take effect in the future
+	verrs, err = m.Convert(DB, MemberConversion{Type: "advisor", Role: "DevOps", EffectiveDate: nulls.NewTime(time.Now().AddDate(0, 0, 1))})
+	ms.NoError(err)
+	ms.Equal([]string{"Effective date can not be in the future."}, verrs.Get("effective_date"))
+
+	// nor before the contract started
+	c := &Member{Name: "Contractor Name", Type: "contractor", ContractStart: nulls.NewTime(time.Now()), ContractEnd: nulls.NewTime(time.Now().AddDate(0, 6, 0))}
+	ms.NoError(DB.Create(c))
+	verrs, err = c.Convert(DB, MemberConversion{Type: "employee", Role: "DevOps", EffectiveDate: nulls.NewTime(time.Now().AddDate(0, -1, 0))})
+	ms.NoError(err)
+	ms.Equal([]string{"Effective date can not be before the contract started."}, verrs.Get("effective_date"))
+	ms.Equal("contractor", c.Type)
+
+	count, err := DB.Where("member_id = ? AND type = ?", m.ID, MemberTypeChanged).Count(&MemberEvent{})
+	ms.NoError(err)
+	ms.Equal(0, count)
+}
@@ -0,0 +1,299 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gofrs/uuid"
+)
+
+// Reasons for two members to be probable duplicates
+const (
+	DuplicateName       = "name"
+	DuplicateEmail      = "email"
+	DuplicateExternalID = "external_id"
+)
+
+// normalizedName is the SQL expression of the name of a member compared
+// to find duplicates, it matches normalizeName
+const normalizedName = `LOWER(REGEXP_REPLACE(TRIM(%s.name), '\s+', ' ', 'g'))`
+
+// DuplicateCandidate is a member that is probably the same person as another one
+type DuplicateCandidate struct {
+	Member  Member   `json:"member" xml:"member"`
+	Reasons []string `json:"reasons" xml:"reasons" enums:"name,email,external_id"`
+}
+
+// DuplicateCandidates is a list of duplicate candidates
+type DuplicateCandidates []DuplicateCandidate
+
+// DuplicatePair is a pair of members that are probably the same person,
+// the member is the oldest one and the suggested survivor of a merge
+type DuplicatePair struct {
+	Member    Member   `json:"member" xml:"member"`
+	Duplicate Member   `json:"duplicate" xml:"duplicate"`
+	Reasons   []string `json:"reasons" xml:"reasons" enums:"name,email"`
+}
+
+// DuplicatePairs is a list of duplicate pairs, the oldest members first
+type DuplicatePairs []DuplicatePair
+
+// MemberMerge names the duplicate folded into the member by a merge
+type MemberMerge struct {
+	DuplicateID uuid.UUID `json:"duplicate_id" xml:"duplicate_id"`
+}
+
+// normalizeName is the name compared to find duplicates: in lower case,
+// without the surrounding spaces and with single spaces between the words
+func normalizeName(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
+
+// duplicateReasons tells why two members are probable duplicates,
+// nothing if they are not
+func duplicateReasons(a, b *Member) []string {
+	reasons := []string{}
+	if normalizeName(a.Name) == normalizeName(b.Name) {
+		reasons = append(reasons, DuplicateName)
+	}
+	if a.Email.Valid && b.Email.Valid && strings.EqualFold(a.Email.String, b.Email.String) {
+		reasons = append(reasons, DuplicateEmail)
+	}
+	return reasons
+}
+
+// FindDuplicates returns the members that are probably the same person as the member:
+// the same normalized name, the same email whatever the case, or one of its IDs
+// in other systems (the member to create with an external ID)
+func FindDuplicates(tx *pop.Connection, m *Member, ids ...ExternalID) (DuplicateCandidates, error) {
+	conditions := []string{strings.Replace(normalizedName, "%s", "members", 1) + " = ?"}
+	args := []interface{}{normalizeName(m.Name)}
+	if m.Email.Valid {
+		conditions = append(conditions, "LOWER(email) = LOWER(?)")
+		args = append(args, m.Email.String)
+	}
+	for _, e := range ids {
+		conditions = append(conditions, "id IN (SELECT member_id FROM external_ids WHERE system = ? AND external_id = ?)")
+		args = append(args, normalizeSystem(e.System), strings.TrimSpace(e.ExternalID))
+	}
+
+	members := Members{}
+	q := tx.Where("id <> ?", m.ID).Where("("+strings.Join(conditions, " OR ")+")", args...)
+	if err := q.Order("created_at").All(&members); err != nil {
+		return nil, err
+	}
+
+	candidates := make(DuplicateCandidates, len(members))
+	for i := range members {
+		candidates[i] = DuplicateCandidate{Member: members[i], Reasons: duplicateReasons(m, &members[i])}
+		for _, e := range ids {
+			found, err := FindExternalID(tx, members[i].ID, e.System)
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return nil, err
+			}
+			if err == nil && found.ExternalID == strings.TrimSpace(e.ExternalID) {
+				candidates[i].Reasons = append(candidates[i].Reasons, DuplicateExternalID)
+				break
+			}
+		}
+	}
+
+	return candidates, nil
+}
+
+// FindDuplicatePairs returns every pair of members that are probably
+// the same person, see FindDuplicates
+func FindDuplicatePairs(tx *pop.Connection) (DuplicatePairs, error) {
+	ids := []struct {
+		MemberID    uuid.UUID `db:"member_id"`
+		DuplicateID uuid.UUID `db:"duplicate_id"`
+	}{}
+
+	err := tx.RawQuery(`SELECT a.id AS member_id, b.id AS duplicate_id
+		FROM members a JOIN members b ON (a.created_at, a.id) < (b.created_at, b.id)
+		WHERE ` + strings.Replace(normalizedName, "%s", "a", 1) + ` = ` + strings.Replace(normalizedName, "%s", "b", 1) + `
+		OR LOWER(a.email) = LOWER(b.email)
+		ORDER BY a.created_at, a.id, b.created_at, b.id`).All(&ids)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ids) == 0 {
+		return DuplicatePairs{}, nil
+	}
+
+	all := []interface{}{}
+	for _, p := range ids {
+		all = append(all, p.MemberID, p.DuplicateID)
+	}
+
+	members := Members{}
+	if err := tx.Where("id IN (?)", all...).All(&members); err != nil {
+		return nil, err
+	}
+
+	byID := map[uuid.UUID]Member{}
+	for _, m := range members {
+		byID[m.ID] = m
+	}
+
+	pairs := make(DuplicatePairs, len(ids))
+	for i, p := range ids {
+		a, b := byID[p.MemberID], byID[p.DuplicateID]
+		pairs[i] = DuplicatePair{Member: a, Duplicate: b, Reasons: duplicateReasons(&a, &b)}
+	}
+
+	return pairs, nil
+}
+
+// Merge folds the duplicate into the member, which survives, and deletes the duplicate.
+// The member gets the tags of the duplicate, the custom fields and the contact information
+// it does not have, along with its contract and status history, assignments, checklists,
+// the items of the checklist templates, teams and external IDs. The reports and the teams led by the duplicate move to the member.
+// The events of the duplicate are kept as they are, it ends with a member.deleted event,
+// and the member.merged event of the member keeps the duplicate.
+func (m *Member) Merge(tx *pop.Connection, duplicate *Member) (*validate.Errors, error) {
+	verrs := validate.NewErrors()
+	if duplicate.ID == m.ID {
+		verrs.Add("duplicate_id", "A member can not be merged into itself.")
+		return verrs, nil
+	}
+
+	for _, t := range duplicate.Tags {
+		if !contains(m.Tags, strings.ToLower(t)) {
+			m.Tags = append(m.Tags, t)
+		}
+	}
+
+	if m.CustomFields == nil {
+		m.CustomFields = CustomValues{}
+	}
+	for k, v := range duplicate.CustomFields {
+		if _, ok := m.CustomFields[k]; !ok {
+			m.CustomFields[k] = v
+		}
+	}
+
+	for _, f := range []struct{ to, from *nulls.String }{
+		{&m.Email, &duplicate.Email},
+		{&m.Phone, &duplicate.Phone},
+		{&m.ChatHandle, &duplicate.ChatHandle},
+		{&m.Location, &duplicate.Location},
+		{&m.TimeZone, &duplicate.TimeZone},
+	} {
+		if !f.to.Valid {
+			*f.to = *f.from
+		}
+	}
+
+	// the member reporting to the duplicate reports to its manager instead
+	if m.ManagerID.Valid && m.ManagerID.UUID == duplicate.ID {
+		m.ManagerID = duplicate.ManagerID
+		if m.ManagerID.Valid && m.ManagerID.UUID == m.ID {
+			m.ManagerID.Valid = false
+		}
+	}
+	if !m.ManagerID.Valid && duplicate.ManagerID.Valid && duplicate.ManagerID.UUID != m.ID {
+		m.ManagerID = duplicate.ManagerID
+	}
+
+	verrs, err := m.Validate(tx)
+	if err != nil || verrs.HasAny() {
+		return verrs, err
+	}
+
+	// the reports of the duplicate move to the member, its manager can not be one of them
+	if m.ManagerID.Valid {
+		reports, err := ReportIDs(tx, duplicate.ID)
+		if err != nil {
+			return verrs, err
+		}
+		for _, id := range reports {
+			if id == m.ManagerID.UUID {
+				verrs.Add("manager_id", "Manager reports to the duplicate, the merge would make a reporting cycle.")
+				return verrs, nil
+			}
+		}
+	}
+
+	// the member is never allocated more than 100%, the assignments of both included
+	peak, err := mergedAllocationPeak(tx, m.ID, duplicate.ID)
+	if err != nil {
+		return verrs, err
+	}
+	if peak > MaxAllocation {
+		verrs.Add("allocation", fmt.Sprintf("Member would be allocated %d%%, more than 100%%, with the assignments of the duplicate.", peak))
+		return verrs, nil
+	}
+
+	moves := []struct {
+		query string
+		args  []interface{}
+	}{
+		{"UPDATE assignments SET member_id = ? WHERE member_id = ?", []interface{}{m.ID, duplicate.ID}},
+		{"UPDATE contracts SET member_id = ? WHERE member_id = ?", []interface{}{m.ID, duplicate.ID}},
+		{"UPDATE status_transitions SET member_id = ? WHERE member_id = ?", []interface{}{m.ID, duplicate.ID}},
+		{"UPDATE checklists SET member_id = ? WHERE member_id = ?", []interface{}{m.ID, duplicate.ID}},
+		{"UPDATE checklist_items SET owner_id = ? WHERE owner_id = ?", []interface{}{m.ID, duplicate.ID}},
+		{"UPDATE teams SET lead_id = ? WHERE lead_id = ?", []interface{}{m.ID, duplicate.ID}},
+		{"UPDATE members SET manager_id = ? WHERE manager_id = ? AND id <> ?", []interface{}{m.ID, duplicate.ID, m.ID}},
+		// the teams the member is already in keep a single membership,
+		// and the systems it already has an ID in keep its ID
+		{`UPDATE team_memberships SET member_id = ? WHERE member_id = ? AND team_id NOT IN (
+			SELECT team_id FROM team_memberships WHERE member_id = ?)`, []interface{}{m.ID, duplicate.ID, m.ID}},
+		{`UPDATE external_ids SET member_id = ? WHERE member_id = ? AND system NOT IN (
+			SELECT system FROM external_ids WHERE member_id = ?)`, []interface{}{m.ID, duplicate.ID, m.ID}},
+	}
+	for _, mv := range moves {
+		if err := tx.RawQuery(mv.query, mv.args...).Exec(); err != nil {
+			return verrs, err
+		}
+	}
+
+	// the owners of the template items are in their JSON, with no foreign key
+	if err := moveTemplateOwner(tx, duplicate.ID, m.ID); err != nil {
+		return verrs, err
+	}
+
+	// the duplicate goes first, the member may take its email
+	if err := tx.Destroy(duplicate); err != nil {
+		return verrs, err
+	}
+
+	if err := tx.Update(m); err != nil {
+		return verrs, err
+	}
+
+	return verrs, recordMemberEvent(tx, m, MemberMerged, map[string]interface{}{
+		"duplicate_id": duplicate.ID,
+		"duplicate":    duplicate,
+	})
+}
+
+// mergedAllocationPeak is the highest allocation of the members on a day,
+// their assignments put together. The assignments of both members are locked
+// until the transaction ends, like when an assignment is saved.
+func mergedAllocationPeak(tx *pop.Connection, ids ...uuid.UUID) (int, error) {
+	args := []interface{}{}
+	for _, id := range ids {
+		if err := tx.RawQuery("SELECT pg_advisory_xact_lock(?, hashtext(?))", assignmentsLock, id.String()).Exec(); err != nil {
+			return 0, err
+		}
+		args = append(args, id)
+	}
+
+	assignments := Assignments{}
+	if err := tx.Where("member_id IN (?)", args...).Order("start_date").All(&assignments); err != nil {
+		return 0, err
+	}
+	if len(assignments) == 0 {
+		return 0, nil
+	}
+
+	return peakAllocation(assignments, assignments[0].StartDate, nulls.Time{}), nil
+}
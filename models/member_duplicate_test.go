@@ -0,0 +1,159 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/slices"
+)
+
+func (ms *ModelSuite) Test_NormalizeName() {
+	ms.Equal("jane doe", normalizeName("  Jane   DOE "))
+}
+
+func (ms *ModelSuite) Test_FindDuplicates() {
+	jane := &Member{Name: "Jane Doe", Type: "employee", Role: "DevOps", Email: nulls.NewString("jane@example.com")}
+	ms.NoError(DB.Create(jane))
+	ms.NoError(DB.Create(&Member{Name: "John Doe", Type: "employee", Role: "DevOps"}))
+
+	candidates, err := FindDuplicates(DB, &Member{Name: "jane  doe"})
+	ms.NoError(err)
+	ms.Equal(1, len(candidates))
+	ms.Equal([]string{DuplicateName}, candidates[0].Reasons)
+
+	candidates, err = FindDuplicates(DB, &Member{Name: "J. Doe", Email: nulls.NewString("JANE@example.com")})
+	ms.NoError(err)
+	ms.Equal(1, len(candidates))
+	ms.Equal([]string{DuplicateEmail}, candidates[0].Reasons)
+
+	candidates, err = FindDuplicates(DB, &Member{Name: "Jack Doe"})
+	ms.NoError(err)
+	ms.Equal(0, len(candidates))
+
+	// the member with the ID in the system
+	ms.NoError(DB.Create(&ExternalID{MemberID: jane.ID, System: "hris", ExternalID: "E-1042"}))
+	candidates, err = FindDuplicates(DB, &Member{Name: "Jack Doe"}, ExternalID{System: "HRIS", ExternalID: "E-1042"})
+	ms.NoError(err)
+	ms.Equal(1, len(candidates))
+	ms.Equal(jane.ID, candidates[0].Member.ID)
+	ms.Equal([]string{DuplicateExternalID}, candidates[0].Reasons)
+
+	candidates, err = FindDuplicates(DB, &Member{Name: "Jack Doe"}, ExternalID{System: "payroll", ExternalID: "E-1042"})
+	ms.NoError(err)
+	ms.Equal(0, len(candidates))
+}
+
+func (ms *ModelSuite) Test_Member_Merge() {
+	jane := &Member{Name: "Jane Doe", Type: "employee", Role: "DevOps", Tags: slices.String{"golang"}}
+	ms.NoError(DB.Create(jane))
+	duplicate := &Member{Name: "Jane Doe", Type: "employee", Role: "DevOps", Tags: slices.String{"golang", "sql"}, Email: nulls.NewString("jane@example.com")}
+	ms.NoError(DB.Create(duplicate))
+	report := &Member{Name: "Report Name", Type: "employee", Role: "DevOps", ManagerID: nulls.NewUUID(duplicate.ID)}
+	ms.NoError(DB.Create(report))
+
+	team := &Team{Name: "Platform"}
+	ms.NoError(DB.Create(team))
+	ms.NoError(DB.Create(&TeamMembership{TeamID: team.ID, MemberID: duplicate.ID}))
+	ms.NoError(DB.Create(&ExternalID{MemberID: duplicate.ID, System: "hris", ExternalID: "E-1042"}))
+	template := &ChecklistTemplate{Name: "Offboarding", Kind: ChecklistOffboarding, Items: ChecklistTemplateItems{{Title: "Laptop", OwnerID: nulls.NewUUID(duplicate.ID)}}}
+	ms.NoError(DB.Create(template))
+
+	verrs, err := jane.Merge(DB, jane)
+	ms.NoError(err)
+	ms.NotEmpty(verrs.Get("duplicate_id"))
+
+	verrs, err = jane.Merge(DB, duplicate)
+	ms.NoError(err)
+	ms.False(verrs.HasAny())
+	ms.Equal(slices.String{"golang", "sql"}, jane.Tags)
+	ms.Equal("jane@example.com", jane.Email.String)
+
+	exists, err := DB.Where("id = ?", duplicate.ID).Exists(&Member{})
+	ms.NoError(err)
+	ms.False(exists)
+
+	ms.NoError(DB.Reload(report))
+	ms.Equal(jane.ID, report.ManagerID.UUID)
+
+	count, err := DB.Where("team_id = ? AND member_id = ?", team.ID, jane.ID).Count(&TeamMembership{})
+	ms.NoError(err)
+	ms.Equal(1, count)
+
+	found, err := FindMemberByExternalID(DB, "hris", "E-1042")
+	ms.NoError(err)
+	ms.Equal(jane.ID, found.ID)
+
+	ms.NoError(DB.Reload(template))
+	ms.Equal(jane.ID, template.Items[0].OwnerID.UUID)
+
+	// both initial status transitions are in the history of the survivor
+	transitions, err := FindStatusTransitions(DB, jane.ID)
+	ms.NoError(err)
+	ms.Equal(2, len(transitions))
+
+	e := &MemberEvent{}
+	ms.NoError(DB.Where("member_id = ? AND type = ?", jane.ID, MemberMerged).First(e))
+
+	details := map[string]interface{}{}
+	ms.NoError(json.Unmarshal(e.Details, &details))
+	ms.Equal(duplicate.ID.String(), details["duplicate_id"])
+}
+
+func (ms *ModelSuite) Test_Member_Merge_Allocation() {
+	ms.LoadFixture("projects")
+
+	developer := &Member{}
+	ms.NoError(DB.Where("name = ?", "Staffed Developer").First(developer))
+	apollo := &Project{}
+	ms.NoError(DB.Where("name = ?", "Apollo").First(apollo))
+
+	// 60% on Apollo until June, 40% on Gemini from March: 100% from March to June
+	duplicate := &Member{Name: "Staffed Developer", Type: "employee", Role: "Software Engineer"}
+	ms.NoError(DB.Create(duplicate))
+	a := &Assignment{MemberID: duplicate.ID, ProjectID: apollo.ID, Allocation: 60, StartDate: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)}
+	verrs, err := DB.ValidateAndCreate(a)
+	ms.NoError(err)
+	ms.False(verrs.HasAny())
+
+	verrs, err = developer.Merge(DB, duplicate)
+	ms.NoError(err)
+	ms.Contains(verrs.Get("allocation"), "Member would be allocated 160%, more than 100%, with the assignments of the duplicate.")
+
+	count, err := DB.Where("member_id = ?", duplicate.ID).Count(&Assignment{})
+	ms.NoError(err)
+	ms.Equal(1, count)
+
+	// from July, only the 40% on Gemini
+	a.StartDate = time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	ms.NoError(DB.Update(a))
+
+	verrs, err = developer.Merge(DB, duplicate)
+	ms.NoError(err)
+	ms.False(verrs.HasAny())
+
+	count, err = DB.Where("member_id = ?", developer.ID).Count(&Assignment{})
+	ms.NoError(err)
+	ms.Equal(3, count)
+}
+
+func (ms *ModelSuite) Test_Member_Merge_ManagerCycle() {
+	jane := &Member{Name: "Jane Doe", Type: "employee", Role: "DevOps"}
+	ms.NoError(DB.Create(jane))
+	duplicate := &Member{Name: "Jane Doe", Type: "employee", Role: "DevOps"}
+	ms.NoError(DB.Create(duplicate))
+	report := &Member{Name: "Report Name", Type: "employee", Role: "DevOps", ManagerID: nulls.NewUUID(duplicate.ID)}
+	ms.NoError(DB.Create(report))
+
+	// the report would move to jane, managing her
+	jane.ManagerID = nulls.NewUUID(report.ID)
+	ms.NoError(DB.Update(jane))
+
+	verrs, err := jane.Merge(DB, duplicate)
+	ms.NoError(err)
+	ms.NotEmpty(verrs.Get("manager_id"))
+
+	exists, err := DB.Where("id = ?", duplicate.ID).Exists(&Member{})
+	ms.NoError(err)
+	ms.True(exists)
+}
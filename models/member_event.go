@@ -0,0 +1,138 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gofrs/uuid"
+)
+
+// Types of the member events
+const (
+	MemberCreated       = "member.created"
+	MemberUpdated       = "member.updated"
+	MemberDeleted       = "member.deleted"
+	MemberTypeChanged   = "member.type_changed"
+	MemberStatusChanged = "member.status_changed"
+	MemberMerged        = "member.merged"
+
+	// the contract of a contractor ends soon, or has ended
+	ContractExpiring = "contract.expiring"
+	ContractExpired  = "contract.expired"
+)
+
+// memberEventsLock is the key of the advisory lock taken
+// while an event is recorded, see recordMemberEvent
+const memberEventsLock = 7263
+
+// MemberEvent is a domain event of the member lifecycle,
+// events are ordered by their ID
+type MemberEvent struct {
+	ID        int64           `json:"id" db:"id"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time       `json:"-" db:"updated_at"`
+	MemberID  uuid.UUID       `json:"member_id" db:"member_id"`
+	Type      string          `json:"type" db:"type" enums:"member.created,member.updated,member.deleted,member.type_changed,member.status_changed,member.merged,contract.expiring,contract.expired"`
+	Payload   json.RawMessage `json:"member" db:"payload" swaggertype:"object"`
+	Details   json.RawMessage `json:"details" db:"details" swaggertype:"object"`
+}
+
+// MemberEvents is a list of member events
+type MemberEvents []MemberEvent
+
+// recordMemberEvent saves an event for the member, along with its details,
+// and adds it to the outbox in the given transaction.
+// The advisory lock is held until the transaction ends, so event IDs
+// become visible in order and a reader never skips an event that
+// was still being committed.
+func recordMemberEvent(tx *pop.Connection, m *Member, eventType string, details map[string]interface{}) error {
+	if err := tx.RawQuery("SELECT pg_advisory_xact_lock(?)", memberEventsLock).Exec(); err != nil {
+		return err
+	}
+
+	// the member as it was when the event happened
+	payload, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	if details == nil {
+		details = map[string]interface{}{}
+	}
+
+	d, err := json.Marshal(details)
+	if err != nil {
+		return err
+	}
+
+	e := &MemberEvent{MemberID: m.ID, Type: eventType, Payload: payload, Details: d}
+	if err := tx.Create(e); err != nil {
+		return err
+	}
+
+	return enqueueOutboxMessages(tx, e)
+}
+
+// FindMemberEventsAfter returns the events following the given one, oldest first,
+// only the events of the given types if there are some
+func FindMemberEventsAfter(tx *pop.Connection, after int64, limit int, types ...string) (MemberEvents, error) {
+	events := MemberEvents{}
+	q := tx.Where("id > ?", after)
+	if len(types) > 0 {
+		args := make([]interface{}, len(types))
+		for i, t := range types {
+			args[i] = t
+		}
+		q = q.Where("type IN (?)", args...)
+	}
+	err := q.Order("id").Limit(limit).All(&events)
+	return events, err
+}
+
+// LastMemberEventID returns the ID of the most recent event, 0 if there is none
+func LastMemberEventID(tx *pop.Connection) (int64, error) {
+	var id int64
+	err := tx.RawQuery("SELECT COALESCE(MAX(id), 0) FROM member_events").First(&id)
+	return id, err
+}
+
+// MemberChange is the net change of a member between two events,
+// it is a line of the member change feed
+type MemberChange struct {
+	Change    string    `json:"change" db:"change" enums:"created,updated,deleted"`
+	MemberID  uuid.UUID `json:"member_id" db:"member_id"`
+	Member    *Member   `json:"member,omitempty" db:"-"`
+	ChangedAt time.Time `json:"changed_at" db:"changed_at"`
+	EventID   int64     `json:"-" db:"event_id"`
+	SyncToken string    `json:"sync_token" db:"-"`
+}
+
+// MemberChanges is a list of member changes
+type MemberChanges []MemberChange
+
+// FindMemberChanges returns the net change of each member over the events
+// in (since, until], ordered by their last event. Only the changes whose last
+// event comes after the "after" event are returned, at most limit of them.
+// A member deleted in the range is a "deleted" change (tombstone), a member
+// created in it is a "created" change even if it was updated afterwards.
+// The contract alerts do not change the member, they are left out.
+func FindMemberChanges(tx *pop.Connection, since, until, after int64, limit int) (MemberChanges, error) {
+	changes := MemberChanges{}
+	err := tx.RawQuery(`SELECT * FROM (
+		SELECT member_id,
+			MAX(id) AS event_id,
+			MAX(created_at) AS changed_at,
+			CASE
+				WHEN (ARRAY_AGG(type ORDER BY id DESC))[1] = ? THEN 'deleted'
+				WHEN BOOL_OR(type = ?) THEN 'created'
+				ELSE 'updated'
+			END AS change
+		FROM member_events
+		WHERE id > ? AND id <= ? AND type LIKE 'member.%'
+		GROUP BY member_id
+	) c WHERE c.event_id > ? ORDER BY c.event_id LIMIT ?`,
+		MemberDeleted, MemberCreated, since, until, after, limit).All(&changes)
+
+	return changes, err
+}
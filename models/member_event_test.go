@@ -0,0 +1,42 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+)
+
+func (ms *ModelSuite) Test_MemberEvent_Recorded() {
+	m := &Member{Name: "Member Name", Type: "employee", Role: "DevOps"}
+	ms.NoError(DB.Create(m))
+
+	m.Role = "Software Engineer"
+	ms.NoError(DB.Update(m))
+	ms.NoError(DB.Destroy(m))
+
+	events := MemberEvents{}
+	ms.NoError(DB.Where("member_id = ?", m.ID).Order("id").All(&events))
+	ms.Equal(3, len(events))
+	ms.Equal(MemberCreated, events[0].Type)
+	ms.Equal(MemberUpdated, events[1].Type)
+	ms.Equal(MemberDeleted, events[2].Type)
+
+	last, err := LastMemberEventID(DB)
+	ms.NoError(err)
+	ms.Equal(events[2].ID, last)
+}
+
+func (ms *ModelSuite) Test_MemberEvent_TypeChanged() {
+	m := &Member{Name: "Member Name", Type: "contractor", ContractStart: nulls.NewTime(time.Now()), ContractEnd: nulls.NewTime(time.Now().AddDate(0, 6, 0))}
+	ms.NoError(DB.Create(m))
+
+	m.Type = "employee"
+	m.Role = "DevOps"
+	ms.NoError(DB.Update(m))
+
+	e := &MemberEvent{}
+	ms.NoError(DB.Where("member_id = ? AND type = ?", m.ID, MemberTypeChanged).First(e))
+	ms.JSONEq(fmt.Sprintf(`{"from":"contractor","to":"employee","effective_date":%q,"discarded":{"contract_start":%q,"contract_end":%q}}`,
+		time.Now().Format("2006-01-02"), m.stored.ContractStart.Time.Format("2006-01-02"), m.stored.ContractEnd.Time.Format("2006-01-02")), string(e.Details))
+}
@@ -0,0 +1,114 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/gobuffalo/pop/slices"
+	"github.com/gobuffalo/pop/v5"
+)
+
+// MemberFilter narrows down a list of members,
+// empty fields are not applied
+type MemberFilter struct {
+	Type string
+	Role string
+	Name string
+	Team string
+	Unit string
+	Tags slices.String
+	// Status are the statuses a member can be in
+	Status slices.String
+	// Custom are the values of custom fields, by field name
+	Custom map[string]string
+}
+
+// ParamValues is the subset of the request parameters
+// needed to build a filter, buffalo.ParamValues satisfies it
+type ParamValues interface {
+	Get(string) string
+}
+
+// MemberFilterFromParams reads the filter from the request parameters:
+// "type", "role", "name", "team" (ID), "unit" (ID), "status" and "tags" (comma separated)
+// and "custom" (comma separated name:value pairs, like "tshirt_size:L,github:octocat")
+func MemberFilterFromParams(params ParamValues) MemberFilter {
+	f := MemberFilter{
+		Type: strings.TrimSpace(params.Get("type")),
+		Role: strings.TrimSpace(params.Get("role")),
+		Name: strings.TrimSpace(params.Get("name")),
+		Team: strings.TrimSpace(params.Get("team")),
+		Unit: strings.TrimSpace(params.Get("unit")),
+	}
+
+	for _, t := range strings.Split(params.Get("tags"), ",") {
+		if t = strings.ToLower(strings.TrimSpace(t)); t != "" {
+			f.Tags = append(f.Tags, t)
+		}
+	}
+
+	for _, s := range strings.Split(params.Get("status"), ",") {
+		if s = strings.ToLower(strings.TrimSpace(s)); s != "" {
+			f.Status = append(f.Status, s)
+		}
+	}
+
+	for _, pair := range strings.Split(params.Get("custom"), ",") {
+		kv := strings.SplitN(pair, ":", 2)
+		if name := strings.TrimSpace(kv[0]); name != "" && len(kv) == 2 {
+			if f.Custom == nil {
+				f.Custom = map[string]string{}
+			}
+			f.Custom[name] = strings.TrimSpace(kv[1])
+		}
+	}
+
+	return f
+}
+
+// likeEscaper escapes the wildcards of a LIKE pattern, % and _ are then plain characters
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLike escapes s to be matched as is by LIKE and ILIKE
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+// Scope applies the filter to a query, it can be used with pop.Query.Scope
+func (f MemberFilter) Scope(q *pop.Query) *pop.Query {
+	if f.Type != "" {
+		q = q.Where("members.type = ?", f.Type)
+	}
+
+	if f.Role != "" {
+		q = q.Where("LOWER(members.role) = LOWER(?)", f.Role)
+	}
+
+	if f.Name != "" {
+		q = q.Where("members.name ILIKE ?", "%"+escapeLike(f.Name)+"%")
+	}
+
+	if f.Team != "" {
+		q = q.Where("members.id IN (SELECT member_id FROM team_memberships WHERE team_id::text = ?)", f.Team)
+	}
+
+	// members of the unit or of any team under it
+	if f.Unit != "" {
+		q = q.Where("members.id IN (SELECT member_id FROM team_memberships WHERE team_id IN ("+subTeamsQuery+"))", f.Unit)
+	}
+
+	// members must have every tag requested
+	if len(f.Tags) > 0 {
+		q = q.Where("members.tags @> ?", f.Tags)
+	}
+
+	if len(f.Status) > 0 {
+		q = q.Where("members.status = ANY(?)", f.Status)
+	}
+
+	// the values are compared as text, 42 matches the number 42
+	for name, value := range f.Custom {
+		q = q.Where("members.custom_fields ->> ? = ?", name, value)
+	}
+
+	return q
+}
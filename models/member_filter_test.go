@@ -0,0 +1,46 @@
+package models
+
+import (
+	"net/url"
+
+	"github.com/gobuffalo/pop/slices"
+)
+
+func (ms *ModelSuite) Test_MemberFilterFromParams() {
+	f := MemberFilterFromParams(url.Values{
+		"type":   {" contractor "},
+		"tags":   {"GoLang, ,kubernetes"},
+		"status": {"Active,on_leave"},
+		"custom": {"tshirt_size: L,github:octo:cat,broken"},
+	})
+
+	ms.Equal("contractor", f.Type)
+	ms.Equal("", f.Role)
+	ms.Equal(slices.String{"golang", "kubernetes"}, f.Tags)
+	ms.Equal(slices.String{"active", "on_leave"}, f.Status)
+	ms.Equal(map[string]string{"tshirt_size": "L", "github": "octo:cat"}, f.Custom)
+}
+
+func (ms *ModelSuite) Test_MemberFilter_Scope() {
+	ms.LoadFixture("contractors")
+
+	members := Members{}
+	err := DB.Scope(MemberFilter{Tags: slices.String{"golang"}}.Scope).All(&members)
+	ms.NoError(err)
+	ms.Equal(1, len(members))
+	ms.Equal("Contractor #2", members[0].Name)
+}
+
+func (ms *ModelSuite) Test_MemberFilter_Scope_Name() {
+	ms.LoadFixture("contractors")
+
+	members := Members{}
+	ms.NoError(DB.Scope(MemberFilter{Name: "#2"}.Scope).All(&members))
+	ms.Equal(1, len(members))
+
+	// % and _ are not wildcards
+	for _, name := range []string{"_", "%", "Contractor_#"} {
+		ms.NoError(DB.Scope(MemberFilter{Name: name}.Scope).All(&members))
+		ms.Equal(0, len(members), name)
+	}
+}
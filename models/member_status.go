@@ -0,0 +1,169 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gofrs/uuid"
+)
+
+// Statuses of the member lifecycle
+const (
+	StatusCandidate   = "candidate"
+	StatusOnboarding  = "onboarding"
+	StatusActive      = "active"
+	StatusOnLeave     = "on_leave"
+	StatusOffboarding = "offboarding"
+	StatusOffboarded  = "offboarded"
+)
+
+var (
+	// MemberStatuses are the statuses of the member lifecycle, in order
+	MemberStatuses = []string{StatusCandidate, StatusOnboarding, StatusActive, StatusOnLeave, StatusOffboarding, StatusOffboarded}
+
+	// activeStatuses are the statuses of the members working, or about to, for the organization
+	activeStatuses = []string{StatusOnboarding, StatusActive, StatusOnLeave}
+
+	// initialStatuses are the statuses a member can be created with
+	initialStatuses = []string{StatusCandidate, StatusOnboarding, StatusActive}
+
+	// statusTransitions are the statuses a member can move to from each status,
+	// an offboarded member can be hired again
+	statusTransitions = map[string][]string{
+		StatusCandidate:   {StatusOnboarding, StatusOffboarded},
+		StatusOnboarding:  {StatusActive, StatusOffboarded},
+		StatusActive:      {StatusOnLeave, StatusOffboarding},
+		StatusOnLeave:     {StatusActive, StatusOffboarding},
+		StatusOffboarding: {StatusActive, StatusOffboarded},
+		StatusOffboarded:  {StatusOnboarding},
+	}
+)
+
+// StatusTransition is a move of a member from a status to another, its history
+// keeps every one from the status it was created with (without a from status)
+type StatusTransition struct {
+	ID            uuid.UUID    `json:"id" db:"id"`
+	CreatedAt     time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time    `json:"-" db:"updated_at"`
+	MemberID      uuid.UUID    `json:"member_id" db:"member_id"`
+	FromStatus    nulls.String `json:"from_status" db:"from_status" swaggertype:"string"`
+	ToStatus      string       `json:"to_status" db:"to_status" enums:"candidate,onboarding,active,on_leave,offboarding,offboarded"`
+	EffectiveDate time.Time    `json:"effective_date" db:"effective_date"`
+	Reason        nulls.String `json:"reason" db:"reason" swaggertype:"string"`
+}
+
+// StatusTransitions is a list of status transitions
+type StatusTransitions []StatusTransition
+
+// StatusChange moves a member to another status, from the effective date
+// (today by default, it can not be in the future)
+type StatusChange struct {
+	Status        string     `json:"status" xml:"status" enums:"candidate,onboarding,active,on_leave,offboarding,offboarded"`
+	EffectiveDate nulls.Time `json:"effective_date" xml:"effective_date" swaggertype:"string" format:"date-time"`
+	Reason        string     `json:"reason" xml:"reason"`
+}
+
+// validateStatus checks the status of a member is known,
+// and that a new member starts as a candidate, onboarding or active
+func validateStatus(m *Member, verrs *validate.Errors) {
+	if m.Status == "" {
+		return
+	}
+
+	if !contains(MemberStatuses, m.Status) {
+		verrs.Add("status", "The status must be one of "+strings.Join(MemberStatuses, ", "))
+		return
+	}
+
+	if m.ID == uuid.Nil && !contains(initialStatuses, m.Status) {
+		verrs.Add("status", "A new member must be "+strings.Join(initialStatuses, ", ")+".")
+	}
+}
+
+// Transition moves the member to another status and saves it, the transition
+// is kept in its history and recorded as a member.status_changed event
+func (m *Member) Transition(tx *pop.Connection, change StatusChange) (*validate.Errors, error) {
+	verrs := validate.NewErrors()
+
+	today := truncateDate(time.Now())
+	effective := today
+	if change.EffectiveDate.Valid {
+		effective = truncateDate(change.EffectiveDate.Time)
+	}
+
+	switch {
+	case !contains(MemberStatuses, change.Status):
+		verrs.Add("status", "The status must be one of "+strings.Join(MemberStatuses, ", "))
+	case !contains(statusTransitions[m.Status], change.Status):
+		verrs.Add("status", "A member can not move from "+m.Status+" to "+change.Status+".")
+	}
+
+	if effective.After(today) {
+		verrs.Add("effective_date", "Effective date can not be in the future.")
+	}
+	if m.StatusSince.Valid && effective.Before(truncateDate(m.StatusSince.Time)) {
+		verrs.Add("effective_date", "Effective date can not be before the current status started.")
+	}
+
+	if verrs.HasAny() {
+		return verrs, nil
+	}
+
+	transition := &StatusTransition{FromStatus: nulls.NewString(m.Status), ToStatus: change.Status, EffectiveDate: effective}
+	if reason := strings.TrimSpace(change.Reason); reason != "" {
+		transition.Reason = nulls.NewString(reason)
+	}
+
+	m.Status = change.Status
+	m.StatusSince = nulls.NewTime(effective)
+
+	m.statusChange = transition
+	defer func() { m.statusChange = nil }()
+
+	return tx.ValidateAndUpdate(m)
+}
+
+// FindStatusTransitions returns the status history of a member, the oldest first
+func FindStatusTransitions(tx *pop.Connection, memberID uuid.UUID) (StatusTransitions, error) {
+	transitions := StatusTransitions{}
+	err := tx.Where("member_id = ?", memberID).Order("effective_date, created_at").All(&transitions)
+	return transitions, err
+}
+
+// recordStatusTransition adds the status of a member to its history: the
+// transition prepared by Transition, or else the status it is created with
+// or changed to by an update. The change is also recorded as an event.
+func recordStatusTransition(tx *pop.Connection, m *Member) error {
+	transition := m.statusChange
+	m.statusChange = nil
+
+	if transition == nil {
+		transition = &StatusTransition{ToStatus: m.Status, EffectiveDate: truncateDate(time.Now())}
+		if m.StatusSince.Valid {
+			transition.EffectiveDate = truncateDate(m.StatusSince.Time)
+		}
+		if m.stored != nil {
+			transition.FromStatus = nulls.NewString(m.stored.Status)
+		}
+	}
+
+	transition.MemberID = m.ID
+	if err := tx.Create(transition); err != nil {
+		return err
+	}
+
+	if !transition.FromStatus.Valid {
+		return nil
+	}
+
+	details := map[string]interface{}{
+		"from":           transition.FromStatus.String,
+		"to":             transition.ToStatus,
+		"effective_date": transition.EffectiveDate.Format("2006-01-02"),
+		"reason":         transition.Reason,
+	}
+	return recordMemberEvent(tx, m, MemberStatusChanged, details)
+}
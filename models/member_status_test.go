@@ -0,0 +1,73 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+)
+
+func (ms *ModelSuite) Test_Member_Transition() {
+	m := &Member{Name: "Member Name", Type: "employee", Role: "DevOps", Status: StatusCandidate}
+	ms.NoError(DB.Create(m))
+
+	verrs, err := m.Transition(DB, StatusChange{Status: StatusOnboarding, Reason: "Offer signed"})
+	ms.NoError(err)
+	ms.False(verrs.HasAny())
+
+	verrs, err = m.Transition(DB, StatusChange{Status: StatusActive})
+	ms.NoError(err)
+	ms.False(verrs.HasAny())
+	ms.Equal(StatusActive, m.Status)
+
+	transitions, err := FindStatusTransitions(DB, m.ID)
+	ms.NoError(err)
+	ms.Equal(3, len(transitions))
+	ms.False(transitions[0].FromStatus.Valid)
+	ms.Equal(StatusCandidate, transitions[0].ToStatus)
+	ms.Equal(nulls.NewString(StatusCandidate), transitions[1].FromStatus)
+	ms.Equal("Offer signed", transitions[1].Reason.String)
+
+	e := &MemberEvent{}
+	ms.NoError(DB.Where("member_id = ? AND type = ?", m.ID, MemberStatusChanged).Order("id").First(e))
+
+	details := map[string]interface{}{}
+	ms.NoError(json.Unmarshal(e.Details, &details))
+	ms.Equal(StatusCandidate, details["from"])
+	ms.Equal(StatusOnboarding, details["to"])
+	ms.Equal("Offer signed", details["reason"])
+	ms.Equal(time.Now().Format("2006-01-02"), details["effective_date"])
+}
+
+func (ms *ModelSuite) Test_Member_Transition_Invalid() {
+	m := &Member{Name: "Member Name", Type: "employee", Role: "DevOps"}
+	ms.NoError(DB.Create(m))
+	ms.Equal(StatusActive, m.Status)
+
+	tests := []struct {
+		change StatusChange
+		key    string
+	}{
+		{StatusChange{Status: "retired"}, "status"},
+		{StatusChange{Status: StatusActive}, "status"},
+		{StatusChange{Status: StatusOffboarded}, "status"},
+		{StatusChange{Status: StatusOnLeave, EffectiveDate: nulls.NewTime(time.Now().AddDate(0, 0, 2))}, "effective_date"},
+		{StatusChange{Status: StatusOnLeave, EffectiveDate: nulls.NewTime(time.Now().AddDate(0, 0, -2))}, "effective_date"},
+	}
+
+	for _, tt := range tests {
+		verrs, err := m.Transition(DB, tt.change)
+		ms.NoError(err)
+		ms.NotEmpty(verrs.Get(tt.key), tt.change.Status)
+	}
+
+	ms.Equal(StatusActive, m.Status)
+}
+
+func (ms *ModelSuite) Test_Member_InitialStatus() {
+	m := &Member{Name: "Member Name", Type: "employee", Role: "DevOps", Status: StatusOffboarded}
+
+	verrs, err := DB.ValidateAndCreate(m)
+	ms.NoError(err)
+	ms.NotEmpty(verrs.Get("status"))
+}
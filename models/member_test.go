@@ -2,7 +2,9 @@ package models
 
 import (
 	"strings"
+	"time"
 
+	"github.com/gobuffalo/nulls"
 	"github.com/gobuffalo/pop/slices"
 )
 
@@ -33,36 +35,56 @@ func (ms *ModelSuite) Test_Member_EmployeeWithoutRole() {
 
 func (ms *ModelSuite) Test_Member_Contractor() {
 	m := &Member{
-		Name:             "Member Name",
-		Type:             "contractor",
-		ContractDuration: 150,
-		Tags:             slices.String{"golang", "kubernetes"},
+		Name:          "Member Name",
+		Type:          "contractor",
+		ContractStart: nulls.NewTime(time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)),
+		ContractEnd:   nulls.NewTime(time.Date(2026, 7, 15, 0, 0, 0, 0, time.UTC)),
+		Tags:          slices.String{"golang", "kubernetes"},
 	}
 
 	verrs, err := DB.ValidateAndCreate(m)
 	ms.NoError(err)
 	ms.Equal(false, verrs.HasAny())
+	ms.Equal("P6M", m.ContractDuration)
+
+	found := &Member{}
+	ms.NoError(DB.Find(found, m.ID))
+	ms.Equal("P6M", found.ContractDuration)
 }
 
-func (ms *ModelSuite) Test_Member_ContractorWithoutContractDuration() {
+func (ms *ModelSuite) Test_Member_ContractorWithoutContractPeriod() {
 	m := &Member{
-		Name:             "Member Name",
-		Type:             "contractor",
-		ContractDuration: 150,
-		Tags:             slices.String{"golang", "kubernetes"},
+		Name: "Member Name",
+		Type: "contractor",
+		Tags: slices.String{"golang", "kubernetes"},
 	}
 
 	verrs, err := DB.ValidateAndCreate(m)
 	ms.NoError(err)
-	ms.Equal(false, verrs.HasAny())
+	ms.Contains(verrs.Get("contract_start"), "Contract start can not be blank.")
+	ms.Contains(verrs.Get("contract_end"), "Contract end can not be blank.")
+}
+
+func (ms *ModelSuite) Test_Member_ContractorEndBeforeStart() {
+	m := &Member{
+		Name:          "Member Name",
+		Type:          "contractor",
+		ContractStart: nulls.NewTime(time.Date(2026, 7, 15, 0, 0, 0, 0, time.UTC)),
+		ContractEnd:   nulls.NewTime(time.Date(2026, 7, 15, 0, 0, 0, 0, time.UTC)),
+	}
+
+	verrs, err := DB.ValidateAndCreate(m)
+	ms.NoError(err)
+	ms.Contains(verrs.Get("contract_end"), "Contract end must be after the contract start.")
 }
 
 func (ms *ModelSuite) Test_Member_IsTagsLowerCase() {
 	m := &Member{
-		Name:             "Member Name",
-		Type:             "contractor",
-		ContractDuration: 150,
-		Tags:             slices.String{"GOLANG", "DocKEr", "kubernetes"},
+		Name:          "Member Name",
+		Type:          "contractor",
+		ContractStart: nulls.NewTime(time.Now()),
+		ContractEnd:   nulls.NewTime(time.Now().AddDate(0, 3, 0)),
+		Tags:          slices.String{"GOLANG", "DocKEr", "kubernetes"},
 	}
 
 	verrs, err := DB.ValidateAndCreate(m)
@@ -96,7 +118,7 @@ func (ms *ModelSuite) Test_Member_WithoutType() {
 	verrs, err := DB.ValidateAndCreate(m)
 	ms.NoError(err)
 	ms.Equal(true, verrs.HasAny())
-	ms.Contains(verrs.Error(), memberTypeInvalid)
+	ms.Contains(verrs.Error(), memberTypeInvalid())
 }
 
 func (ms *ModelSuite) Test_Member_WithoutTags() {
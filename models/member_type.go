@@ -0,0 +1,144 @@
+package models
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/gobuffalo/envy"
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/packr/v2"
+)
+
+// MemberType is a type of member, with the fields it requires and
+// the fields it forbids. The types are declared in config/member_types.toml,
+// or in the file MEMBER_TYPES_FILE points to.
+type MemberType struct {
+	Name        string   `json:"name" toml:"name"`
+	Description string   `json:"description" toml:"description"`
+	Required    []string `json:"required" toml:"required"`
+	Forbidden   []string `json:"forbidden" toml:"forbidden"`
+}
+
+// MemberTypes is the list of the types of member, in the order they are declared
+var MemberTypes []MemberType
+
+// memberFields are the fields a member type can require or forbid,
+// with how to tell the field is set and how to clear it
+var memberFields = map[string]struct {
+	blank string
+	isSet func(m *Member) bool
+	clear func(m *Member)
+}{
+	"role": {
+		blank: "Role can not be blank.",
+		isSet: func(m *Member) bool { return strings.TrimSpace(m.Role) != "" },
+		clear: func(m *Member) { m.Role, m.RoleID = "", nulls.UUID{} },
+	},
+	"contract_start": {
+		blank: "Contract start can not be blank.",
+		isSet: func(m *Member) bool { return m.ContractStart.Valid },
+		clear: func(m *Member) { m.ContractStart = nulls.Time{} },
+	},
+	"contract_end": {
+		blank: "Contract end can not be blank.",
+		isSet: func(m *Member) bool { return m.ContractEnd.Valid },
+		clear: func(m *Member) { m.ContractEnd = nulls.Time{} },
+	},
+}
+
+func init() {
+	data, err := readMemberTypes()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := loadMemberTypes(data); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// readMemberTypes reads the file declaring the member types
+func readMemberTypes() (string, error) {
+	if file := envy.Get("MEMBER_TYPES_FILE", ""); file != "" {
+		b, err := ioutil.ReadFile(file)
+		return string(b), err
+	}
+
+	return packr.New("app:config", "../config").FindString("member_types.toml")
+}
+
+// memberTypeMaxLength is the size of the type column of the members
+const memberTypeMaxLength = 10
+
+// loadMemberTypes parses and checks the declaration of the member types,
+// and replaces the member types with them
+func loadMemberTypes(data string) error {
+	declared := struct {
+		Types []MemberType `toml:"types"`
+	}{}
+	if _, err := toml.Decode(data, &declared); err != nil {
+		return fmt.Errorf("member types: %w", err)
+	}
+
+	if len(declared.Types) == 0 {
+		return fmt.Errorf("member types: no type declared")
+	}
+
+	names := []string{}
+	for _, t := range declared.Types {
+		if t.Name == "" || contains(names, t.Name) {
+			return fmt.Errorf("member types: the name %q is blank or declared twice", t.Name)
+		}
+		if len(t.Name) > memberTypeMaxLength {
+			return fmt.Errorf("member types: the name %q is longer than %d characters", t.Name, memberTypeMaxLength)
+		}
+		names = append(names, t.Name)
+
+		for _, f := range append(t.Required, t.Forbidden...) {
+			if _, ok := memberFields[f]; !ok {
+				return fmt.Errorf("member types: %s has an unknown field %q", t.Name, f)
+			}
+		}
+		for _, f := range t.Required {
+			if contains(t.Forbidden, f) {
+				return fmt.Errorf("member types: %s requires and forbids %q", t.Name, f)
+			}
+		}
+	}
+
+	MemberTypes = declared.Types
+	return nil
+}
+
+// MemberTypeNames are the names of the member types
+func MemberTypeNames() []string {
+	names := make([]string, len(MemberTypes))
+	for i, t := range MemberTypes {
+		names[i] = t.Name
+	}
+	return names
+}
+
+// IsMemberType tells if a member type is declared
+func IsMemberType(name string) bool {
+	_, ok := findMemberType(name)
+	return ok
+}
+
+// findMemberType returns the member type with this name
+func findMemberType(name string) (MemberType, bool) {
+	for _, t := range MemberTypes {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return MemberType{}, false
+}
+
+// memberTypeInvalid is the error of a type that is not declared
+func memberTypeInvalid() string {
+	return fmt.Sprintf("The member type must be one of %s", strings.Join(MemberTypeNames(), ", "))
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"time"
+
+	"github.com/gobuffalo/nulls"
+)
+
+func (ms *ModelSuite) Test_MemberTypes_Loaded() {
+	ms.Equal([]string{"employee", "contractor", "intern", "vendor", "advisor"}, MemberTypeNames())
+}
+
+func (ms *ModelSuite) Test_MemberTypes_Invalid() {
+	declared := MemberTypes
+	defer func() { MemberTypes = declared }()
+
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"no type", ``},
+		{"blank name", "[[types]]\nrequired = [\"role\"]"},
+		{"name too long", "[[types]]\nname = \"contractor_ext\""},
+		{"declared twice", "[[types]]\nname = \"intern\"\n[[types]]\nname = \"intern\""},
+		{"unknown field", "[[types]]\nname = \"intern\"\nrequired = [\"badge\"]"},
+		{"required and forbidden", "[[types]]\nname = \"intern\"\nrequired = [\"role\"]\nforbidden = [\"role\"]"},
+	}
+
+	for _, tt := range tests {
+		ms.Error(loadMemberTypes(tt.data), tt.name)
+	}
+	ms.Equal(declared, MemberTypes)
+}
+
+func (ms *ModelSuite) Test_Member_TypeRequiredFields() {
+	m := &Member{Name: "Intern Name", Type: "intern"}
+
+	verrs, err := DB.ValidateAndCreate(m)
+	ms.NoError(err)
+	ms.Contains(verrs.Get("role"), "Role can not be blank.")
+	ms.Contains(verrs.Get("contract_start"), "Contract start can not be blank.")
+	ms.Contains(verrs.Get("contract_end"), "Contract end can not be blank.")
+
+	m.Role = "Software Engineer"
+	m.ContractStart = nulls.NewTime(time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC))
+	m.ContractEnd = nulls.NewTime(time.Date(2027, 2, 28, 0, 0, 0, 0, time.UTC))
+	verrs, err = DB.ValidateAndCreate(m)
+	ms.NoError(err)
+	ms.False(verrs.HasAny())
+	ms.Equal("Software Engineer", m.Role)
+	ms.Equal("P5M27D", m.ContractDuration)
+}
+
+func (ms *ModelSuite) Test_Member_TypeForbiddenFields() {
+	m := &Member{
+		Name:          "Vendor Name",
+		Type:          "vendor",
+		Role:          "Consultant",
+		ContractStart: nulls.NewTime(time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)),
+		ContractEnd:   nulls.NewTime(time.Date(2027, 2, 28, 0, 0, 0, 0, time.UTC)),
+	}
+
+	verrs, err := DB.ValidateAndCreate(m)
+	ms.NoError(err)
+	ms.False(verrs.HasAny())
+	ms.Equal("", m.Role)
+}
@@ -0,0 +1,111 @@
+package models
+
+import (
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v5"
+)
+
+// Status of the outbox messages
+const (
+	OutboxPending    = "pending"
+	OutboxDispatched = "dispatched"
+	OutboxDead       = "dead"
+)
+
+var (
+	// OutboxSinks are the names of the sinks every member event is dispatched to,
+	// a message is added to the outbox for each of them
+	OutboxSinks = []string{"webhooks"}
+	// OutboxMaxAttempts is how many times a message is dispatched
+	// before it is dead-lettered
+	OutboxMaxAttempts = 10
+	// OutboxRetryBackoff is the delay before the first retry,
+	// it doubles after each failed attempt
+	OutboxRetryBackoff = 10 * time.Second
+)
+
+// OutboxMessage is a member event waiting to be dispatched to a sink.
+// Messages are added in the transaction recording the event, and stay
+// pending until the sink handles the event: each sink gets every event
+// at least once.
+type OutboxMessage struct {
+	ID            int64        `json:"id" db:"id"`
+	CreatedAt     time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time    `json:"-" db:"updated_at"`
+	EventID       int64        `json:"event_id" db:"event_id"`
+	Sink          string       `json:"sink" db:"sink"`
+	Status        string       `json:"status" db:"status" enums:"pending,dispatched,dead"`
+	Attempts      int          `json:"attempts" db:"attempts"`
+	NextAttemptAt nulls.Time   `json:"next_attempt_at" db:"next_attempt_at" swaggertype:"string"`
+	DispatchedAt  nulls.Time   `json:"dispatched_at" db:"dispatched_at" swaggertype:"string"`
+	LastError     nulls.String `json:"last_error" db:"last_error" swaggertype:"string"`
+}
+
+// OutboxMessages is a list of outbox messages
+type OutboxMessages []OutboxMessage
+
+// enqueueOutboxMessages adds the event to the outbox of every sink
+func enqueueOutboxMessages(tx *pop.Connection, e *MemberEvent) error {
+	for _, sink := range OutboxSinks {
+		msg := &OutboxMessage{
+			EventID:       e.ID,
+			Sink:          sink,
+			Status:        OutboxPending,
+			NextAttemptAt: nulls.NewTime(time.Now()),
+		}
+
+		if err := tx.Create(msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// NextDueOutboxMessage locks and returns the oldest pending message due at the given time.
+// Messages locked by another transaction are skipped, so several workers can run at once.
+// It returns false when there is nothing to dispatch.
+func NextDueOutboxMessage(tx *pop.Connection, now time.Time) (*OutboxMessage, bool, error) {
+	messages := OutboxMessages{}
+	err := tx.RawQuery(`SELECT * FROM outbox_messages
+		WHERE status = ? AND next_attempt_at <= ?
+		ORDER BY next_attempt_at, id
+		LIMIT 1 FOR UPDATE SKIP LOCKED`, OutboxPending, now).All(&messages)
+	if err != nil || len(messages) == 0 {
+		return nil, false, err
+	}
+
+	return &messages[0], true, nil
+}
+
+// Dispatched records that the sink handled the event
+func (o *OutboxMessage) Dispatched(now time.Time) {
+	o.Attempts++
+	o.Status = OutboxDispatched
+	o.DispatchedAt = nulls.NewTime(now)
+	o.NextAttemptAt = nulls.Time{}
+	o.LastError = nulls.String{}
+}
+
+// Failed records a failed attempt, the next one is scheduled with an exponential
+// backoff until the message runs out of attempts and is dead-lettered
+func (o *OutboxMessage) Failed(reason string, now time.Time) {
+	o.Attempts++
+	o.LastError = nulls.NewString(reason)
+
+	if o.Attempts >= OutboxMaxAttempts {
+		o.Status = OutboxDead
+		o.NextAttemptAt = nulls.Time{}
+		return
+	}
+
+	o.NextAttemptAt = nulls.NewTime(now.Add(retryBackoff(OutboxRetryBackoff, o.Attempts)))
+}
+
+// retryBackoff is the delay before the next attempt, doubling the base delay
+// after each failed attempt
+func retryBackoff(base time.Duration, attempts int) time.Duration {
+	return base << uint(attempts-1)
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+)
+
+func (ms *ModelSuite) Test_OutboxMessage_Enqueued() {
+	sinks := OutboxSinks
+	defer func() { OutboxSinks = sinks }()
+	OutboxSinks = []string{"webhooks", "log"}
+
+	m := &Member{Name: "Member Name", Type: "employee", Role: "DevOps"}
+	ms.NoError(DB.Create(m))
+
+	messages := OutboxMessages{}
+	ms.NoError(DB.Order("sink").All(&messages))
+	ms.Equal(2, len(messages))
+	ms.Equal("log", messages[0].Sink)
+	ms.Equal("webhooks", messages[1].Sink)
+	ms.Equal(OutboxPending, messages[0].Status)
+
+	msg, ok, err := NextDueOutboxMessage(DB, time.Now())
+	ms.NoError(err)
+	ms.True(ok)
+	ms.Equal(messages[0].EventID, msg.EventID)
+}
+
+func (ms *ModelSuite) Test_OutboxMessage_Failed() {
+	now := time.Now()
+	o := &OutboxMessage{Status: OutboxPending}
+
+	o.Failed("unknown sink", now)
+	ms.Equal(OutboxPending, o.Status)
+	ms.Equal(now.Add(OutboxRetryBackoff), o.NextAttemptAt.Time)
+
+	for o.Attempts < OutboxMaxAttempts {
+		o.Failed("unknown sink", now)
+	}
+	ms.Equal(OutboxDead, o.Status)
+
+	o.Dispatched(now)
+	ms.Equal(OutboxDispatched, o.Status)
+	ms.Equal(now, o.DispatchedAt.Time)
+}
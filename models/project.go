@@ -0,0 +1,48 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gobuffalo/validate/v3/validators"
+	"github.com/gofrs/uuid"
+)
+
+// Project is staffed with members through assignments
+type Project struct {
+	ID          uuid.UUID    `json:"id" db:"id"`
+	CreatedAt   time.Time    `json:"-" db:"created_at"`
+	UpdatedAt   time.Time    `json:"-" db:"updated_at"`
+	Name        string       `json:"name" db:"name"`
+	Description nulls.String `json:"description" db:"description" swaggertype:"string"`
+	Assignments Assignments  `json:"assignments,omitempty" has_many:"assignments" order_by:"start_date"`
+}
+
+// Projects is a list of projects
+type Projects []Project
+
+// Validate the project
+func (p *Project) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	verrs := validate.Validate(
+		&validators.StringIsPresent{Name: "Name", Field: p.Name},
+	)
+
+	exists, err := tx.Where("LOWER(name) = LOWER(?) AND id <> ?", strings.TrimSpace(p.Name), p.ID).Exists(&Project{})
+	if err != nil {
+		return verrs, err
+	}
+	if exists {
+		verrs.Add("name", "Name is already taken.")
+	}
+
+	return verrs, nil
+}
+
+// BeforeSave (create or update), trim the name
+func (p *Project) BeforeSave(tx *pop.Connection) error {
+	p.Name = strings.TrimSpace(p.Name)
+	return nil
+}
@@ -0,0 +1,131 @@
+package models
+
+import (
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gofrs/uuid"
+)
+
+// reportsQuery selects the IDs of the members reporting to a member,
+// directly or not. UNION (not UNION ALL) stops the recursion if a cycle was ever stored.
+const reportsQuery = `WITH RECURSIVE reports AS (
+		SELECT id FROM members WHERE manager_id = ?
+		UNION
+		SELECT members.id FROM members JOIN reports ON members.manager_id = reports.id
+	) SELECT id FROM reports`
+
+// ReportIDs returns the IDs of the members reporting to a member, at any depth
+func ReportIDs(tx *pop.Connection, id uuid.UUID) ([]uuid.UUID, error) {
+	ids := []uuid.UUID{}
+	err := tx.RawQuery(reportsQuery, id).All(&ids)
+	return ids, err
+}
+
+// FindReports returns the members reporting to a member, ordered by name:
+// only its direct reports when direct is set, every member under it otherwise
+func FindReports(tx *pop.Connection, id uuid.UUID, direct bool) (Members, error) {
+	members := Members{}
+
+	q := tx.Where("manager_id = ?", id)
+	if !direct {
+		q = tx.Where("id IN ("+reportsQuery+")", id)
+	}
+
+	err := q.Order("name").All(&members)
+	return members, err
+}
+
+// FindManagerChain returns the managers of a member,
+// from its direct manager up to the top of the organisation
+func FindManagerChain(tx *pop.Connection, id uuid.UUID) (Members, error) {
+	members := Members{}
+	err := tx.RawQuery(`WITH RECURSIVE chain AS (
+			SELECT id, manager_id, 0 AS depth, ARRAY[id] AS seen FROM members WHERE id = ?
+			UNION ALL
+			SELECT members.id, members.manager_id, chain.depth + 1, chain.seen || members.id
+			FROM members JOIN chain ON members.id = chain.manager_id
+			WHERE NOT members.id = ANY(chain.seen)
+		) SELECT members.* FROM chain JOIN members ON members.id = chain.id
+		WHERE chain.depth > 0
+		ORDER BY chain.depth`, id).All(&members)
+	return members, err
+}
+
+// OrgChartNode is a member in the org chart, with the members reporting to it
+type OrgChartNode struct {
+	ID          uuid.UUID       `json:"id" xml:"id"`
+	Name        string          `json:"name" xml:"name"`
+	Type        string          `json:"type" xml:"type"`
+	Role        string          `json:"role,omitempty" xml:"role,omitempty"`
+	ContractEnd string          `json:"contract_end,omitempty" xml:"contract_end,omitempty" example:"2026-12-31"`
+	Reports     []*OrgChartNode `json:"reports" xml:"reports>member"`
+}
+
+// OrgChart is the reporting lines of the organisation,
+// one tree per member without manager
+type OrgChart []*OrgChartNode
+
+// BuildOrgChart loads the members and builds the org chart from their manager,
+// the chart starts from the root member when it is set.
+// Members are ordered by name at each level. A cycle of managers, if one
+// was ever stored, is broken: its last member by name is at the top.
+func BuildOrgChart(tx *pop.Connection, root *uuid.UUID) (OrgChart, error) {
+	members := Members{}
+	if err := tx.Order("name").All(&members); err != nil {
+		return nil, err
+	}
+
+	nodes := map[uuid.UUID]*OrgChartNode{}
+	for _, m := range members {
+		nodes[m.ID] = &OrgChartNode{
+			ID:      m.ID,
+			Name:    m.Name,
+			Type:    m.Type,
+			Role:    m.Role,
+			Reports: []*OrgChartNode{},
+		}
+		if m.ContractEnd.Valid {
+			nodes[m.ID].ContractEnd = m.ContractEnd.Time.Format("2006-01-02")
+		}
+	}
+
+	// managers holds the reporting lines added to the chart so far, a member whose
+	// manager already reports to it (a cycle was stored) goes to the top instead
+	managers := map[uuid.UUID]uuid.UUID{}
+	chart := OrgChart{}
+	for _, m := range members {
+		node := nodes[m.ID]
+
+		manager, ok := nodes[m.ManagerID.UUID]
+		if !m.ManagerID.Valid || !ok || reportsTo(managers, manager.ID, m.ID) {
+			chart = append(chart, node)
+			continue
+		}
+		manager.Reports = append(manager.Reports, node)
+		managers[m.ID] = manager.ID
+	}
+
+	if root != nil {
+		node, ok := nodes[*root]
+		if !ok {
+			return OrgChart{}, nil
+		}
+		return OrgChart{node}, nil
+	}
+
+	return chart, nil
+}
+
+// reportsTo tells if the member reports to the manager, at any depth,
+// following the reporting lines of the chart being built
+func reportsTo(managers map[uuid.UUID]uuid.UUID, id, manager uuid.UUID) bool {
+	for {
+		if id == manager {
+			return true
+		}
+		next, ok := managers[id]
+		if !ok {
+			return false
+		}
+		id = next
+	}
+}
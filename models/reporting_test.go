@@ -0,0 +1,95 @@
+package models
+
+import (
+	"github.com/gobuffalo/nulls"
+)
+
+func (ms *ModelSuite) Test_Reports() {
+	ms.LoadFixture("reporting")
+
+	ceo := &Member{}
+	ms.NoError(DB.Where("name = ?", "Alice CEO").First(ceo))
+
+	reports, err := FindReports(DB, ceo.ID, true)
+	ms.NoError(err)
+	ms.Equal(2, len(reports))
+	ms.Equal("Bob CTO", reports[0].Name)
+
+	reports, err = FindReports(DB, ceo.ID, false)
+	ms.NoError(err)
+	ms.Equal(4, len(reports))
+}
+
+func (ms *ModelSuite) Test_ManagerChain() {
+	ms.LoadFixture("reporting")
+
+	developer := &Member{}
+	ms.NoError(DB.Where("name = ?", "Dave Developer").First(developer))
+
+	chain, err := FindManagerChain(DB, developer.ID)
+	ms.NoError(err)
+	ms.Equal(2, len(chain))
+	ms.Equal("Bob CTO", chain[0].Name)
+	ms.Equal("Alice CEO", chain[1].Name)
+}
+
+func (ms *ModelSuite) Test_Member_ManagerCycle() {
+	ms.LoadFixture("reporting")
+
+	ceo := &Member{}
+	ms.NoError(DB.Where("name = ?", "Alice CEO").First(ceo))
+	developer := &Member{}
+	ms.NoError(DB.Where("name = ?", "Dave Developer").First(developer))
+
+	ceo.ManagerID = nulls.NewUUID(developer.ID)
+	verrs, err := DB.ValidateAndUpdate(ceo)
+	ms.NoError(err)
+	ms.Contains(verrs.Get("manager_id"), "Manager cannot be one of the reports of the member.")
+
+	ceo.ManagerID = nulls.NewUUID(ceo.ID)
+	verrs, err = DB.ValidateAndUpdate(ceo)
+	ms.NoError(err)
+	ms.Contains(verrs.Get("manager_id"), "Member cannot be their own manager.")
+}
+
+func (ms *ModelSuite) Test_BuildOrgChart() {
+	ms.LoadFixture("reporting")
+
+	chart, err := BuildOrgChart(DB, nil)
+	ms.NoError(err)
+	ms.Equal(1, len(chart))
+	ms.Equal("Alice CEO", chart[0].Name)
+	ms.Equal(2, len(chart[0].Reports))
+	ms.Equal("Bob CTO", chart[0].Reports[0].Name)
+	ms.Equal(2, len(chart[0].Reports[0].Reports))
+
+	cto := &Member{}
+	ms.NoError(DB.Where("name = ?", "Bob CTO").First(cto))
+
+	chart, err = BuildOrgChart(DB, &cto.ID)
+	ms.NoError(err)
+	ms.Equal(1, len(chart))
+	ms.Equal("Bob CTO", chart[0].Name)
+}
+
+func (ms *ModelSuite) Test_BuildOrgChart_Cycle() {
+	a := &Member{Name: "Alice Doe", Type: "employee", Role: "DevOps"}
+	ms.NoError(DB.Create(a))
+	b := &Member{Name: "Bob Doe", Type: "employee", Role: "DevOps", ManagerID: nulls.NewUUID(a.ID)}
+	ms.NoError(DB.Create(b))
+
+	// the validation prevents it, a cycle could only be stored in the database
+	ms.NoError(DB.RawQuery("UPDATE members SET manager_id = ? WHERE id = ?", b.ID, a.ID).Exec())
+
+	chart, err := BuildOrgChart(DB, nil)
+	ms.NoError(err)
+	ms.Equal(1, len(chart))
+	ms.Equal("Bob Doe", chart[0].Name)
+	ms.Equal("Alice Doe", chart[0].Reports[0].Name)
+	ms.Empty(chart[0].Reports[0].Reports)
+
+	chart, err = BuildOrgChart(DB, &a.ID)
+	ms.NoError(err)
+	ms.Equal("Alice Doe", chart[0].Name)
+	ms.Empty(chart[0].Reports)
+}
@@ -0,0 +1,191 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gobuffalo/envy"
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gobuffalo/validate/v3/validators"
+	"github.com/gofrs/uuid"
+)
+
+// Modes of the validation of the member roles, read from ROLE_VALIDATION
+const (
+	// RoleValidationStrict rejects a role that is not in the catalog
+	RoleValidationStrict = "strict"
+	// RoleValidationLenient accepts it, along with the closest role of the catalog
+	RoleValidationLenient = "lenient"
+)
+
+var roleLevels = []string{"junior", "mid", "senior", "lead", "principal"}
+
+// Role is a role of the catalog, the role of a member is one of their titles
+// once the catalog has roles
+type Role struct {
+	ID          uuid.UUID    `json:"id" db:"id"`
+	CreatedAt   time.Time    `json:"-" db:"created_at"`
+	UpdatedAt   time.Time    `json:"-" db:"updated_at"`
+	Title       string       `json:"title" db:"title" example:"Software Engineer"`
+	Family      string       `json:"family" db:"family" example:"Engineering"`
+	Level       string       `json:"level" db:"level" enums:"junior,mid,senior,lead,principal"`
+	Description nulls.String `json:"description" db:"description" swaggertype:"string"`
+}
+
+// Roles is a list of roles
+type Roles []Role
+
+// Validate the role
+func (r *Role) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	verrs := validate.Validate(
+		&validators.StringIsPresent{Name: "Title", Field: r.Title},
+	)
+
+	if r.Level != "" && !contains(roleLevels, r.Level) {
+		verrs.Add("level", "The level must be one of "+strings.Join(roleLevels, ", "))
+	}
+
+	exists, err := tx.Where("LOWER(title) = LOWER(?) AND id <> ?", strings.TrimSpace(r.Title), r.ID).Exists(&Role{})
+	if err != nil {
+		return verrs, err
+	}
+	if exists {
+		verrs.Add("title", "Title is already taken.")
+	}
+
+	return verrs, nil
+}
+
+// BeforeSave (create or update), trim the title and the family
+func (r *Role) BeforeSave(tx *pop.Connection) error {
+	r.Title = strings.TrimSpace(r.Title)
+	r.Family = strings.TrimSpace(r.Family)
+	return nil
+}
+
+// AfterUpdate renames the role of the members having it,
+// each of them is updated so the change is in the member events
+func (r *Role) AfterUpdate(tx *pop.Connection) error {
+	members := Members{}
+	if err := tx.Where("role_id = ? AND role <> ?", r.ID, r.Title).All(&members); err != nil {
+		return err
+	}
+
+	for i := range members {
+		members[i].Role = r.Title
+		if err := tx.Update(&members[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// roleValidation is the mode of the validation of the roles, strict by default
+func roleValidation() string {
+	if envy.Get("ROLE_VALIDATION", RoleValidationStrict) == RoleValidationLenient {
+		return RoleValidationLenient
+	}
+	return RoleValidationStrict
+}
+
+// matchRole links the role of a member to the catalog, with the spelling
+// of the catalog, or sets the role from its role_id when it is blank.
+// A new role that is not in the catalog is rejected, or accepted with a suggestion
+// in the lenient mode; the role the member already had is accepted with a suggestion. Without roles in the catalog, any role is accepted,
+// the types forbidding the role are not checked (it is cleared on save).
+func matchRole(tx *pop.Connection, m *Member, verrs *validate.Errors) error {
+	m.RoleSuggestion = ""
+
+	if t, ok := findMemberType(m.Type); ok && contains(t.Forbidden, "role") {
+		return nil
+	}
+
+	title := strings.TrimSpace(m.Role)
+	if title == "" && m.RoleID.Valid {
+		r := &Role{}
+		if err := tx.Find(r, m.RoleID.UUID); err != nil {
+			verrs.Add("role_id", "Role must be in the catalog.")
+			return nil
+		}
+		m.Role = r.Title
+		return nil
+	}
+
+	m.RoleID = nulls.UUID{}
+	if title == "" {
+		return nil
+	}
+
+	roles := Roles{}
+	if err := tx.Select("id", "title").All(&roles); err != nil {
+		return err
+	}
+	if len(roles) == 0 {
+		return nil
+	}
+
+	closest, distance := Role{}, -1
+	for _, r := range roles {
+		d := levenshtein(strings.ToLower(title), strings.ToLower(r.Title))
+		if d == 0 {
+			m.Role, m.RoleID = r.Title, nulls.NewUUID(r.ID)
+			return nil
+		}
+		if distance < 0 || d < distance {
+			closest, distance = r, d
+		}
+	}
+
+	// the roles given before the catalog had them are kept until they change
+	stored := []string{}
+	if m.ID != uuid.Nil {
+		if err := tx.RawQuery("SELECT role FROM members WHERE id = ?", m.ID).All(&stored); err != nil {
+			return err
+		}
+	}
+	unchanged := len(stored) == 1 && stored[0] == title
+
+	if unchanged || roleValidation() == RoleValidationLenient {
+		m.RoleSuggestion = closest.Title
+		return nil
+	}
+
+	verrs.Add("role", "Role is not in the catalog, did you mean "+closest.Title+"?")
+	return nil
+}
+
+// levenshtein is the edit distance between two strings
+func levenshtein(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	prev := make([]int, len(t)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(s); i++ {
+		cur := make([]int, len(t)+1)
+		cur[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+
+	return prev[len(t)]
+}
+
+// min is the smallest of the numbers
+func min(n int, others ...int) int {
+	for _, o := range others {
+		if o < n {
+			n = o
+		}
+	}
+	return n
+}
@@ -0,0 +1,108 @@
+package models
+
+import (
+	"github.com/gobuffalo/envy"
+	"github.com/gobuffalo/nulls"
+)
+
+func (ms *ModelSuite) Test_Levenshtein() {
+	ms.Equal(0, levenshtein("devops", "devops"))
+	ms.Equal(1, levenshtein("devop", "devops"))
+	ms.Equal(3, levenshtein("kitten", "sitting"))
+	ms.Equal(4, levenshtein("", "lead"))
+}
+
+func (ms *ModelSuite) Test_Role_Validate() {
+	ms.NoError(DB.Create(&Role{Title: "DevOps"}))
+
+	tests := []struct {
+		role Role
+		key  string
+	}{
+		{Role{Title: " "}, "title"},
+		{Role{Title: "devops"}, "title"},
+		{Role{Title: "Software Engineer", Level: "guru"}, "level"},
+	}
+
+	for _, tt := range tests {
+		verrs, err := tt.role.Validate(DB)
+		ms.NoError(err)
+		ms.NotEmpty(verrs.Get(tt.key), tt.role.Title)
+	}
+}
+
+func (ms *ModelSuite) Test_Member_Role_Catalog() {
+	// without roles in the catalog, any role is accepted
+	m := &Member{Name: "Member Name", Type: "employee", Role: "Devops Engineer"}
+	verrs, err := DB.ValidateAndCreate(m)
+	ms.NoError(err)
+	ms.False(verrs.HasAny())
+	ms.False(m.RoleID.Valid)
+
+	r := &Role{Title: "DevOps", Family: "Engineering", Level: "senior"}
+	ms.NoError(DB.Create(r))
+
+	// the spelling of the catalog is used
+	m = &Member{Name: "Member Name", Type: "employee", Role: "devops"}
+	verrs, err = DB.ValidateAndCreate(m)
+	ms.NoError(err)
+	ms.False(verrs.HasAny())
+	ms.Equal("DevOps", m.Role)
+	ms.Equal(r.ID, m.RoleID.UUID)
+
+	// or the role is set from its id
+	m = &Member{Name: "Member Name", Type: "employee", RoleID: nulls.NewUUID(r.ID)}
+	verrs, err = DB.ValidateAndCreate(m)
+	ms.NoError(err)
+	ms.False(verrs.HasAny())
+	ms.Equal("DevOps", m.Role)
+
+	// a role that is not in the catalog is rejected, with the closest one
+	m = &Member{Name: "Member Name", Type: "employee", Role: "Devop"}
+	verrs, err = DB.ValidateAndCreate(m)
+	ms.NoError(err)
+	ms.Equal([]string{"Role is not in the catalog, did you mean DevOps?"}, verrs.Get("role"))
+
+	// or accepted with a suggestion in the lenient mode
+	mode := envy.Get("ROLE_VALIDATION", "")
+	envy.Set("ROLE_VALIDATION", RoleValidationLenient)
+	defer envy.Set("ROLE_VALIDATION", mode)
+
+	verrs, err = DB.ValidateAndCreate(m)
+	ms.NoError(err)
+	ms.False(verrs.HasAny())
+	ms.Equal("Devop", m.Role)
+	ms.Equal("DevOps", m.RoleSuggestion)
+
+	// renaming the role renames the members having it
+	r.Title = "DevOps Engineer"
+	ms.NoError(DB.Update(r))
+
+	count, err := DB.Where("role = ?", "DevOps Engineer").Count(&Member{})
+	ms.NoError(err)
+	ms.Equal(2, count)
+
+	// and records their update
+	count, err = DB.Where("type = ? AND payload->>'role' = ?", MemberUpdated, "DevOps Engineer").Count(&MemberEvent{})
+	ms.NoError(err)
+	ms.Equal(2, count)
+}
+
+func (ms *ModelSuite) Test_Member_Role_Catalog_Unchanged() {
+	m := &Member{Name: "Member Name", Type: "employee", Role: "Project Owner"}
+	ms.NoError(DB.Create(m))
+	ms.NoError(DB.Create(&Role{Title: "Product Owner"}))
+
+	// the role given before the catalog is kept while it does not change
+	m.Name = "Other Name"
+	verrs, err := DB.ValidateAndUpdate(m)
+	ms.NoError(err)
+	ms.False(verrs.HasAny())
+	ms.Equal("Project Owner", m.Role)
+	ms.Equal("Product Owner", m.RoleSuggestion)
+
+	m.Role = "Project Manager"
+	verrs, err = DB.ValidateAndUpdate(m)
+	ms.NoError(err)
+	ms.Equal([]string{"Role is not in the catalog, did you mean Product Owner?"}, verrs.Get("role"))
+}
@@ -0,0 +1,484 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gobuffalo/envy"
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/slices"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gofrs/uuid"
+)
+
+// Schemas of the SCIM 2.0 resources and messages (RFC 7643 and 7644),
+// the type, role and tags of the members are in the extension of the users
+const (
+	ScimUserSchema          = "urn:ietf:params:scim:schemas:core:2.0:User"
+	ScimGroupSchema         = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	ScimUserExtensionSchema = "urn:ietf:params:scim:schemas:extension:team_manager:2.0:User"
+	ScimListSchema          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	ScimPatchSchema         = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	ScimErrorSchema         = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+// ScimExternalSystem is the system of the external IDs given by the SCIM clients, see ExternalID
+const ScimExternalSystem = "scim"
+
+var (
+	// scimActivation and scimDeactivation are the transitions of a member
+	// activated or deactivated by SCIM, from its status
+	scimActivation = map[string][]string{
+		StatusCandidate:   {StatusOnboarding},
+		StatusOffboarding: {StatusActive},
+		StatusOffboarded:  {StatusOnboarding},
+	}
+	scimDeactivation = map[string][]string{
+		StatusOnboarding: {StatusOffboarded},
+		StatusActive:     {StatusOffboarding, StatusOffboarded},
+		StatusOnLeave:    {StatusOffboarding, StatusOffboarded},
+	}
+)
+
+// ScimError is a SCIM error response, scimType tells what was wrong with a 400
+type ScimError struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+// NewScimError returns the SCIM error of an HTTP status
+func NewScimError(status int, scimType, detail string) *ScimError {
+	return &ScimError{Schemas: []string{ScimErrorSchema}, Status: fmt.Sprint(status), ScimType: scimType, Detail: detail}
+}
+
+func (e *ScimError) Error() string {
+	return e.Detail
+}
+
+// ScimErrorDetail is the detail of a SCIM error from the validation errors, one sentence per field
+func ScimErrorDetail(verrs *validate.Errors) string {
+	keys := verrs.Keys()
+	sort.Strings(keys)
+
+	messages := []string{}
+	for _, k := range keys {
+		messages = append(messages, verrs.Get(k)...)
+	}
+	return strings.Join(messages, " ")
+}
+
+// ScimListResponse is a page of SCIM resources, startIndex is 1 for the first resource
+type ScimListResponse struct {
+	Schemas      []string    `json:"schemas"`
+	TotalResults int         `json:"totalResults"`
+	StartIndex   int         `json:"startIndex"`
+	ItemsPerPage int         `json:"itemsPerPage"`
+	Resources    interface{} `json:"Resources"`
+}
+
+// ScimMeta is the metadata of a SCIM resource, the location is its URL
+type ScimMeta struct {
+	ResourceType string    `json:"resourceType"`
+	Created      time.Time `json:"created"`
+	LastModified time.Time `json:"lastModified"`
+	Location     string    `json:"location,omitempty"`
+}
+
+// ScimName is the name of a SCIM user
+type ScimName struct {
+	Formatted  string `json:"formatted,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+}
+
+// ScimValue is a value of a multi-valued SCIM attribute, like the emails
+type ScimValue struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// ScimAddress is an address of a SCIM user, the location of the member
+type ScimAddress struct {
+	Formatted string `json:"formatted,omitempty"`
+	Locality  string `json:"locality,omitempty"`
+	Type      string `json:"type,omitempty"`
+	Primary   bool   `json:"primary,omitempty"`
+}
+
+// ScimReference is a reference to a SCIM resource: the groups of a user, the members of a group
+type ScimReference struct {
+	Value   string `json:"value"`
+	Ref     string `json:"$ref,omitempty"`
+	Display string `json:"display,omitempty"`
+}
+
+// ScimUserExtension is the part of a member that SCIM does not define
+type ScimUserExtension struct {
+	Type          string   `json:"type,omitempty"`
+	Role          string   `json:"role,omitempty"`
+	Tags          []string `json:"tags,omitempty"`
+	ContractStart string   `json:"contractStart,omitempty"`
+	ContractEnd   string   `json:"contractEnd,omitempty"`
+	Status        string   `json:"status,omitempty"`
+}
+
+// ScimUser is a member as a SCIM user. The userName is the email of the member,
+// the name its name, and active tells if it is onboarding, active or on leave.
+type ScimUser struct {
+	Schemas      []string           `json:"schemas"`
+	ID           string             `json:"id,omitempty"`
+	ExternalID   string             `json:"externalId,omitempty"`
+	UserName     string             `json:"userName"`
+	Name         *ScimName          `json:"name,omitempty"`
+	DisplayName  string             `json:"displayName,omitempty"`
+	Active       *bool              `json:"active,omitempty"`
+	Emails       []ScimValue        `json:"emails,omitempty"`
+	PhoneNumbers []ScimValue        `json:"phoneNumbers,omitempty"`
+	Ims          []ScimValue        `json:"ims,omitempty"`
+	Addresses    []ScimAddress      `json:"addresses,omitempty"`
+	Timezone     string             `json:"timezone,omitempty"`
+	Groups       []ScimReference    `json:"groups,omitempty"`
+	Extension    *ScimUserExtension `json:"urn:ietf:params:scim:schemas:extension:team_manager:2.0:User,omitempty"`
+	Meta         *ScimMeta          `json:"meta,omitempty"`
+}
+
+// ScimGroup is a team as a SCIM group
+type ScimGroup struct {
+	Schemas     []string        `json:"schemas"`
+	ID          string          `json:"id,omitempty"`
+	DisplayName string          `json:"displayName"`
+	Members     []ScimReference `json:"members,omitempty"`
+	Meta        *ScimMeta       `json:"meta,omitempty"`
+}
+
+// NewScimUser returns the member as a SCIM user, with its SCIM external ID
+// and the teams it is in as groups
+func NewScimUser(m *Member, externalID string) ScimUser {
+	active := contains(activeStatuses, m.Status)
+	u := ScimUser{
+		Schemas:     []string{ScimUserSchema, ScimUserExtensionSchema},
+		ID:          m.ID.String(),
+		ExternalID:  externalID,
+		UserName:    m.Email.String,
+		Name:        &ScimName{Formatted: m.Name},
+		DisplayName: m.Name,
+		Active:      &active,
+		Timezone:    m.TimeZone.String,
+		Extension: &ScimUserExtension{
+			Type:   m.Type,
+			Role:   m.Role,
+			Tags:   m.Tags,
+			Status: m.Status,
+		},
+		Meta: &ScimMeta{ResourceType: "User", Created: m.CreatedAt, LastModified: m.UpdatedAt},
+	}
+
+	if m.Email.Valid {
+		u.Emails = []ScimValue{{Value: m.Email.String, Type: "work", Primary: true}}
+	}
+	if m.Phone.Valid {
+		u.PhoneNumbers = []ScimValue{{Value: m.Phone.String, Type: "work", Primary: true}}
+	}
+	if m.ChatHandle.Valid {
+		u.Ims = []ScimValue{{Value: m.ChatHandle.String, Type: "work", Primary: true}}
+	}
+	if m.Location.Valid {
+		u.Addresses = []ScimAddress{{Formatted: m.Location.String, Type: "work", Primary: true}}
+	}
+	if m.ContractStart.Valid {
+		u.Extension.ContractStart = m.ContractStart.Time.Format("2006-01-02")
+	}
+	if m.ContractEnd.Valid {
+		u.Extension.ContractEnd = m.ContractEnd.Time.Format("2006-01-02")
+	}
+
+	for _, t := range m.Teams {
+		u.Groups = append(u.Groups, ScimReference{Value: t.ID.String(), Display: t.Name})
+	}
+
+	return u
+}
+
+// NewScimGroup returns the team as a SCIM group, with its members
+func NewScimGroup(t *Team) ScimGroup {
+	g := ScimGroup{
+		Schemas:     []string{ScimGroupSchema},
+		ID:          t.ID.String(),
+		DisplayName: t.Name,
+		Members:     []ScimReference{},
+		Meta:        &ScimMeta{ResourceType: "Group", Created: t.CreatedAt, LastModified: t.UpdatedAt},
+	}
+
+	for _, m := range t.Members {
+		g.Members = append(g.Members, ScimReference{Value: m.ID.String(), Display: m.Name})
+	}
+
+	return g
+}
+
+// applyTo replaces the fields of the member with the ones of the user,
+// except the status (see scimSetActive), the type when the user has none
+// and the fields of the extension when the user does not have it
+func (u *ScimUser) applyTo(m *Member) error {
+	m.Name = u.DisplayName
+	if n := u.Name; m.Name == "" && n != nil {
+		m.Name = n.Formatted
+		if m.Name == "" {
+			m.Name = strings.TrimSpace(n.GivenName + " " + n.FamilyName)
+		}
+	}
+
+	// the userName is the email, the emails are only read without it
+	m.Email = nulls.NewString(u.UserName)
+	if u.UserName == "" {
+		m.Email = nulls.NewString(primaryScimValue(u.Emails))
+	}
+	m.Phone = nulls.NewString(primaryScimValue(u.PhoneNumbers))
+	m.ChatHandle = nulls.NewString(primaryScimValue(u.Ims))
+	m.TimeZone = nulls.NewString(u.Timezone)
+
+	m.Location = nulls.String{}
+	for _, a := range u.Addresses {
+		if !m.Location.Valid || a.Primary {
+			m.Location = nulls.NewString(a.Formatted)
+			if a.Formatted == "" {
+				m.Location = nulls.NewString(a.Locality)
+			}
+		}
+	}
+
+	// the clients that do not know the extension keep its fields as they are
+	ext := u.Extension
+	if ext == nil {
+		return nil
+	}
+	// a member always has a type, it is kept when the user has none
+	if ext.Type != "" {
+		m.Type = ext.Type
+	}
+	m.Role, m.RoleID = ext.Role, nulls.UUID{}
+	m.Tags = slices.String(ext.Tags)
+
+	for _, d := range []struct {
+		name  string
+		value string
+		to    *nulls.Time
+	}{{"contractStart", ext.ContractStart, &m.ContractStart}, {"contractEnd", ext.ContractEnd, &m.ContractEnd}} {
+		*d.to = nulls.Time{}
+		if d.value == "" {
+			continue
+		}
+
+		t, err := parseScimDate(d.value)
+		if err != nil {
+			return NewScimError(400, "invalidValue", "The "+d.name+" must be a date, like 2026-01-15.")
+		}
+		*d.to = nulls.NewTime(t)
+	}
+
+	return nil
+}
+
+// primaryScimValue is the primary value of a multi-valued attribute, or its first value
+func primaryScimValue(values []ScimValue) string {
+	value := ""
+	for i, v := range values {
+		if i == 0 || v.Primary {
+			value = v.Value
+		}
+		if v.Primary {
+			break
+		}
+	}
+	return value
+}
+
+// parseScimDate reads a date, or the date of a date and time
+func parseScimDate(s string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+// SaveScimUser creates the member from the SCIM user, or replaces it when it exists:
+// a field the user does not have is cleared (see applyTo), the type can not change.
+// A new user without a type has the type of SCIM_DEFAULT_TYPE. The external ID of the user
+// is saved in the "scim" system, and a change of active moves the member
+// through its statuses (see scimSetActive).
+func SaveScimUser(tx *pop.Connection, m *Member, u ScimUser) (*validate.Errors, error) {
+	storedType := m.Type
+	if err := u.applyTo(m); err != nil {
+		return validate.NewErrors(), err
+	}
+
+	// the type changes with a conversion, which keeps the fields the new type discards
+	isNew := m.ID == uuid.Nil
+	if !isNew && m.Type != storedType {
+		return validate.NewErrors(), NewScimError(400, "mutability", "The type of a member can not change with SCIM, convert it with /v1/members/"+m.ID.String()+"/convert.")
+	}
+
+	var verrs *validate.Errors
+	var err error
+	if isNew {
+		// the clients that do not know the extension create members of the default type
+		if m.Type == "" {
+			m.Type = envy.Get("SCIM_DEFAULT_TYPE", "")
+		}
+		m.Status = StatusActive
+		if u.Active != nil && !*u.Active {
+			m.Status = StatusCandidate
+		}
+		verrs, err = ConstraintErrors(tx.ValidateAndCreate(m))
+	} else {
+		verrs, err = ConstraintErrors(tx.ValidateAndUpdate(m))
+	}
+	if err != nil || verrs.HasAny() {
+		return verrs, err
+	}
+
+	if err := saveScimExternalID(tx, m, u.ExternalID); err != nil {
+		return verrs, err
+	}
+
+	if isNew || u.Active == nil {
+		return verrs, nil
+	}
+	return m.scimSetActive(tx, *u.Active)
+}
+
+// saveScimExternalID sets, or removes when it is blank, the SCIM external ID of the member
+func saveScimExternalID(tx *pop.Connection, m *Member, externalID string) error {
+	ext, err := FindExternalID(tx, m.ID, ScimExternalSystem)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	if externalID == "" {
+		if ext.ID == uuid.Nil {
+			return nil
+		}
+		return tx.Destroy(ext)
+	}
+
+	ext.MemberID, ext.System, ext.ExternalID = m.ID, ScimExternalSystem, externalID
+	return tx.Save(ext)
+}
+
+// FindScimExternalIDs returns the SCIM external IDs of the members, by member ID
+func FindScimExternalIDs(tx *pop.Connection, members Members) (map[uuid.UUID]string, error) {
+	ids := map[uuid.UUID]string{}
+	if len(members) == 0 {
+		return ids, nil
+	}
+
+	memberIDs := make([]interface{}, len(members))
+	for i, m := range members {
+		memberIDs[i] = m.ID
+	}
+
+	externalIDs := ExternalIDs{}
+	if err := tx.Where("system = ?", ScimExternalSystem).Where("member_id IN (?)", memberIDs...).All(&externalIDs); err != nil {
+		return nil, err
+	}
+
+	for _, e := range externalIDs {
+		ids[e.MemberID] = e.ExternalID
+	}
+	return ids, nil
+}
+
+// scimSetActive activates or deactivates the member with the transitions
+// of its status, from a candidate or an offboarded member to onboarding,
+// from an active member to offboarding then offboarded, and so on
+func (m *Member) scimSetActive(tx *pop.Connection, active bool) (*validate.Errors, error) {
+	path := scimDeactivation[m.Status]
+	if active {
+		path = scimActivation[m.Status]
+	}
+
+	verrs := validate.NewErrors()
+	for _, status := range path {
+		var err error
+		if verrs, err = m.Transition(tx, StatusChange{Status: status, Reason: "SCIM"}); err != nil || verrs.HasAny() {
+			return verrs, err
+		}
+	}
+
+	return verrs, nil
+}
+
+// SaveScimGroup creates the team from the SCIM group, or renames it
+// when it exists, and makes its members the members of the group
+func SaveScimGroup(tx *pop.Connection, t *Team, g ScimGroup) (*validate.Errors, error) {
+	t.Name = g.DisplayName
+
+	verrs, err := tx.ValidateAndSave(t)
+	if err != nil || verrs.HasAny() {
+		return verrs, err
+	}
+
+	// the members of the group, each once
+	members := map[uuid.UUID]bool{}
+	ids := []interface{}{}
+	for _, ref := range g.Members {
+		id, err := uuid.FromString(ref.Value)
+		if err != nil {
+			verrs.Add("members", "Member "+ref.Value+" does not exist.")
+			continue
+		}
+		if !members[id] {
+			members[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	if len(ids) > 0 {
+		count, err := tx.Where("id IN (?)", ids...).Count(&Member{})
+		if err != nil {
+			return verrs, err
+		}
+		if count != len(ids) {
+			verrs.Add("members", "Members must exist.")
+		}
+	}
+	if verrs.HasAny() {
+		return verrs, nil
+	}
+
+	memberships := TeamMemberships{}
+	if err := tx.Where("team_id = ?", t.ID).All(&memberships); err != nil {
+		return verrs, err
+	}
+
+	for i := range memberships {
+		tm := &memberships[i]
+		if members[tm.MemberID] {
+			delete(members, tm.MemberID)
+			continue
+		}
+		if err := tx.Destroy(tm); err != nil {
+			return verrs, err
+		}
+	}
+
+	// the members not in the team yet
+	for _, id := range ids {
+		if !members[id.(uuid.UUID)] {
+			continue
+		}
+		if err := tx.Create(&TeamMembership{TeamID: t.ID, MemberID: id.(uuid.UUID)}); err != nil {
+			return verrs, err
+		}
+	}
+
+	return verrs, nil
+}
@@ -0,0 +1,323 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gobuffalo/pop/v5"
+)
+
+// scimOperators are the comparison operators of the SCIM filters
+var scimOperators = []string{"eq", "ne", "co", "sw", "ew", "gt", "ge", "lt", "le", "pr"}
+
+// ScimFilter is a SCIM filter, like userName eq "jane@example.com": comparisons
+// joined with "and" and "or" ("and" first), without parentheses nor "not".
+// Each item of the filter is a list of comparisons that must all match.
+type ScimFilter [][]scimComparison
+
+// scimComparison compares an attribute with a value, pr has no value
+type scimComparison struct {
+	Attribute string
+	Operator  string
+	Value     interface{}
+}
+
+// scimAttribute is an attribute a filter can compare: a SQL expression
+// compared with the operators, or a SQL condition with the value as its argument
+// for the attributes only compared with eq
+type scimAttribute struct {
+	column    string
+	caseExact bool
+	condition string
+}
+
+// scimUserAttributes are the attributes of the users a filter can compare, in lower case
+var scimUserAttributes = map[string]scimAttribute{
+	"id":                       {column: "members.id::text", caseExact: true},
+	"externalid":               {column: "(SELECT external_id FROM external_ids WHERE external_ids.member_id = members.id AND system = '" + ScimExternalSystem + "')", caseExact: true},
+	"username":                 {column: "members.email"},
+	"emails":                   {column: "members.email"},
+	"emails.value":             {column: "members.email"},
+	"displayname":              {column: "members.name"},
+	"name.formatted":           {column: "members.name"},
+	"active":                   {column: "(members.status IN ('" + strings.Join(activeStatuses, "', '") + "'))", caseExact: true},
+	"timezone":                 {column: "members.time_zone"},
+	"meta.created":             {column: "members.created_at", caseExact: true},
+	"meta.lastmodified":        {column: "members.updated_at", caseExact: true},
+	scimExtensionKey("type"):   {column: "members.type"},
+	scimExtensionKey("role"):   {column: "members.role"},
+	scimExtensionKey("status"): {column: "members.status"},
+	scimExtensionKey("tags"):   {condition: "LOWER(?) = ANY(members.tags)"},
+	"groups":                   {condition: "EXISTS (SELECT 1 FROM team_memberships WHERE team_memberships.member_id = members.id AND team_memberships.team_id::text = ?)"},
+	"groups.value":             {condition: "EXISTS (SELECT 1 FROM team_memberships WHERE team_memberships.member_id = members.id AND team_memberships.team_id::text = ?)"},
+}
+
+// scimGroupAttributes are the attributes of the groups a filter can compare, in lower case
+var scimGroupAttributes = map[string]scimAttribute{
+	"id":                {column: "teams.id::text", caseExact: true},
+	"displayname":       {column: "teams.name"},
+	"meta.created":      {column: "teams.created_at", caseExact: true},
+	"meta.lastmodified": {column: "teams.updated_at", caseExact: true},
+	"members":           {condition: "EXISTS (SELECT 1 FROM team_memberships WHERE team_memberships.team_id = teams.id AND team_memberships.member_id::text = ?)"},
+	"members.value":     {condition: "EXISTS (SELECT 1 FROM team_memberships WHERE team_memberships.team_id = teams.id AND team_memberships.member_id::text = ?)"},
+}
+
+// scimExtensionKey is the key of an attribute of the extension of the users, in lower case
+func scimExtensionKey(name string) string {
+	return strings.ToLower(ScimUserExtensionSchema + ":" + name)
+}
+
+// ParseScimFilter reads a SCIM filter, an invalidFilter error tells what is wrong
+func ParseScimFilter(filter string) (ScimFilter, error) {
+	tokens, err := scimFilterTokens(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	f := ScimFilter{{}}
+	for len(tokens) > 0 {
+		if len(tokens) < 2 {
+			return nil, scimInvalidFilter("The comparison of %q is not complete.", tokens[0])
+		}
+
+		c := scimComparison{Attribute: tokens[0], Operator: strings.ToLower(tokens[1])}
+		if !contains(scimOperators, c.Operator) {
+			return nil, scimInvalidFilter("The operator %q is not supported.", tokens[1])
+		}
+		tokens = tokens[2:]
+
+		if c.Operator != "pr" {
+			if len(tokens) == 0 {
+				return nil, scimInvalidFilter("The comparison of %q has no value.", c.Attribute)
+			}
+			if err := json.Unmarshal([]byte(tokens[0]), &c.Value); err != nil {
+				return nil, scimInvalidFilter("The value %s is not a string, a number, true, false or null.", tokens[0])
+			}
+			tokens = tokens[1:]
+		}
+		f[len(f)-1] = append(f[len(f)-1], c)
+
+		if len(tokens) == 0 {
+			break
+		}
+		switch strings.ToLower(tokens[0]) {
+		case "and":
+		case "or":
+			f = append(f, []scimComparison{})
+		default:
+			return nil, scimInvalidFilter("Expected and or or, not %q.", tokens[0])
+		}
+		tokens = tokens[1:]
+		if len(tokens) == 0 {
+			return nil, scimInvalidFilter("The filter ends with %q.", "and/or")
+		}
+	}
+
+	return f, nil
+}
+
+// scimFilterTokens splits a filter in words and JSON strings, the parentheses and brackets are not supported
+func scimFilterTokens(filter string) ([]string, error) {
+	tokens := []string{}
+	s := strings.TrimSpace(filter)
+	for s != "" {
+		switch {
+		case s[0] == '"':
+			end := 1
+			for ; end < len(s) && s[end] != '"'; end++ {
+				if s[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(s) {
+				return nil, scimInvalidFilter("A string is not closed.")
+			}
+			tokens = append(tokens, s[:end+1])
+			s = s[end+1:]
+		case strings.ContainsRune("()[]", rune(s[0])):
+			return nil, scimInvalidFilter("Parentheses and brackets are not supported.")
+		default:
+			end := strings.IndexAny(s, " \t\"()[]")
+			if end < 0 {
+				end = len(s)
+			}
+			tokens = append(tokens, s[:end])
+			s = s[end:]
+		}
+		s = strings.TrimLeft(s, " \t")
+	}
+
+	return tokens, nil
+}
+
+// scimInvalidFilter is the error of a filter that can not be read or applied
+func scimInvalidFilter(format string, args ...interface{}) *ScimError {
+	return NewScimError(400, "invalidFilter", fmt.Sprintf(format, args...))
+}
+
+// UserScope returns the scope selecting the members matching the filter,
+// an invalidFilter error if it compares an attribute that is not supported
+func (f ScimFilter) UserScope() (pop.ScopeFunc, error) {
+	return f.scope(scimUserAttributes)
+}
+
+// GroupScope returns the scope selecting the teams matching the filter,
+// an invalidFilter error if it compares an attribute that is not supported
+func (f ScimFilter) GroupScope() (pop.ScopeFunc, error) {
+	return f.scope(scimGroupAttributes)
+}
+
+func (f ScimFilter) scope(attributes map[string]scimAttribute) (pop.ScopeFunc, error) {
+	ors := []string{}
+	args := []interface{}{}
+	for _, and := range f {
+		conditions := []string{}
+		for _, c := range and {
+			condition, cargs, err := c.sql(attributes)
+			if err != nil {
+				return nil, err
+			}
+			conditions = append(conditions, condition)
+			args = append(args, cargs...)
+		}
+		if len(conditions) > 0 {
+			ors = append(ors, "("+strings.Join(conditions, " AND ")+")")
+		}
+	}
+
+	return func(q *pop.Query) *pop.Query {
+		if len(ors) == 0 {
+			return q
+		}
+		return q.Where("("+strings.Join(ors, " OR ")+")", args...)
+	}, nil
+}
+
+// sql is the SQL condition of the comparison, with its arguments
+func (c scimComparison) sql(attributes map[string]scimAttribute) (string, []interface{}, error) {
+	a, ok := attributes[strings.ToLower(c.Attribute)]
+	if !ok {
+		return "", nil, scimInvalidFilter("The attribute %q can not be filtered.", c.Attribute)
+	}
+
+	if a.condition != "" {
+		if c.Operator != "eq" {
+			return "", nil, scimInvalidFilter("The attribute %q can only be compared with eq.", c.Attribute)
+		}
+		return a.condition, []interface{}{fmt.Sprint(c.Value)}, nil
+	}
+
+	column := a.column
+	if c.Operator == "pr" {
+		return column + " IS NOT NULL", nil, nil
+	}
+	if c.Value == nil {
+		switch c.Operator {
+		case "eq":
+			return column + " IS NULL", nil, nil
+		case "ne":
+			return column + " IS NOT NULL", nil, nil
+		}
+		return "", nil, scimInvalidFilter("The attribute %q can not be compared with null.", c.Attribute)
+	}
+
+	value := c.Value
+	if s, ok := value.(string); ok && !a.caseExact {
+		column, value = "LOWER("+column+")", strings.ToLower(s)
+	}
+
+	switch c.Operator {
+	case "eq":
+		return column + " = ?", []interface{}{value}, nil
+	case "ne":
+		return "(" + column + " IS NULL OR " + column + " <> ?)", []interface{}{value}, nil
+	case "gt":
+		return column + " > ?", []interface{}{value}, nil
+	case "ge":
+		return column + " >= ?", []interface{}{value}, nil
+	case "lt":
+		return column + " < ?", []interface{}{value}, nil
+	case "le":
+		return column + " <= ?", []interface{}{value}, nil
+	}
+
+	// co, sw and ew compare the strings
+	s, ok := value.(string)
+	if !ok {
+		return "", nil, scimInvalidFilter("The operator %q compares strings.", c.Operator)
+	}
+	s = escapeLike(s)
+	switch c.Operator {
+	case "co":
+		s = "%" + s + "%"
+	case "sw":
+		s = s + "%"
+	case "ew":
+		s = "%" + s
+	}
+	return column + " LIKE ?", []interface{}{s}, nil
+}
+
+// matches tells if a value of a multi-valued attribute, like an email
+// or a member of a group, matches the filter. The strings are compared
+// whatever their case.
+func (f ScimFilter) matches(value map[string]interface{}) bool {
+	for _, and := range f {
+		all := true
+		for _, c := range and {
+			if !c.matches(value) {
+				all = false
+				break
+			}
+		}
+		if all && len(and) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (c scimComparison) matches(value map[string]interface{}) bool {
+	v, ok := scimLookup(value, c.Attribute)
+	if c.Operator == "pr" {
+		return ok && v != nil && v != ""
+	}
+
+	actual, expected := strings.ToLower(fmt.Sprint(v)), strings.ToLower(fmt.Sprint(c.Value))
+	if !ok {
+		return c.Operator == "ne" || (c.Operator == "eq" && c.Value == nil)
+	}
+
+	switch c.Operator {
+	case "eq":
+		return actual == expected
+	case "ne":
+		return actual != expected
+	case "co":
+		return strings.Contains(actual, expected)
+	case "sw":
+		return strings.HasPrefix(actual, expected)
+	case "ew":
+		return strings.HasSuffix(actual, expected)
+	case "gt":
+		return actual > expected
+	case "ge":
+		return actual >= expected
+	case "lt":
+		return actual < expected
+	case "le":
+		return actual <= expected
+	}
+	return false
+}
+
+// scimLookup returns the attribute of a resource, whatever the case of its name
+func scimLookup(resource map[string]interface{}, name string) (interface{}, bool) {
+	for k, v := range resource {
+		if strings.EqualFold(k, name) {
+			return v, true
+		}
+	}
+	return nil, false
+}
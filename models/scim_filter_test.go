@@ -0,0 +1,76 @@
+package models
+
+import "github.com/gobuffalo/nulls"
+
+func (ms *ModelSuite) Test_ParseScimFilter() {
+	f, err := ParseScimFilter(`userName eq "jane@example.com" and active eq true or displayName sw "J"`)
+	ms.NoError(err)
+	ms.Equal(ScimFilter{
+		{{Attribute: "userName", Operator: "eq", Value: "jane@example.com"}, {Attribute: "active", Operator: "eq", Value: true}},
+		{{Attribute: "displayName", Operator: "sw", Value: "J"}},
+	}, f)
+
+	f, err = ParseScimFilter(`externalId PR`)
+	ms.NoError(err)
+	ms.Equal(ScimFilter{{{Attribute: "externalId", Operator: "pr"}}}, f)
+
+	for _, filter := range []string{
+		`userName`,
+		`userName eq`,
+		`userName is "jane"`,
+		`userName eq jane`,
+		`userName eq "jane`,
+		`userName eq "jane" and`,
+		`(userName eq "jane")`,
+		`userName eq "jane" xor active eq true`,
+	} {
+		_, err := ParseScimFilter(filter)
+		ms.Error(err, filter)
+		ms.Equal("invalidFilter", err.(*ScimError).ScimType, filter)
+	}
+}
+
+func (ms *ModelSuite) Test_ScimFilter_UserScope() {
+	jane := &Member{Name: "Jane Doe", Type: "employee", Role: "DevOps", Status: StatusActive, Email: nulls.NewString("Jane@example.com"), Tags: []string{"remote"}}
+	ms.NoError(DB.Create(jane))
+	john := &Member{Name: "John Doe", Type: "contractor", Role: "Developer", Status: StatusOffboarded}
+	ms.NoError(DB.Create(john))
+	ms.NoError(DB.Create(&ExternalID{MemberID: john.ID, System: ScimExternalSystem, ExternalID: "00u1"}))
+
+	tests := []struct {
+		filter string
+		names  []string
+	}{
+		{`userName eq "jane@EXAMPLE.com"`, []string{"Jane Doe"}},
+		{`displayName sw "j" and active eq true`, []string{"Jane Doe"}},
+		{`active eq false`, []string{"John Doe"}},
+		{`externalId eq "00u1"`, []string{"John Doe"}},
+		{`userName pr or displayName ew "doe"`, []string{"Jane Doe", "John Doe"}},
+		{`userName eq null`, []string{"John Doe"}},
+		{`displayName co "100%"`, []string{}},
+		{`urn:ietf:params:scim:schemas:extension:team_manager:2.0:User:type eq "contractor"`, []string{"John Doe"}},
+		{`urn:ietf:params:scim:schemas:extension:team_manager:2.0:User:tags eq "Remote"`, []string{"Jane Doe"}},
+	}
+
+	for _, tt := range tests {
+		f, err := ParseScimFilter(tt.filter)
+		ms.NoError(err, tt.filter)
+		scope, err := f.UserScope()
+		ms.NoError(err, tt.filter)
+
+		members := Members{}
+		ms.NoError(DB.Scope(scope).Order("name").All(&members), tt.filter)
+		names := []string{}
+		for _, m := range members {
+			names = append(names, m.Name)
+		}
+		ms.Equal(tt.names, names, tt.filter)
+	}
+
+	for _, filter := range []string{`password eq "secret"`, `groups ne "x"`, `displayName co 1`} {
+		f, err := ParseScimFilter(filter)
+		ms.NoError(err, filter)
+		_, err = f.UserScope()
+		ms.Error(err, filter)
+	}
+}
@@ -0,0 +1,269 @@
+package models
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// ScimPatch is a SCIM PATCH request, its operations are applied in order
+type ScimPatch struct {
+	Schemas    []string             `json:"schemas"`
+	Operations []ScimPatchOperation `json:"Operations"`
+}
+
+// ScimPatchOperation adds, replaces or removes the value at a path, like
+// active, name.givenName, emails[type eq "work"].value or members[value eq "<id>"].
+// Without a path, the attributes of the value are added or replaced.
+type ScimPatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// scimPath is a parsed path: an attribute, in the extension schema or not,
+// with a filter selecting some of its values and a sub-attribute of them
+type scimPath struct {
+	schema    string
+	attribute string
+	filter    ScimFilter
+	sub       string
+}
+
+// ApplyScimPatch applies the operations of the patch to the resource, as a JSON object,
+// and decodes the patched resource into the target (a ScimUser or a ScimGroup).
+// The errors are SCIM errors (invalidPath, invalidValue, noTarget...).
+func ApplyScimPatch(resource interface{}, patch ScimPatch, target interface{}) error {
+	b, err := json.Marshal(resource)
+	if err != nil {
+		return err
+	}
+
+	doc := map[string]interface{}{}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return err
+	}
+
+	for _, op := range patch.Operations {
+		if err := applyScimOperation(doc, op); err != nil {
+			return err
+		}
+	}
+
+	if b, err = json.Marshal(doc); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(b, target); err != nil {
+		return NewScimError(400, "invalidValue", "The patched resource is not valid: "+err.Error())
+	}
+	return nil
+}
+
+// applyScimOperation applies an operation to the resource
+func applyScimOperation(doc map[string]interface{}, op ScimPatchOperation) error {
+	kind := strings.ToLower(op.Op)
+	if kind != "add" && kind != "replace" && kind != "remove" {
+		return NewScimError(400, "invalidSyntax", "The operation "+op.Op+" is not supported, use add, replace or remove.")
+	}
+
+	if op.Path == "" {
+		if kind == "remove" {
+			return NewScimError(400, "noTarget", "A remove operation needs a path.")
+		}
+		values, ok := op.Value.(map[string]interface{})
+		if !ok {
+			return NewScimError(400, "invalidValue", "An operation without a path needs an object value.")
+		}
+		for k, v := range values {
+			if err := applyScimOperation(doc, ScimPatchOperation{Op: kind, Path: k, Value: v}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	path, err := parseScimPath(op.Path)
+	if err != nil {
+		return err
+	}
+
+	// the attributes of the extension are in an object named after its schema
+	container := doc
+	if path.schema != "" {
+		ext, _ := scimLookup(doc, path.schema)
+		extension, ok := ext.(map[string]interface{})
+		if !ok {
+			extension = map[string]interface{}{}
+		}
+		scimSet(doc, path.schema, extension)
+		container = extension
+
+		if path.attribute == "" {
+			if kind == "remove" {
+				scimDelete(doc, path.schema)
+				return nil
+			}
+			values, ok := op.Value.(map[string]interface{})
+			if !ok {
+				return NewScimError(400, "invalidValue", "The value of "+path.schema+" must be an object.")
+			}
+			for k, v := range values {
+				scimSet(container, k, v)
+			}
+			return nil
+		}
+	}
+
+	// some clients send active as a string, like "False"
+	if s, ok := op.Value.(string); ok && strings.EqualFold(path.attribute, "active") && path.sub == "" {
+		active, err := strconv.ParseBool(s)
+		if err != nil {
+			return NewScimError(400, "invalidValue", "The value of active must be true or false.")
+		}
+		op.Value = active
+	}
+
+	current, _ := scimLookup(container, path.attribute)
+
+	if path.filter == nil {
+		switch kind {
+		case "remove":
+			if path.sub == "" {
+				scimDelete(container, path.attribute)
+			} else if parent, ok := current.(map[string]interface{}); ok {
+				scimDelete(parent, path.sub)
+			}
+		case "add":
+			// a value added to a multi-valued attribute joins its values
+			if list, ok := current.([]interface{}); ok && path.sub == "" {
+				if values, ok := op.Value.([]interface{}); ok {
+					scimSet(container, path.attribute, append(list, values...))
+				} else {
+					scimSet(container, path.attribute, append(list, op.Value))
+				}
+				return nil
+			}
+			fallthrough
+		case "replace":
+			if path.sub == "" {
+				scimSet(container, path.attribute, op.Value)
+				return nil
+			}
+			parent, ok := current.(map[string]interface{})
+			if !ok {
+				parent = map[string]interface{}{}
+			}
+			scimSet(parent, path.sub, op.Value)
+			scimSet(container, path.attribute, parent)
+		}
+		return nil
+	}
+
+	// the values of a multi-valued attribute matching the filter
+	list, _ := current.([]interface{})
+	kept, matched := []interface{}{}, 0
+	for _, item := range list {
+		value, ok := item.(map[string]interface{})
+		if !ok || !path.filter.matches(value) {
+			kept = append(kept, item)
+			continue
+		}
+		matched++
+
+		switch {
+		case kind == "remove" && path.sub == "":
+			continue
+		case kind == "remove":
+			scimDelete(value, path.sub)
+		case path.sub == "":
+			replacement, ok := op.Value.(map[string]interface{})
+			if !ok {
+				return NewScimError(400, "invalidValue", "The value of "+op.Path+" must be an object.")
+			}
+			if kind == "add" {
+				for k, v := range replacement {
+					scimSet(value, k, v)
+				}
+			} else {
+				value = replacement
+			}
+		default:
+			scimSet(value, path.sub, op.Value)
+		}
+		kept = append(kept, value)
+	}
+
+	if matched == 0 && kind != "remove" {
+		return NewScimError(400, "noTarget", "No value matches "+op.Path+".")
+	}
+
+	scimSet(container, path.attribute, kept)
+	return nil
+}
+
+// parseScimPath reads a path like name.givenName, emails[type eq "work"].value
+// or urn:ietf:params:scim:schemas:extension:team_manager:2.0:User:role.
+// The filters of the paths are the filters of the lists (see ParseScimFilter).
+func parseScimPath(p string) (scimPath, error) {
+	path := scimPath{}
+
+	// the attributes of the core schemas may be prefixed with their schema
+	for _, schema := range []string{ScimUserSchema, ScimGroupSchema} {
+		if strings.HasPrefix(strings.ToLower(p), strings.ToLower(schema)+":") {
+			p = p[len(schema)+1:]
+		}
+	}
+
+	if strings.HasPrefix(strings.ToLower(p), strings.ToLower(ScimUserExtensionSchema)) {
+		path.schema = ScimUserExtensionSchema
+		p = strings.TrimPrefix(p[len(ScimUserExtensionSchema):], ":")
+		if p == "" {
+			return path, nil
+		}
+	}
+
+	if open := strings.Index(p, "["); open >= 0 {
+		end := strings.LastIndex(p, "]")
+		if end < open {
+			return path, NewScimError(400, "invalidPath", "The filter of "+p+" is not closed.")
+		}
+
+		filter, err := ParseScimFilter(p[open+1 : end])
+		if err != nil {
+			return path, NewScimError(400, "invalidPath", "The filter of "+p+" is not valid: "+err.Error())
+		}
+
+		path.attribute, path.filter = p[:open], filter
+		path.sub = strings.TrimPrefix(p[end+1:], ".")
+	} else if dot := strings.Index(p, "."); dot >= 0 {
+		path.attribute, path.sub = p[:dot], p[dot+1:]
+	} else {
+		path.attribute = p
+	}
+
+	if path.attribute == "" || strings.ContainsAny(path.attribute+path.sub, "[]. ") {
+		return path, NewScimError(400, "invalidPath", "The path "+p+" is not valid.")
+	}
+
+	return path, nil
+}
+
+// scimSet sets the attribute of a resource, keeping the case of its name if it is already set
+func scimSet(resource map[string]interface{}, name string, value interface{}) {
+	for k := range resource {
+		if strings.EqualFold(k, name) {
+			resource[k] = value
+			return
+		}
+	}
+	resource[name] = value
+}
+
+// scimDelete removes the attribute of a resource, whatever the case of its name
+func scimDelete(resource map[string]interface{}, name string) {
+	for k := range resource {
+		if strings.EqualFold(k, name) {
+			delete(resource, k)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package models
+
+func (ms *ModelSuite) Test_ApplyScimPatch() {
+	active := true
+	u := ScimUser{
+		Schemas:  []string{ScimUserSchema, ScimUserExtensionSchema},
+		UserName: "jane@example.com",
+		Name:     &ScimName{Formatted: "Jane Doe"},
+		Active:   &active,
+		Emails: []ScimValue{
+			{Value: "jane@example.com", Type: "work", Primary: true},
+			{Value: "jane@home.example", Type: "home"},
+		},
+		Extension: &ScimUserExtension{Type: "employee", Role: "DevOps"},
+	}
+
+	patched := ScimUser{}
+	ms.NoError(ApplyScimPatch(u, ScimPatch{Operations: []ScimPatchOperation{
+		{Op: "Replace", Path: "active", Value: "False"},
+		{Op: "replace", Path: "name.formatted", Value: "Jane Smith"},
+		{Op: "replace", Path: `emails[type eq "work"].value`, Value: "jane.smith@example.com"},
+		{Op: "remove", Path: `emails[type eq "home"]`},
+		{Op: "add", Path: "phoneNumbers", Value: []interface{}{map[string]interface{}{"value": "+14155552671"}}},
+		{Op: "replace", Path: "urn:ietf:params:scim:schemas:extension:team_manager:2.0:User:role", Value: "SRE"},
+		{Op: "add", Value: map[string]interface{}{"timezone": "Europe/Paris", "urn:ietf:params:scim:schemas:core:2.0:User:displayName": "Jane"}},
+	}}, &patched))
+
+	ms.False(*patched.Active)
+	ms.Equal("Jane Smith", patched.Name.Formatted)
+	ms.Equal([]ScimValue{{Value: "jane.smith@example.com", Type: "work", Primary: true}}, patched.Emails)
+	ms.Equal([]ScimValue{{Value: "+14155552671"}}, patched.PhoneNumbers)
+	ms.Equal("SRE", patched.Extension.Role)
+	ms.Equal("employee", patched.Extension.Type)
+	ms.Equal("Europe/Paris", patched.Timezone)
+	ms.Equal("Jane", patched.DisplayName)
+
+	// the members of a group are added and removed one by one
+	g := ScimGroup{DisplayName: "Platform", Members: []ScimReference{{Value: "a"}, {Value: "b"}}}
+	patchedGroup := ScimGroup{}
+	ms.NoError(ApplyScimPatch(g, ScimPatch{Operations: []ScimPatchOperation{
+		{Op: "add", Path: "members", Value: []interface{}{map[string]interface{}{"value": "c"}}},
+		{Op: "remove", Path: `members[value eq "a"]`},
+	}}, &patchedGroup))
+	ms.Equal([]ScimReference{{Value: "b"}, {Value: "c"}}, patchedGroup.Members)
+
+	tests := []struct {
+		op       ScimPatchOperation
+		scimType string
+	}{
+		{ScimPatchOperation{Op: "move", Path: "active"}, "invalidSyntax"},
+		{ScimPatchOperation{Op: "remove"}, "noTarget"},
+		{ScimPatchOperation{Op: "replace", Value: "Jane"}, "invalidValue"},
+		{ScimPatchOperation{Op: "replace", Path: "emails[type eq \"work\"", Value: "x"}, "invalidPath"},
+		{ScimPatchOperation{Op: "replace", Path: "name.given name", Value: "x"}, "invalidPath"},
+		{ScimPatchOperation{Op: "replace", Path: `emails[type eq "other"].value`, Value: "x"}, "noTarget"},
+		{ScimPatchOperation{Op: "replace", Path: "active", Value: "maybe"}, "invalidValue"},
+		{ScimPatchOperation{Op: "replace", Path: "userName", Value: 42}, "invalidValue"},
+	}
+
+	for _, tt := range tests {
+		err := ApplyScimPatch(u, ScimPatch{Operations: []ScimPatchOperation{tt.op}}, &ScimUser{})
+		ms.Error(err, tt.op.Path)
+		ms.Equal(tt.scimType, err.(*ScimError).ScimType, tt.op.Path)
+	}
+}
@@ -0,0 +1,275 @@
+package models
+
+// Schemas of the SCIM discovery resources (RFC 7643, sections 5 to 7)
+const (
+	ScimServiceProviderConfigSchema = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	ScimResourceTypeSchema          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+	ScimSchemaSchema                = "urn:ietf:params:scim:schemas:core:2.0:Schema"
+)
+
+// ScimMaxResults is the largest page of a SCIM list
+const ScimMaxResults = 200
+
+// ScimSupported tells if a SCIM feature is supported
+type ScimSupported struct {
+	Supported bool `json:"supported"`
+}
+
+// ScimFilterConfig tells if the filters are supported, and the largest page of a list
+type ScimFilterConfig struct {
+	Supported  bool `json:"supported"`
+	MaxResults int  `json:"maxResults"`
+}
+
+// ScimBulkConfig tells if the bulk operations are supported
+type ScimBulkConfig struct {
+	Supported      bool `json:"supported"`
+	MaxOperations  int  `json:"maxOperations"`
+	MaxPayloadSize int  `json:"maxPayloadSize"`
+}
+
+// ScimAuthenticationScheme is a way for the SCIM clients to authenticate
+type ScimAuthenticationScheme struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Primary     bool   `json:"primary,omitempty"`
+}
+
+// ScimServiceProviderConfig tells the SCIM clients what the service supports
+type ScimServiceProviderConfig struct {
+	Schemas               []string                   `json:"schemas"`
+	Patch                 ScimSupported              `json:"patch"`
+	Bulk                  ScimBulkConfig             `json:"bulk"`
+	Filter                ScimFilterConfig           `json:"filter"`
+	ChangePassword        ScimSupported              `json:"changePassword"`
+	Sort                  ScimSupported              `json:"sort"`
+	Etag                  ScimSupported              `json:"etag"`
+	AuthenticationSchemes []ScimAuthenticationScheme `json:"authenticationSchemes"`
+	Meta                  *ScimMeta                  `json:"meta,omitempty"`
+}
+
+// ScimSchemaExtension is an extension schema of a resource type
+type ScimSchemaExtension struct {
+	Schema   string `json:"schema"`
+	Required bool   `json:"required"`
+}
+
+// ScimResourceType is a type of SCIM resource, and where it is
+type ScimResourceType struct {
+	Schemas          []string              `json:"schemas"`
+	ID               string                `json:"id"`
+	Name             string                `json:"name"`
+	Endpoint         string                `json:"endpoint"`
+	Description      string                `json:"description"`
+	Schema           string                `json:"schema"`
+	SchemaExtensions []ScimSchemaExtension `json:"schemaExtensions,omitempty"`
+	Meta             *ScimMeta             `json:"meta,omitempty"`
+}
+
+// ScimSchemaAttribute is an attribute of a SCIM schema
+type ScimSchemaAttribute struct {
+	Name            string                `json:"name"`
+	Type            string                `json:"type"`
+	MultiValued     bool                  `json:"multiValued"`
+	Description     string                `json:"description"`
+	Required        bool                  `json:"required"`
+	CaseExact       bool                  `json:"caseExact"`
+	Mutability      string                `json:"mutability"`
+	Returned        string                `json:"returned"`
+	Uniqueness      string                `json:"uniqueness"`
+	CanonicalValues []string              `json:"canonicalValues,omitempty"`
+	ReferenceTypes  []string              `json:"referenceTypes,omitempty"`
+	SubAttributes   []ScimSchemaAttribute `json:"subAttributes,omitempty"`
+}
+
+// ScimSchema is the definition of the attributes of a SCIM resource
+type ScimSchema struct {
+	Schemas     []string              `json:"schemas"`
+	ID          string                `json:"id"`
+	Name        string                `json:"name"`
+	Description string                `json:"description"`
+	Attributes  []ScimSchemaAttribute `json:"attributes"`
+	Meta        *ScimMeta             `json:"meta,omitempty"`
+}
+
+// NewScimServiceProviderConfig returns what the service supports,
+// the clients authenticate with a bearer token when one is required
+func NewScimServiceProviderConfig(bearer bool) ScimServiceProviderConfig {
+	c := ScimServiceProviderConfig{
+		Schemas:               []string{ScimServiceProviderConfigSchema},
+		Patch:                 ScimSupported{Supported: true},
+		Filter:                ScimFilterConfig{Supported: true, MaxResults: ScimMaxResults},
+		AuthenticationSchemes: []ScimAuthenticationScheme{},
+		Meta:                  &ScimMeta{ResourceType: "ServiceProviderConfig"},
+	}
+
+	if bearer {
+		c.AuthenticationSchemes = append(c.AuthenticationSchemes, ScimAuthenticationScheme{
+			Type:        "oauthbearertoken",
+			Name:        "Bearer token",
+			Description: "The token of the SCIM_TOKEN variable, in the Authorization header",
+			Primary:     true,
+		})
+	}
+
+	return c
+}
+
+// ScimResourceTypes are the types of the SCIM resources: the users and the groups
+func ScimResourceTypes() []ScimResourceType {
+	return []ScimResourceType{
+		{
+			Schemas:          []string{ScimResourceTypeSchema},
+			ID:               "User",
+			Name:             "User",
+			Endpoint:         "/Users",
+			Description:      "Members",
+			Schema:           ScimUserSchema,
+			SchemaExtensions: []ScimSchemaExtension{{Schema: ScimUserExtensionSchema}},
+			Meta:             &ScimMeta{ResourceType: "ResourceType"},
+		},
+		{
+			Schemas:     []string{ScimResourceTypeSchema},
+			ID:          "Group",
+			Name:        "Group",
+			Endpoint:    "/Groups",
+			Description: "Teams",
+			Schema:      ScimGroupSchema,
+			Meta:        &ScimMeta{ResourceType: "ResourceType"},
+		},
+	}
+}
+
+// scimSchemaAttribute returns a SCIM schema attribute with the usual defaults:
+// a single-valued, optional, read-write attribute, returned by default
+func scimSchemaAttribute(name, kind, description string) ScimSchemaAttribute {
+	return ScimSchemaAttribute{
+		Name:        name,
+		Type:        kind,
+		Description: description,
+		Mutability:  "readWrite",
+		Returned:    "default",
+		Uniqueness:  "none",
+	}
+}
+
+// scimMultiValued returns a multi-valued attribute of values (see ScimValue)
+func scimMultiValued(name, description string) ScimSchemaAttribute {
+	a := scimSchemaAttribute(name, "complex", description)
+	a.MultiValued = true
+	a.SubAttributes = []ScimSchemaAttribute{
+		scimSchemaAttribute("value", "string", "The value"),
+		scimSchemaAttribute("type", "string", "The type of the value, like work"),
+		scimSchemaAttribute("primary", "boolean", "Whether it is the value used"),
+	}
+	return a
+}
+
+// scimReferences returns a multi-valued attribute of references to resources (see ScimReference)
+func scimReferences(name, description, referenceType, mutability string) ScimSchemaAttribute {
+	value := scimSchemaAttribute("value", "string", "The id of the "+referenceType)
+	value.Mutability = mutability
+
+	ref := scimSchemaAttribute("$ref", "reference", "The URL of the "+referenceType)
+	ref.ReferenceTypes, ref.Mutability = []string{referenceType}, mutability
+
+	display := scimSchemaAttribute("display", "string", "The name of the "+referenceType)
+	display.Mutability = "readOnly"
+
+	a := scimSchemaAttribute(name, "complex", description)
+	a.MultiValued, a.Mutability = true, mutability
+	a.SubAttributes = []ScimSchemaAttribute{value, ref, display}
+	return a
+}
+
+// ScimSchemas are the schemas of the SCIM resources: the users, their extension and the groups
+func ScimSchemas() []ScimSchema {
+	id := scimSchemaAttribute("id", "string", "The id of the resource")
+	id.CaseExact, id.Mutability, id.Returned, id.Uniqueness = true, "readOnly", "always", "server"
+
+	externalID := scimSchemaAttribute("externalId", "string", "The id of the resource in the client")
+	externalID.CaseExact = true
+
+	userName := scimSchemaAttribute("userName", "string", "The email of the member")
+	userName.Required, userName.Uniqueness = true, "server"
+
+	name := scimSchemaAttribute("name", "complex", "The name of the member")
+	name.SubAttributes = []ScimSchemaAttribute{
+		scimSchemaAttribute("formatted", "string", "The full name"),
+		scimSchemaAttribute("familyName", "string", "The family name, read when there is no formatted name"),
+		scimSchemaAttribute("givenName", "string", "The given name, read when there is no formatted name"),
+	}
+
+	addresses := scimSchemaAttribute("addresses", "complex", "The location of the member")
+	addresses.MultiValued = true
+	addresses.SubAttributes = []ScimSchemaAttribute{
+		scimSchemaAttribute("formatted", "string", "The location"),
+		scimSchemaAttribute("locality", "string", "The city, read when there is no formatted location"),
+		scimSchemaAttribute("type", "string", "The type of the address, like work"),
+		scimSchemaAttribute("primary", "boolean", "Whether it is the location"),
+	}
+
+	memberType := scimSchemaAttribute("type", "string", "The type of the member")
+	memberType.Required, memberType.CanonicalValues = true, MemberTypeNames()
+
+	tags := scimSchemaAttribute("tags", "string", "The tags of the member")
+	tags.MultiValued = true
+
+	status := scimSchemaAttribute("status", "string", "The status of the member, changed with active")
+	status.Mutability, status.CanonicalValues = "readOnly", MemberStatuses
+
+	displayName := scimSchemaAttribute("displayName", "string", "The name of the team")
+	displayName.Required, displayName.Uniqueness = true, "server"
+
+	return []ScimSchema{
+		{
+			Schemas:     []string{ScimSchemaSchema},
+			ID:          ScimUserSchema,
+			Name:        "User",
+			Description: "Member",
+			Attributes: []ScimSchemaAttribute{
+				id,
+				externalID,
+				userName,
+				name,
+				scimSchemaAttribute("displayName", "string", "The name of the member, read before name"),
+				scimSchemaAttribute("active", "boolean", "Whether the member is onboarding, active or on leave"),
+				scimMultiValued("emails", "The email of the member, read when there is no userName"),
+				scimMultiValued("phoneNumbers", "The phone number of the member, in E.164 format"),
+				scimMultiValued("ims", "The chat handle of the member"),
+				addresses,
+				scimSchemaAttribute("timezone", "string", "The IANA time zone of the member"),
+				scimReferences("groups", "The teams of the member", "Group", "readOnly"),
+			},
+			Meta: &ScimMeta{ResourceType: "Schema"},
+		},
+		{
+			Schemas:     []string{ScimSchemaSchema},
+			ID:          ScimUserExtensionSchema,
+			Name:        "TeamManagerUser",
+			Description: "The fields of a member SCIM does not define",
+			Attributes: []ScimSchemaAttribute{
+				memberType,
+				scimSchemaAttribute("role", "string", "The role of the member"),
+				tags,
+				scimSchemaAttribute("contractStart", "dateTime", "The start of the contract of the member, a date"),
+				scimSchemaAttribute("contractEnd", "dateTime", "The end of the contract of the member, a date"),
+				status,
+			},
+			Meta: &ScimMeta{ResourceType: "Schema"},
+		},
+		{
+			Schemas:     []string{ScimSchemaSchema},
+			ID:          ScimGroupSchema,
+			Name:        "Group",
+			Description: "Team",
+			Attributes: []ScimSchemaAttribute{
+				id,
+				displayName,
+				scimReferences("members", "The members of the team", "User", "readWrite"),
+			},
+			Meta: &ScimMeta{ResourceType: "Schema"},
+		},
+	}
+}
@@ -0,0 +1,110 @@
+package models
+
+import "github.com/gobuffalo/envy"
+
+func (ms *ModelSuite) Test_SaveScimUser() {
+	inactive := false
+	u := ScimUser{
+		ExternalID:   "00u1",
+		UserName:     "jane@example.com",
+		Name:         &ScimName{GivenName: "Jane", FamilyName: "Doe"},
+		Active:       &inactive,
+		PhoneNumbers: []ScimValue{{Value: "+1 415 555 0100"}, {Value: "+14155552671", Primary: true}},
+		Addresses:    []ScimAddress{{Locality: "Paris"}},
+		Extension:    &ScimUserExtension{Type: "employee", Role: "DevOps", Tags: []string{"remote"}},
+	}
+
+	m := &Member{}
+	verrs, err := SaveScimUser(DB, m, u)
+	ms.NoError(err)
+	ms.False(verrs.HasAny(), verrs.Error())
+	ms.Equal("Jane Doe", m.Name)
+	ms.Equal("jane@example.com", m.Email.String)
+	ms.Equal("+14155552671", m.Phone.String)
+	ms.Equal("Paris", m.Location.String)
+	ms.Equal(StatusCandidate, m.Status)
+
+	found, err := FindMemberByExternalID(DB, ScimExternalSystem, "00u1")
+	ms.NoError(err)
+	ms.Equal(m.ID, found.ID)
+
+	// activated, without the extension its fields are kept
+	active := true
+	verrs, err = SaveScimUser(DB, m, ScimUser{UserName: "jane@example.com", DisplayName: "Jane Doe", Active: &active})
+	ms.NoError(err)
+	ms.False(verrs.HasAny(), verrs.Error())
+	ms.Equal("employee", m.Type)
+	ms.Equal("DevOps", m.Role)
+	ms.Equal([]string{"remote"}, []string(m.Tags))
+	ms.Equal(StatusOnboarding, m.Status)
+	ms.False(m.Phone.Valid)
+
+	ids, err := FindScimExternalIDs(DB, Members{*m})
+	ms.NoError(err)
+	ms.Empty(ids)
+
+	// the type does not change
+	verrs, err = SaveScimUser(DB, m, ScimUser{UserName: "jane@example.com", DisplayName: "Jane Doe", Extension: &ScimUserExtension{Type: "contractor", ContractStart: "2026-01-01", ContractEnd: "2026-12-31"}})
+	ms.Equal("mutability", err.(*ScimError).ScimType)
+	ms.False(verrs.HasAny())
+
+	ms.NoError(DB.Reload(m))
+	ms.Equal("employee", m.Type)
+	ms.Equal("DevOps", m.Role)
+
+	// deactivated
+	verrs, err = SaveScimUser(DB, m, ScimUser{UserName: "jane@example.com", DisplayName: "Jane Doe", Active: &inactive})
+	ms.NoError(err)
+	ms.False(verrs.HasAny(), verrs.Error())
+	ms.Equal(StatusOffboarded, m.Status)
+
+	verrs, err = SaveScimUser(DB, &Member{}, ScimUser{UserName: "not an email", DisplayName: "John Doe", Extension: &ScimUserExtension{Type: "employee"}})
+	ms.NoError(err)
+	ms.NotEmpty(verrs.Get("email"))
+
+	_, err = SaveScimUser(DB, &Member{}, ScimUser{UserName: "john@example.com", Extension: &ScimUserExtension{Type: "employee", ContractStart: "soon"}})
+	ms.Equal("invalidValue", err.(*ScimError).ScimType)
+}
+
+func (ms *ModelSuite) Test_SaveScimUser_DefaultType() {
+	u := ScimUser{UserName: "jane@example.com", DisplayName: "Jane Doe", Extension: &ScimUserExtension{Role: "DevOps"}}
+
+	verrs, err := SaveScimUser(DB, &Member{}, u)
+	ms.NoError(err)
+	ms.NotEmpty(verrs.Get("type"))
+
+	defaultType := envy.Get("SCIM_DEFAULT_TYPE", "")
+	envy.Set("SCIM_DEFAULT_TYPE", "employee")
+	defer envy.Set("SCIM_DEFAULT_TYPE", defaultType)
+
+	m := &Member{}
+	verrs, err = SaveScimUser(DB, m, u)
+	ms.NoError(err)
+	ms.False(verrs.HasAny(), verrs.Error())
+	ms.Equal("employee", m.Type)
+}
+
+func (ms *ModelSuite) Test_SaveScimGroup() {
+	jane := &Member{Name: "Jane Doe", Type: "employee", Role: "DevOps"}
+	ms.NoError(DB.Create(jane))
+	john := &Member{Name: "John Doe", Type: "employee", Role: "DevOps"}
+	ms.NoError(DB.Create(john))
+
+	t := &Team{}
+	verrs, err := SaveScimGroup(DB, t, ScimGroup{DisplayName: "Platform", Members: []ScimReference{{Value: jane.ID.String()}, {Value: jane.ID.String()}}})
+	ms.NoError(err)
+	ms.False(verrs.HasAny(), verrs.Error())
+
+	verrs, err = SaveScimGroup(DB, t, ScimGroup{DisplayName: "Platform Team", Members: []ScimReference{{Value: john.ID.String()}}})
+	ms.NoError(err)
+	ms.False(verrs.HasAny(), verrs.Error())
+
+	ms.NoError(DB.Load(t, "Members"))
+	ms.Equal("Platform Team", t.Name)
+	ms.Len(t.Members, 1)
+	ms.Equal(john.ID, t.Members[0].ID)
+
+	verrs, err = SaveScimGroup(DB, t, ScimGroup{DisplayName: "Platform Team", Members: []ScimReference{{Value: "unknown"}}})
+	ms.NoError(err)
+	ms.NotEmpty(verrs.Get("members"))
+}
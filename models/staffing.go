@@ -0,0 +1,163 @@
+package models
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/slices"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gofrs/uuid"
+)
+
+const (
+	staffingDefaultLimit = 20
+	staffingMaxLimit     = 100
+)
+
+// StaffingSearch looks for the members available on a date window
+// with the required tags, the more nice-to-have tags they have the better
+type StaffingSearch struct {
+	Required      []string   `json:"required" xml:"required"`
+	NiceToHave    []string   `json:"nice_to_have" xml:"nice_to_have"`
+	Type          string     `json:"type" xml:"type" enums:"employee,contractor,intern,vendor,advisor"`
+	From          time.Time  `json:"from" xml:"from"`
+	To            nulls.Time `json:"to" xml:"to" swaggertype:"string" format:"date-time"`
+	MinAllocation int        `json:"min_allocation" xml:"min_allocation" minimum:"1" maximum:"100"`
+	Limit         int        `json:"limit" xml:"limit" minimum:"1" maximum:"100"`
+}
+
+// StaffingCandidate is a member found by a staffing search
+type StaffingCandidate struct {
+	Member         Member   `json:"member" xml:"member"`
+	Score          int      `json:"score" xml:"score"`
+	Matched        []string `json:"matched" xml:"matched"`
+	FreeAllocation int      `json:"free_allocation" xml:"free_allocation"`
+}
+
+// StaffingCandidates is the ranked result of a staffing search
+type StaffingCandidates []StaffingCandidate
+
+// Validate the search, and set the defaults: from today, at least 1% free and 20 candidates
+func (s *StaffingSearch) Validate() *validate.Errors {
+	verrs := validate.NewErrors()
+
+	if s.From.IsZero() {
+		s.From = time.Now()
+	}
+	s.From = truncateDate(s.From)
+	if s.To.Valid {
+		s.To.Time = truncateDate(s.To.Time)
+		if s.To.Time.Before(s.From) {
+			verrs.Add("to", "To must not be before from.")
+		}
+	}
+
+	if _, ok := findMemberType(s.Type); s.Type != "" && !ok {
+		verrs.Add("type", memberTypeInvalid())
+	}
+
+	if s.MinAllocation == 0 {
+		s.MinAllocation = 1
+	}
+	if s.MinAllocation < 1 || s.MinAllocation > MaxAllocation {
+		verrs.Add("min_allocation", "Min allocation must be between 1 and 100.")
+	}
+
+	if s.Limit == 0 {
+		s.Limit = staffingDefaultLimit
+	}
+	if s.Limit < 1 || s.Limit > staffingMaxLimit {
+		verrs.Add("limit", "Limit must be between 1 and 100.")
+	}
+
+	s.Required = normalizeTags(s.Required)
+	s.NiceToHave = normalizeTags(s.NiceToHave)
+
+	return verrs
+}
+
+// SearchStaffing returns the active members having every required tag and at least
+// MinAllocation free over the whole window, a contractor until the end of the window
+// (or its start, when it has no end), ranked by:
+// their score (the share of the required and nice-to-have tags they have),
+// the member type preferred, then the most free allocation
+func SearchStaffing(tx *pop.Connection, s StaffingSearch) (StaffingCandidates, error) {
+	last := s.From
+	if s.To.Valid {
+		last = s.To.Time
+	}
+
+	members := Members{}
+	q := tx.Scope(MemberFilter{Tags: slices.String(s.Required), Status: slices.String(activeStatuses)}.Scope).
+		Where("(members.contract_end IS NULL OR members.contract_end >= ?)", last)
+	if err := q.All(&members); err != nil {
+		return nil, err
+	}
+
+	assignments, err := findAssignmentsBetween(tx.Q(), s.From, s.To)
+	if err != nil {
+		return nil, err
+	}
+
+	byMember := map[uuid.UUID]Assignments{}
+	for _, a := range assignments {
+		byMember[a.MemberID] = append(byMember[a.MemberID], a)
+	}
+
+	wanted := len(s.Required) + len(s.NiceToHave)
+	candidates := StaffingCandidates{}
+	for _, m := range members {
+		free := MaxAllocation - peakAllocation(byMember[m.ID], s.From, s.To)
+		if free < s.MinAllocation {
+			continue
+		}
+
+		matched := append([]string{}, s.Required...)
+		for _, t := range s.NiceToHave {
+			if contains(m.Tags, t) {
+				matched = append(matched, t)
+			}
+		}
+
+		score := 100
+		if wanted > 0 {
+			score = 100 * len(matched) / wanted
+		}
+
+		candidates = append(candidates, StaffingCandidate{Member: m, Score: score, Matched: matched, FreeAllocation: free})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if s.Type != "" && (a.Member.Type == s.Type) != (b.Member.Type == s.Type) {
+			return a.Member.Type == s.Type
+		}
+		if a.FreeAllocation != b.FreeAllocation {
+			return a.FreeAllocation > b.FreeAllocation
+		}
+		return a.Member.Name < b.Member.Name
+	})
+
+	if len(candidates) > s.Limit {
+		candidates = candidates[:s.Limit]
+	}
+
+	return candidates, nil
+}
+
+// normalizeTags lower cases the tags, as they are stored, and drops the blank ones
+func normalizeTags(tags []string) []string {
+	normalized := []string{}
+	for _, t := range tags {
+		if t = strings.ToLower(strings.TrimSpace(t)); t != "" {
+			normalized = append(normalized, t)
+		}
+	}
+	return normalized
+}
@@ -0,0 +1,109 @@
+package models
+
+import (
+	"time"
+
+	"github.com/gobuffalo/nulls"
+)
+
+func (ms *ModelSuite) Test_SearchStaffing() {
+	ms.LoadFixture("projects")
+
+	july := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	// the developer has 60% free from July, the contractor 100%
+	s := StaffingSearch{NiceToHave: []string{"GoLang"}, From: july}
+	ms.Equal(false, s.Validate().HasAny())
+	candidates, err := SearchStaffing(DB, s)
+	ms.NoError(err)
+	ms.Equal(2, len(candidates))
+	ms.Equal("Staffed Developer", candidates[0].Member.Name)
+	ms.Equal(100, candidates[0].Score)
+	ms.Equal([]string{"golang"}, candidates[0].Matched)
+	ms.Equal(60, candidates[0].FreeAllocation)
+	ms.Equal(0, candidates[1].Score)
+
+	// same score, the member type preferred comes first
+	s = StaffingSearch{Type: "employee", From: july}
+	ms.Equal(false, s.Validate().HasAny())
+	candidates, err = SearchStaffing(DB, s)
+	ms.NoError(err)
+	ms.Equal("Staffed Developer", candidates[0].Member.Name)
+
+	s = StaffingSearch{From: july}
+	ms.Equal(false, s.Validate().HasAny())
+	candidates, err = SearchStaffing(DB, s)
+	ms.NoError(err)
+	ms.Equal("Free Contractor", candidates[0].Member.Name)
+
+	// the developer is allocated 100% in April
+	april := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	s = StaffingSearch{From: april, To: nulls.NewTime(april.AddDate(0, 0, 29))}
+	ms.Equal(false, s.Validate().HasAny())
+	candidates, err = SearchStaffing(DB, s)
+	ms.NoError(err)
+	ms.Equal(1, len(candidates))
+	ms.Equal("Free Contractor", candidates[0].Member.Name)
+
+	// the developer has the tag, not enough free allocation
+	s = StaffingSearch{Required: []string{"golang"}, From: july, MinAllocation: 70}
+	ms.Equal(false, s.Validate().HasAny())
+	candidates, err = SearchStaffing(DB, s)
+	ms.NoError(err)
+	ms.Equal(0, len(candidates))
+}
+
+func (ms *ModelSuite) Test_SearchStaffing_Available() {
+	ms.LoadFixture("projects")
+
+	july := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	// a contractor whose contract ends in June, and a member leaving
+	short := &Member{Name: "Short Contractor", Type: "contractor", ContractStart: nulls.NewTime(july.AddDate(0, -6, 0)), ContractEnd: nulls.NewTime(july.AddDate(0, 0, -1))}
+	verrs, err := DB.ValidateAndCreate(short)
+	ms.NoError(err)
+	ms.False(verrs.HasAny())
+
+	leaving := &Member{Name: "Leaving Engineer", Type: "employee", Role: "Software Engineer"}
+	verrs, err = DB.ValidateAndCreate(leaving)
+	ms.NoError(err)
+	ms.False(verrs.HasAny())
+	ms.NoError(DB.RawQuery("UPDATE members SET status = ? WHERE id = ?", StatusOffboarding, leaving.ID).Exec())
+
+	s := StaffingSearch{From: july}
+	ms.Equal(false, s.Validate().HasAny())
+	candidates, err := SearchStaffing(DB, s)
+	ms.NoError(err)
+	ms.Equal(2, len(candidates))
+	for _, c := range candidates {
+		ms.NotEqual(short.ID, c.Member.ID)
+		ms.NotEqual(leaving.ID, c.Member.ID)
+	}
+
+	// the contract of the Free Contractor ends before the end of the window
+	s = StaffingSearch{From: july, To: nulls.NewTime(july.AddDate(1, 0, 0))}
+	ms.Equal(false, s.Validate().HasAny())
+	candidates, err = SearchStaffing(DB, s)
+	ms.NoError(err)
+	ms.Equal(1, len(candidates))
+	ms.Equal("Staffed Developer", candidates[0].Member.Name)
+
+	// the short contract covers June, when the developer is allocated 100%
+	june := july.AddDate(0, -1, 0)
+	s = StaffingSearch{From: june, To: nulls.NewTime(june.AddDate(0, 0, 29))}
+	ms.Equal(false, s.Validate().HasAny())
+	candidates, err = SearchStaffing(DB, s)
+	ms.NoError(err)
+	ms.Equal(2, len(candidates))
+}
+
+func (ms *ModelSuite) Test_StaffingSearch_Validate() {
+	now := time.Now()
+	s := StaffingSearch{Type: "intern", From: now, To: nulls.NewTime(now.AddDate(0, 0, -1)), MinAllocation: 120, Limit: 1000}
+
+	verrs := s.Validate()
+	ms.Contains(verrs.Get("type"), memberTypeInvalid())
+	ms.Contains(verrs.Get("to"), "To must not be before from.")
+	ms.Contains(verrs.Get("min_allocation"), "Min allocation must be between 1 and 100.")
+	ms.Contains(verrs.Get("limit"), "Limit must be between 1 and 100.")
+}
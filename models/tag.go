@@ -0,0 +1,25 @@
+package models
+
+import "github.com/gobuffalo/pop/v5"
+
+// Tag is a tag given to members, with how many members have it.
+// The tags are not stored on their own, they are the tags of the members.
+type Tag struct {
+	Name        string `json:"name" db:"name"`
+	MemberCount int    `json:"member_count" db:"member_count"`
+}
+
+// Tags is a list of tags
+type Tags []Tag
+
+// tagsQuery selects every tag of the members, by name
+const tagsQuery = `SELECT tag AS name, COUNT(*) AS member_count
+	FROM members, UNNEST(members.tags) AS tag
+	GROUP BY tag ORDER BY tag`
+
+// FindTags returns the tags of the members, by name
+func FindTags(tx *pop.Connection) (Tags, error) {
+	tags := Tags{}
+	err := tx.RawQuery(tagsQuery).All(&tags)
+	return tags, err
+}
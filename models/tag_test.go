@@ -0,0 +1,14 @@
+package models
+
+func (ms *ModelSuite) Test_FindTags() {
+	ms.LoadFixture("teams")
+
+	tags, err := FindTags(DB)
+	ms.NoError(err)
+	ms.Equal(Tags{
+		{Name: "golang", MemberCount: 2},
+		{Name: "kubernetes", MemberCount: 1},
+		{Name: "leadership", MemberCount: 1},
+		{Name: "react", MemberCount: 1},
+	}, tags)
+}
@@ -0,0 +1,168 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gobuffalo/validate/v3/validators"
+	"github.com/gofrs/uuid"
+)
+
+// Team is a group of members, it can have a lead.
+// Teams nest under a parent team to build the organisation
+// (departments containing teams containing squads).
+type Team struct {
+	ID          uuid.UUID    `json:"id" db:"id"`
+	CreatedAt   time.Time    `json:"-" db:"created_at"`
+	UpdatedAt   time.Time    `json:"-" db:"updated_at"`
+	Name        string       `json:"name" db:"name"`
+	Description nulls.String `json:"description" db:"description" swaggertype:"string"`
+	LeadID      nulls.UUID   `json:"lead_id" db:"lead_id" swaggertype:"string"`
+	ParentID    nulls.UUID   `json:"parent_id" db:"parent_id" swaggertype:"string"`
+	Members     Members      `json:"members,omitempty" many_to_many:"team_memberships"`
+}
+
+// Teams is a list of teams
+type Teams []Team
+
+// Validate the team
+func (t *Team) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	verrs := validate.Validate(
+		&validators.StringIsPresent{Name: "Name", Field: t.Name},
+	)
+
+	exists, err := tx.Where("LOWER(name) = LOWER(?) AND id <> ?", strings.TrimSpace(t.Name), t.ID).Exists(&Team{})
+	if err != nil {
+		return verrs, err
+	}
+	if exists {
+		verrs.Add("name", "Name is already taken.")
+	}
+
+	if t.LeadID.Valid {
+		exists, err := tx.Where("id = ?", t.LeadID.UUID).Exists(&Member{})
+		if err != nil {
+			return verrs, err
+		}
+		if !exists {
+			verrs.Add("lead_id", "Lead must be a member.")
+		}
+	}
+
+	if t.ParentID.Valid {
+		if err := t.validateParent(tx, verrs); err != nil {
+			return verrs, err
+		}
+	}
+
+	return verrs, nil
+}
+
+// validateParent checks the parent exists and is not
+// the team itself or one of its sub-teams
+func (t *Team) validateParent(tx *pop.Connection, verrs *validate.Errors) error {
+	if t.ParentID.UUID == t.ID {
+		verrs.Add("parent_id", "Team cannot be its own parent.")
+		return nil
+	}
+
+	exists, err := tx.Where("id = ?", t.ParentID.UUID).Exists(&Team{})
+	if err != nil {
+		return err
+	}
+	if !exists {
+		verrs.Add("parent_id", "Parent must be a team.")
+		return nil
+	}
+
+	if t.ID == uuid.Nil {
+		return nil
+	}
+
+	ids, err := SubTeamIDs(tx, t.ID)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if id == t.ParentID.UUID {
+			verrs.Add("parent_id", "Parent cannot be one of the sub-teams.")
+		}
+	}
+
+	return nil
+}
+
+// BeforeSave (create or update), trim the name
+func (t *Team) BeforeSave(tx *pop.Connection) error {
+	t.Name = strings.TrimSpace(t.Name)
+	return nil
+}
+
+// TeamMembership assigns a member to a team
+type TeamMembership struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"-" db:"updated_at"`
+	TeamID    uuid.UUID `json:"team_id" db:"team_id"`
+	MemberID  uuid.UUID `json:"member_id" db:"member_id"`
+}
+
+// TeamMemberships is a list of team memberships
+type TeamMemberships []TeamMembership
+
+// Validate the membership, a member is only once in a team
+func (tm *TeamMembership) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	verrs := validate.NewErrors()
+
+	exists, err := tx.Where("id = ?", tm.MemberID).Exists(&Member{})
+	if err != nil {
+		return verrs, err
+	}
+	if !exists {
+		verrs.Add("member_id", "Member does not exist.")
+		return verrs, nil
+	}
+
+	exists, err = tx.Where("team_id = ? AND member_id = ?", tm.TeamID, tm.MemberID).Exists(&TeamMembership{})
+	if err != nil {
+		return verrs, err
+	}
+	if exists {
+		verrs.Add("member_id", "Member is already in the team.")
+	}
+
+	return verrs, nil
+}
+
+// subTeamsQuery selects the IDs of a team and of every team under it.
+// UNION (not UNION ALL) stops the recursion if a cycle was ever stored.
+const subTeamsQuery = `WITH RECURSIVE tree AS (
+		SELECT id FROM teams WHERE id::text = ?
+		UNION
+		SELECT teams.id FROM teams JOIN tree ON teams.parent_id = tree.id
+	) SELECT id FROM tree`
+
+// SubTeamIDs returns the IDs of the teams under a team, at any depth, the team included
+func SubTeamIDs(tx *pop.Connection, id uuid.UUID) ([]uuid.UUID, error) {
+	ids := []uuid.UUID{}
+	err := tx.RawQuery(subTeamsQuery, id.String()).All(&ids)
+	return ids, err
+}
+
+// TeamPath returns the breadcrumbs of a team: its ancestors
+// from the top of the organisation down to the team itself
+func TeamPath(tx *pop.Connection, id uuid.UUID) (Teams, error) {
+	teams := Teams{}
+	err := tx.RawQuery(`WITH RECURSIVE path AS (
+			SELECT id, parent_id, 0 AS depth, ARRAY[id] AS seen FROM teams WHERE id = ?
+			UNION ALL
+			SELECT teams.id, teams.parent_id, path.depth + 1, path.seen || teams.id
+			FROM teams JOIN path ON teams.id = path.parent_id
+			WHERE NOT teams.id = ANY(path.seen)
+		) SELECT teams.* FROM path JOIN teams ON teams.id = path.id
+		ORDER BY path.depth DESC`, id).All(&teams)
+	return teams, err
+}
@@ -0,0 +1,96 @@
+package models
+
+import (
+	"github.com/gobuffalo/nulls"
+	"github.com/gofrs/uuid"
+)
+
+func (ms *ModelSuite) Test_Team() {
+	ms.LoadFixture("teams")
+
+	lead := &Member{}
+	ms.NoError(DB.Where("name = ?", "Team Lead").First(lead))
+
+	t := &Team{Name: " Data ", LeadID: nulls.NewUUID(lead.ID)}
+	verrs, err := DB.ValidateAndCreate(t)
+	ms.NoError(err)
+	ms.Equal(false, verrs.HasAny())
+	ms.Equal("Data", t.Name)
+}
+
+func (ms *ModelSuite) Test_Team_Invalid() {
+	ms.LoadFixture("teams")
+
+	t := &Team{Name: "platform", LeadID: nulls.NewUUID(uuid.Must(uuid.NewV4()))}
+	verrs, err := DB.ValidateAndCreate(t)
+	ms.NoError(err)
+	ms.Equal(true, verrs.HasAny())
+	ms.Contains(verrs.Get("name"), "Name is already taken.")
+	ms.Contains(verrs.Get("lead_id"), "Lead must be a member.")
+}
+
+func (ms *ModelSuite) Test_Team_Members() {
+	ms.LoadFixture("teams")
+
+	t := &Team{}
+	ms.NoError(DB.Where("name = ?", "Platform").First(t))
+	ms.NoError(DB.Load(t, "Members"))
+	ms.Equal(2, len(t.Members))
+
+	m := &Member{}
+	ms.NoError(DB.Where("name = ?", "Team Contractor").First(m))
+
+	verrs, err := DB.ValidateAndCreate(&TeamMembership{TeamID: t.ID, MemberID: m.ID})
+	ms.NoError(err)
+	ms.Equal(false, verrs.HasAny())
+
+	verrs, err = DB.ValidateAndCreate(&TeamMembership{TeamID: t.ID, MemberID: m.ID})
+	ms.NoError(err)
+	ms.Contains(verrs.Get("member_id"), "Member is already in the team.")
+
+	ms.NoError(DB.Load(m, "Teams"))
+	ms.Equal(2, len(m.Teams))
+}
+
+func (ms *ModelSuite) Test_Team_Hierarchy() {
+	ms.LoadFixture("org")
+
+	engineering := &Team{}
+	ms.NoError(DB.Where("name = ?", "Engineering").First(engineering))
+	infra := &Team{}
+	ms.NoError(DB.Where("name = ?", "Infra Squad").First(infra))
+
+	ids, err := SubTeamIDs(DB, engineering.ID)
+	ms.NoError(err)
+	ms.Equal(3, len(ids))
+
+	path, err := TeamPath(DB, infra.ID)
+	ms.NoError(err)
+	ms.Equal(3, len(path))
+	ms.Equal("Engineering", path[0].Name)
+	ms.Equal("Platform", path[1].Name)
+	ms.Equal("Infra Squad", path[2].Name)
+
+	members := Members{}
+	ms.NoError(DB.Scope(MemberFilter{Unit: engineering.ID.String()}.Scope).All(&members))
+	ms.Equal(3, len(members))
+}
+
+func (ms *ModelSuite) Test_Team_Cycle() {
+	ms.LoadFixture("org")
+
+	engineering := &Team{}
+	ms.NoError(DB.Where("name = ?", "Engineering").First(engineering))
+	infra := &Team{}
+	ms.NoError(DB.Where("name = ?", "Infra Squad").First(infra))
+
+	engineering.ParentID = nulls.NewUUID(infra.ID)
+	verrs, err := DB.ValidateAndUpdate(engineering)
+	ms.NoError(err)
+	ms.Contains(verrs.Get("parent_id"), "Parent cannot be one of the sub-teams.")
+
+	engineering.ParentID = nulls.NewUUID(engineering.ID)
+	verrs, err = DB.ValidateAndUpdate(engineering)
+	ms.NoError(err)
+	ms.Contains(verrs.Get("parent_id"), "Team cannot be its own parent.")
+}
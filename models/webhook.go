@@ -0,0 +1,74 @@
+package models
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/gobuffalo/pop/slices"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gobuffalo/validate/v3/validators"
+	"github.com/gofrs/uuid"
+)
+
+const (
+	webhookSecretMinLength = 16
+)
+
+var (
+	// MemberEventTypes are the events a webhook can subscribe to
+	MemberEventTypes = []string{MemberCreated, MemberUpdated, MemberDeleted, MemberTypeChanged, MemberStatusChanged, MemberMerged, ContractExpiring, ContractExpired}
+)
+
+// Webhook is an URL notified of the member events it subscribed to,
+// the deliveries are signed with its secret
+type Webhook struct {
+	ID         uuid.UUID     `json:"id" db:"id"`
+	CreatedAt  time.Time     `json:"-" db:"created_at"`
+	UpdatedAt  time.Time     `json:"-" db:"updated_at"`
+	URL        string        `json:"url" db:"url"`
+	Secret     string        `json:"secret,omitempty" db:"secret"`
+	EventTypes slices.String `json:"event_types" db:"event_types"`
+	Disabled   bool          `json:"disabled" db:"disabled"`
+}
+
+// Webhooks is a list of webhooks
+type Webhooks []Webhook
+
+// Validate the webhook
+func (w *Webhook) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	verrs := validate.Validate(
+		&validators.StringIsPresent{Name: "URL", Field: w.URL},
+		&validators.StringLengthInRange{Name: "Secret", Field: w.Secret, Min: webhookSecretMinLength, Message: "Secret must be at least 16 characters long."},
+	)
+
+	if u, err := url.Parse(w.URL); w.URL != "" && (err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "") {
+		verrs.Add("url", "URL must be an absolute http or https URL.")
+	}
+
+	if len(w.EventTypes) == 0 {
+		verrs.Add("event_types", "Event types can not be blank.")
+	}
+
+	for _, t := range w.EventTypes {
+		if !contains(MemberEventTypes, t) {
+			verrs.Add("event_types", t+" is not an event type.")
+		}
+	}
+
+	return verrs, nil
+}
+
+// Subscribed tells if the webhook is notified of the event type
+func (w Webhook) Subscribed(eventType string) bool {
+	return !w.Disabled && contains(w.EventTypes, eventType)
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gofrs/uuid"
+)
+
+// Status of the webhook deliveries
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryDead      = "dead"
+)
+
+var (
+	// WebhookMaxAttempts is how many times a delivery is attempted
+	// before it is dead-lettered
+	WebhookMaxAttempts = 8
+	// WebhookRetryBackoff is the delay before the first retry,
+	// it doubles after each failed attempt
+	WebhookRetryBackoff = 30 * time.Second
+)
+
+// WebhookDelivery is a member event to send to a webhook.
+// Deliveries are created in the transaction changing the member (outbox),
+// then sent in the background until the webhook accepts them.
+type WebhookDelivery struct {
+	ID             uuid.UUID       `json:"id" db:"id"`
+	CreatedAt      time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time       `json:"-" db:"updated_at"`
+	WebhookID      uuid.UUID       `json:"webhook_id" db:"webhook_id"`
+	EventID        int64           `json:"event_id" db:"event_id"`
+	EventType      string          `json:"event_type" db:"event_type"`
+	Payload        json.RawMessage `json:"payload" db:"payload" swaggertype:"object"`
+	Status         string          `json:"status" db:"status" enums:"pending,delivered,dead"`
+	Attempts       int             `json:"attempts" db:"attempts"`
+	NextAttemptAt  nulls.Time      `json:"next_attempt_at" db:"next_attempt_at" swaggertype:"string"`
+	LastAttemptAt  nulls.Time      `json:"last_attempt_at" db:"last_attempt_at" swaggertype:"string"`
+	ResponseStatus int             `json:"response_status,omitempty" db:"response_status"`
+	LastError      nulls.String    `json:"last_error" db:"last_error" swaggertype:"string"`
+}
+
+// WebhookDeliveries is a list of webhook deliveries
+type WebhookDeliveries []WebhookDelivery
+
+// EnqueueWebhookDeliveries creates a pending delivery of the event
+// for each webhook subscribed to it
+func EnqueueWebhookDeliveries(tx *pop.Connection, e *MemberEvent) error {
+	webhooks := Webhooks{}
+	if err := tx.Where("disabled = false AND ? = ANY(event_types)", e.Type).All(&webhooks); err != nil {
+		return err
+	}
+
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	for _, w := range webhooks {
+		d := &WebhookDelivery{
+			WebhookID:     w.ID,
+			EventID:       e.ID,
+			EventType:     e.Type,
+			Payload:       payload,
+			Status:        DeliveryPending,
+			NextAttemptAt: nulls.NewTime(time.Now()),
+		}
+
+		if err := tx.Create(d); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// NextDueWebhookDelivery locks and returns the oldest pending delivery due at the given time.
+// Deliveries locked by another transaction are skipped, so several workers can run at once.
+// It returns false when there is nothing to deliver.
+func NextDueWebhookDelivery(tx *pop.Connection, now time.Time) (*WebhookDelivery, bool, error) {
+	deliveries := WebhookDeliveries{}
+	err := tx.RawQuery(`SELECT * FROM webhook_deliveries
+		WHERE status = ? AND next_attempt_at <= ?
+		ORDER BY next_attempt_at
+		LIMIT 1 FOR UPDATE SKIP LOCKED`, DeliveryPending, now).All(&deliveries)
+	if err != nil || len(deliveries) == 0 {
+		return nil, false, err
+	}
+
+	return &deliveries[0], true, nil
+}
+
+// Delivered records a successful attempt
+func (d *WebhookDelivery) Delivered(status int, now time.Time) {
+	d.Attempts++
+	d.Status = DeliveryDelivered
+	d.ResponseStatus = status
+	d.LastAttemptAt = nulls.NewTime(now)
+	d.NextAttemptAt = nulls.Time{}
+	d.LastError = nulls.String{}
+}
+
+// Failed records a failed attempt, the next one is scheduled with an exponential
+// backoff until the delivery runs out of attempts and is dead-lettered
+func (d *WebhookDelivery) Failed(status int, reason string, now time.Time) {
+	d.Attempts++
+	d.ResponseStatus = status
+	d.LastAttemptAt = nulls.NewTime(now)
+	d.LastError = nulls.NewString(reason)
+
+	if d.Attempts >= WebhookMaxAttempts {
+		d.Status = DeliveryDead
+		d.NextAttemptAt = nulls.Time{}
+		return
+	}
+
+	d.NextAttemptAt = nulls.NewTime(now.Add(retryBackoff(WebhookRetryBackoff, d.Attempts)))
+}
+
+// Redeliver schedules the delivery again right away,
+// with a fresh set of attempts
+func (d *WebhookDelivery) Redeliver(now time.Time) {
+	d.Status = DeliveryPending
+	d.Attempts = 0
+	d.NextAttemptAt = nulls.NewTime(now)
+}
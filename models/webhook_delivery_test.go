@@ -0,0 +1,31 @@
+package models
+
+import (
+	"net/http"
+	"time"
+)
+
+func (ms *ModelSuite) Test_WebhookDelivery_Failed() {
+	now := time.Now()
+	d := &WebhookDelivery{Status: DeliveryPending}
+
+	d.Failed(http.StatusInternalServerError, "the webhook answered 500", now)
+	ms.Equal(DeliveryPending, d.Status)
+	ms.Equal(1, d.Attempts)
+	ms.Equal(now.Add(WebhookRetryBackoff), d.NextAttemptAt.Time)
+
+	d.Failed(http.StatusInternalServerError, "the webhook answered 500", now)
+	ms.Equal(now.Add(2*WebhookRetryBackoff), d.NextAttemptAt.Time)
+
+	for d.Attempts < WebhookMaxAttempts {
+		d.Failed(0, "timeout", now)
+	}
+	ms.Equal(DeliveryDead, d.Status)
+	ms.False(d.NextAttemptAt.Valid)
+	ms.Equal("timeout", d.LastError.String)
+
+	d.Redeliver(now)
+	ms.Equal(DeliveryPending, d.Status)
+	ms.Equal(0, d.Attempts)
+	ms.Equal(now, d.NextAttemptAt.Time)
+}
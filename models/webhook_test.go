@@ -0,0 +1,56 @@
+package models
+
+import (
+	"github.com/gobuffalo/pop/slices"
+)
+
+func (ms *ModelSuite) Test_Webhook() {
+	w := &Webhook{
+		URL:        "https://example.com/hooks",
+		Secret:     "a-secret-long-enough",
+		EventTypes: slices.String{MemberCreated, MemberDeleted},
+	}
+
+	verrs, err := DB.ValidateAndCreate(w)
+	ms.NoError(err)
+	ms.Equal(false, verrs.HasAny())
+}
+
+func (ms *ModelSuite) Test_Webhook_Invalid() {
+	w := &Webhook{
+		URL:        "example.com/hooks",
+		Secret:     "short",
+		EventTypes: slices.String{"member.renamed"},
+	}
+
+	verrs, err := DB.ValidateAndCreate(w)
+	ms.NoError(err)
+	ms.Equal(true, verrs.HasAny())
+	ms.NotEmpty(verrs.Get("url"))
+	ms.NotEmpty(verrs.Get("secret"))
+	ms.NotEmpty(verrs.Get("event_types"))
+}
+
+func (ms *ModelSuite) Test_Webhook_EnqueueDeliveries() {
+	created := &Webhook{URL: "https://example.com/created", Secret: "a-secret-long-enough", EventTypes: slices.String{MemberCreated}}
+	ms.NoError(DB.Create(created))
+	deleted := &Webhook{URL: "https://example.com/deleted", Secret: "a-secret-long-enough", EventTypes: slices.String{MemberDeleted}}
+	ms.NoError(DB.Create(deleted))
+	disabled := &Webhook{URL: "https://example.com/disabled", Secret: "a-secret-long-enough", EventTypes: slices.String{MemberCreated}, Disabled: true}
+	ms.NoError(DB.Create(disabled))
+
+	m := &Member{Name: "Member Name", Type: "employee", Role: "DevOps"}
+	ms.NoError(DB.Create(m))
+
+	e := &MemberEvent{}
+	ms.NoError(DB.Where("member_id = ?", m.ID).First(e))
+	ms.NoError(EnqueueWebhookDeliveries(DB, e))
+
+	deliveries := WebhookDeliveries{}
+	ms.NoError(DB.All(&deliveries))
+	ms.Equal(1, len(deliveries))
+	ms.Equal(created.ID, deliveries[0].WebhookID)
+	ms.Equal(MemberCreated, deliveries[0].EventType)
+	ms.Equal(DeliveryPending, deliveries[0].Status)
+	ms.Contains(string(deliveries[0].Payload), m.ID.String())
+}